@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrLoaderPanicked is returned to every caller waiting on a GetOrLoad whose loader panicked
+var ErrLoaderPanicked = errors.New("cache loader panicked")
+
+// Cache is an in-memory key/value cache with optional per-entry expiry and LRU eviction. It is safe
+// for concurrent use. A cache with a TTL runs a janitor goroutine until Close is called.
+type Cache[K comparable, V any] struct {
+	config config
+
+	mutex   sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List // of *entry[K, V], most recently used first
+	loads   map[K]*load[V]
+
+	hits, misses, evictions, expirations atomic.Uint64
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero if the entry never expires
+}
+
+// load is an in-flight GetOrLoad call that concurrent callers for the same key wait on
+type load[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Stats are counters accumulated since the cache was created
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64 // entries dropped to respect WithMaxEntries
+	Expirations uint64 // entries dropped because their TTL passed
+	Entries     int
+}
+
+// HitRate returns Hits / (Hits + Misses), or 0 before any lookup
+func (s Stats) HitRate() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// New creates an empty cache
+func New[K comparable, V any](opts ...Option) *Cache[K, V] {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	c := &Cache[K, V]{
+		config:  cfg,
+		entries: map[K]*list.Element{},
+		lru:     list.New(),
+		loads:   map[K]*load[V]{},
+		stop:    make(chan struct{}),
+	}
+	interval := cfg.janitorInterval
+	if interval == 0 {
+		interval = cfg.ttl
+	}
+	if cfg.ttl > 0 && interval > 0 {
+		go c.janitor(interval)
+	}
+	return c
+}
+
+// Get returns the value cached for key, marking it as recently used
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.getLocked(key, time.Now())
+}
+
+func (c *Cache[K, V]) getLocked(key K, now time.Time) (V, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+	e := element.Value.(*entry[K, V])
+	if !e.expires.IsZero() && !now.Before(e.expires) {
+		c.removeLocked(element)
+		c.expirations.Add(1)
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+	c.lru.MoveToFront(element)
+	c.hits.Add(1)
+	return e.value, true
+}
+
+// Set caches value under key with the cache's TTL
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.config.ttl)
+}
+
+// SetWithTTL caches value under key, expiring it after ttl instead of the cache's TTL (0 never expires)
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.setLocked(key, value, ttl)
+}
+
+func (c *Cache[K, V]) setLocked(key K, value V, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[K, V])
+		e.value, e.expires = value, expires
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+	for c.config.maxEntries > 0 && c.lru.Len() > c.config.maxEntries {
+		c.removeLocked(c.lru.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// GetOrLoad returns the value cached for key, calling loader to produce and cache it on a miss.
+// Concurrent calls for the same key share a single loader call, which runs with ctx's values but
+// without its cancellation, so one caller giving up does not fail the load for the others. Callers
+// whose own ctx is done stop waiting and get ctx.Err(). Errors are returned to every waiting caller
+// but not cached; a panicking loader is reported as ErrLoaderPanicked.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader func(ctx context.Context, key K) (V, error)) (V, error) {
+	c.mutex.Lock()
+	if value, ok := c.getLocked(key, time.Now()); ok {
+		c.mutex.Unlock()
+		return value, nil
+	}
+	pending, ok := c.loads[key]
+	if !ok {
+		pending = &load[V]{done: make(chan struct{})}
+		c.loads[key] = pending
+		go c.load(context.WithoutCancel(ctx), key, pending, loader)
+	}
+	c.mutex.Unlock()
+
+	select {
+	case <-pending.done:
+		return pending.value, pending.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (c *Cache[K, V]) load(ctx context.Context, key K, pending *load[V], loader func(ctx context.Context, key K) (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero V
+			pending.value, pending.err = zero, fmt.Errorf("%w: %v", ErrLoaderPanicked, r)
+		}
+		c.mutex.Lock()
+		if pending.err == nil {
+			c.setLocked(key, pending.value, c.config.ttl)
+		}
+		delete(c.loads, key)
+		c.mutex.Unlock()
+		close(pending.done)
+	}()
+	pending.value, pending.err = loader(ctx, key)
+}
+
+// Delete removes key from the cache
+func (c *Cache[K, V]) Delete(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.removeLocked(element)
+	}
+}
+
+// Clear removes every entry
+func (c *Cache[K, V]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries = map[K]*list.Element{}
+	c.lru.Init()
+}
+
+// Len returns the number of entries, including expired ones the janitor hasn't swept yet
+func (c *Cache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns the cache's counters
+func (c *Cache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Evictions:   c.evictions.Load(),
+		Expirations: c.expirations.Load(),
+		Entries:     c.Len(),
+	}
+}
+
+// Close stops the janitor goroutine. The cache stays usable; expired entries are then only dropped
+// when they are looked up.
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() { close(c.stop) })
+}
+
+func (c *Cache[K, V]) removeLocked(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
+
+func (c *Cache[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			c.deleteExpired(now)
+		}
+	}
+}
+
+func (c *Cache[K, V]) deleteExpired(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for element := c.lru.Back(); element != nil; {
+		previous := element.Prev()
+		if e := element.Value.(*entry[K, V]); !e.expires.IsZero() && !now.Before(e.expires) {
+			c.removeLocked(element)
+			c.expirations.Add(1)
+		}
+		element = previous
+	}
+}
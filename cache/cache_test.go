@@ -0,0 +1,298 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheGetSetDelete(t *testing.T) {
+	c := New[string, int]()
+	defer c.Close()
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get() on an empty cache found a value")
+	}
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("a", 3)
+	tests := []struct {
+		key    string
+		want   int
+		wantOK bool
+	}{
+		{"a", 3, true},
+		{"b", 2, true},
+		{"c", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := c.Get(tt.key); got != tt.want || ok != tt.wantOK {
+			t.Errorf("Get(%q) = %d, %v, want %d, %v", tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+
+	c.Delete("a")
+	c.Delete("missing")
+	if _, ok := c.Get("a"); ok || c.Len() != 1 {
+		t.Errorf("after Delete(a), Get(a) found = %v and Len() = %d, want false and 1", ok, c.Len())
+	}
+	c.Clear()
+	if _, ok := c.Get("b"); ok || c.Len() != 0 {
+		t.Errorf("after Clear(), Get(b) found = %v and Len() = %d", ok, c.Len())
+	}
+}
+
+func TestCacheLRUEviction(t *testing.T) {
+	tests := []struct {
+		name     string
+		ops      func(c *Cache[int, int])
+		wantKeys []int
+		gone     []int
+	}{
+		{
+			name:     "oldest evicted",
+			ops:      func(c *Cache[int, int]) { c.Set(1, 1); c.Set(2, 2); c.Set(3, 3); c.Set(4, 4) },
+			wantKeys: []int{2, 3, 4},
+			gone:     []int{1},
+		},
+		{
+			name:     "get refreshes",
+			ops:      func(c *Cache[int, int]) { c.Set(1, 1); c.Set(2, 2); c.Set(3, 3); c.Get(1); c.Set(4, 4) },
+			wantKeys: []int{1, 3, 4},
+			gone:     []int{2},
+		},
+		{
+			name:     "overwrite refreshes",
+			ops:      func(c *Cache[int, int]) { c.Set(1, 1); c.Set(2, 2); c.Set(3, 3); c.Set(1, 10); c.Set(4, 4) },
+			wantKeys: []int{1, 3, 4},
+			gone:     []int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[int, int](WithMaxEntries(3))
+			tt.ops(c)
+			for _, k := range tt.gone {
+				if _, ok := c.Get(k); ok {
+					t.Errorf("key %d was not evicted", k)
+				}
+			}
+			for _, k := range tt.wantKeys {
+				if _, ok := c.Get(k); !ok {
+					t.Errorf("key %d was evicted", k)
+				}
+			}
+			if s := c.Stats(); s.Evictions != uint64(len(tt.gone)) || s.Entries != 3 {
+				t.Errorf("Stats() = %+v, want %d evictions and 3 entries", s, len(tt.gone))
+			}
+		})
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	c := New[string, string](WithTTL(30*time.Millisecond), WithJanitorInterval(0))
+	c.Set("short", "x")
+	c.SetWithTTL("forever", "y", 0)
+	c.SetWithTTL("long", "z", time.Hour)
+
+	if _, ok := c.Get("short"); !ok {
+		t.Fatal("Get() before the TTL passed missed")
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get() after the TTL passed found the entry")
+	}
+	for _, key := range []string{"forever", "long"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%q) missed, want its own TTL to apply", key)
+		}
+	}
+	if s := c.Stats(); s.Expirations != 1 || s.Entries != 2 {
+		t.Errorf("Stats() = %+v, want 1 expiration and 2 entries", s)
+	}
+}
+
+func TestCacheDeleteExpired(t *testing.T) {
+	c := New[int, int](WithJanitorInterval(0))
+	c.SetWithTTL(1, 1, time.Minute)
+	c.SetWithTTL(2, 2, time.Hour)
+	c.SetWithTTL(3, 3, 0)
+
+	c.deleteExpired(time.Now().Add(2 * time.Minute))
+	if c.Len() != 2 {
+		t.Errorf("Len() after sweeping = %d, want 2", c.Len())
+	}
+	if _, ok := c.Get(1); ok {
+		t.Error("the expired entry survived the sweep")
+	}
+	if c.Stats().Expirations != 1 {
+		t.Errorf("Expirations = %d, want 1", c.Stats().Expirations)
+	}
+}
+
+func TestCacheJanitor(t *testing.T) {
+	c := New[int, int](WithTTL(10*time.Millisecond), WithJanitorInterval(5*time.Millisecond))
+	defer c.Close()
+	for i := 0; i < 10; i++ {
+		c.Set(i, i)
+	}
+	deadline := time.Now().Add(time.Second)
+	for c.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d, want the janitor to sweep every expired entry", c.Len())
+	}
+	if s := c.Stats(); s.Expirations != 10 || s.Misses != 0 {
+		t.Errorf("Stats() = %+v, want 10 expirations without lookups", s)
+	}
+	c.Close()
+	c.Close()
+}
+
+func TestStatsHitRate(t *testing.T) {
+	tests := []struct {
+		stats Stats
+		want  float64
+	}{
+		{Stats{}, 0},
+		{Stats{Hits: 3, Misses: 1}, 0.75},
+		{Stats{Misses: 5}, 0},
+		{Stats{Hits: 2}, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.stats.HitRate(); got != tt.want {
+			t.Errorf("%+v.HitRate() = %v, want %v", tt.stats, got, tt.want)
+		}
+	}
+
+	c := New[string, int]()
+	c.Set("a", 1)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	if s := c.Stats(); s.Hits != 2 || s.Misses != 1 || s.Entries != 1 {
+		t.Errorf("Stats() = %+v, want 2 hits, 1 miss and 1 entry", s)
+	}
+}
+
+func TestGetOrLoadDeduplicates(t *testing.T) {
+	c := New[string, int]()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context, key string) (int, error) {
+		calls.Add(1)
+		<-release
+		return len(key), nil
+	}
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.GetOrLoad(context.Background(), "abcd", loader)
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("loader ran %d times for concurrent callers, want 1", calls.Load())
+	}
+	for i := range results {
+		if results[i] != 4 || errs[i] != nil {
+			t.Errorf("caller %d got %d, %v, want 4, nil", i, results[i], errs[i])
+		}
+	}
+	if v, err := c.GetOrLoad(context.Background(), "abcd", loader); v != 4 || err != nil || calls.Load() != 1 {
+		t.Errorf("GetOrLoad() after loading = %d, %v with %d calls, want a cache hit", v, err, calls.Load())
+	}
+}
+
+func TestGetOrLoadErrors(t *testing.T) {
+	errLoad := errors.New("backend down")
+	tests := []struct {
+		name    string
+		loader  func(context.Context, string) (int, error)
+		wantErr error
+	}{
+		{"error", func(context.Context, string) (int, error) { return 7, errLoad }, errLoad},
+		{"panic", func(context.Context, string) (int, error) { panic("boom") }, ErrLoaderPanicked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[string, int]()
+			if _, err := c.GetOrLoad(context.Background(), "k", tt.loader); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetOrLoad() error = %v, want %v", err, tt.wantErr)
+			}
+			if c.Len() != 0 {
+				t.Error("a failed load was cached")
+			}
+			// the failure isn't remembered, so the next call loads again
+			v, err := c.GetOrLoad(context.Background(), "k", func(context.Context, string) (int, error) { return 1, nil })
+			if v != 1 || err != nil {
+				t.Errorf("GetOrLoad() after a failure = %d, %v, want 1, nil", v, err)
+			}
+		})
+	}
+}
+
+func TestGetOrLoadCallerContext(t *testing.T) {
+	c := New[string, string]()
+	release := make(chan struct{})
+	loaderCtx := make(chan context.Context, 1)
+	loader := func(ctx context.Context, key string) (string, error) {
+		loaderCtx <- ctx
+		<-release
+		return "loaded", nil
+	}
+
+	type ctxKey struct{}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), ctxKey{}, "v"), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetOrLoad(ctx, "k", loader); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetOrLoad() with an expiring ctx error = %v, want DeadlineExceeded", err)
+	}
+
+	got := <-loaderCtx
+	if got.Value(ctxKey{}) != "v" || got.Err() != nil {
+		t.Errorf("loader ctx value = %v, err = %v, want the caller's values without its cancellation", got.Value(ctxKey{}), got.Err())
+	}
+
+	// a second caller joins the load the first one gave up on
+	done := make(chan string)
+	go func() {
+		v, _ := c.GetOrLoad(context.Background(), "k", loader)
+		done <- v
+	}()
+	close(release)
+	if v := <-done; v != "loaded" {
+		t.Errorf("GetOrLoad() joining a pending load = %q, want loaded", v)
+	}
+	if len(loaderCtx) != 0 {
+		t.Error("the second caller started another load")
+	}
+}
+
+func BenchmarkCacheGet(b *testing.B) {
+	c := New[int, int](WithMaxEntries(1024))
+	for i := 0; i < 1024; i++ {
+		c.Set(i, i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Get(i & 1023)
+	}
+}
@@ -0,0 +1,39 @@
+package cache
+
+import "time"
+
+// Option configures a Cache built by New
+type Option func(*config)
+
+type config struct {
+	ttl             time.Duration
+	maxEntries      int
+	janitorInterval time.Duration
+}
+
+// WithTTL expires entries d after they were set. By default entries never expire.
+func WithTTL(d time.Duration) Option {
+	return func(c *config) {
+		c.ttl = max(d, 0)
+	}
+}
+
+// WithMaxEntries caps the cache at n entries, evicting the least recently used one to make room.
+// By default the cache is unbounded.
+func WithMaxEntries(n int) Option {
+	return func(c *config) {
+		c.maxEntries = max(n, 0)
+	}
+}
+
+// WithJanitorInterval sets how often a background goroutine sweeps out expired entries (default: the TTL).
+// Expired entries are never returned either way; the janitor only frees their memory sooner. Zero or
+// negative disables it.
+func WithJanitorInterval(d time.Duration) Option {
+	return func(c *config) {
+		if d <= 0 {
+			d = -1
+		}
+		c.janitorInterval = d
+	}
+}
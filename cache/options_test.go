@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want config
+	}{
+		{"defaults", nil, config{}},
+		{"ttl", []Option{WithTTL(time.Minute)}, config{ttl: time.Minute}},
+		{"negative ttl", []Option{WithTTL(-time.Second)}, config{}},
+		{"max entries", []Option{WithMaxEntries(10)}, config{maxEntries: 10}},
+		{"negative max entries", []Option{WithMaxEntries(-1)}, config{}},
+		{"janitor interval", []Option{WithJanitorInterval(time.Second)}, config{janitorInterval: time.Second}},
+		{"janitor disabled", []Option{WithJanitorInterval(0)}, config{janitorInterval: -1}},
+		{"later wins", []Option{WithTTL(time.Second), WithTTL(time.Hour)}, config{ttl: time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[string, int](tt.opts...)
+			defer c.Close()
+			if c.config != tt.want {
+				t.Errorf("config = %+v, want %+v", c.config, tt.want)
+			}
+		})
+	}
+}
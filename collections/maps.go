@@ -0,0 +1,31 @@
+package collections
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Keys returns the keys of m in unspecified order; use SortedKeys for a stable order
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// SortedKeys returns the keys of m in ascending order
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// Values returns the values of m in unspecified order
+func Values[M ~map[K]V, K comparable, V any](m M) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
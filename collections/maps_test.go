@@ -0,0 +1,61 @@
+package collections
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		in   map[string]int
+		want []string
+	}{
+		{"nil", nil, []string{}},
+		{"values", map[string]int{"b": 2, "a": 1, "c": 3}, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Keys(tt.in)
+			slices.Sort(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keys() = %#v, want %#v", got, tt.want)
+			}
+			if sorted := SortedKeys(tt.in); !reflect.DeepEqual(sorted, tt.want) {
+				t.Errorf("SortedKeys() = %#v, want %#v", sorted, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortedKeysIsStable(t *testing.T) {
+	m := map[int]bool{}
+	for i := 100; i > 0; i-- {
+		m[i] = true
+	}
+	keys := SortedKeys(m)
+	if len(keys) != 100 || !slices.IsSorted(keys) {
+		t.Errorf("SortedKeys() = %v, want 1..100 in order", keys)
+	}
+}
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		name string
+		in   map[string]int
+		want []int
+	}{
+		{"nil", nil, []int{}},
+		{"duplicates kept", map[string]int{"a": 1, "b": 2, "c": 1}, []int{1, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Values(tt.in)
+			slices.Sort(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
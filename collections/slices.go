@@ -0,0 +1,123 @@
+package collections
+
+// Map returns a new slice holding fn applied to each element of s
+func Map[S ~[]E, E, R any](s S, fn func(E) R) []R {
+	if s == nil {
+		return nil
+	}
+	out := make([]R, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Filter returns a new slice holding the elements of s for which keep returns true, in order
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	if s == nil {
+		return nil
+	}
+	out := make(S, 0, len(s))
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s from left to right, starting from initial
+func Reduce[S ~[]E, E, A any](s S, initial A, fn func(acc A, v E) A) A {
+	acc := initial
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Chunk splits s into consecutive slices of size elements, the last one possibly shorter.
+// The chunks share s's backing array but are capped, so appending to one can't overwrite the next.
+// It panics if size is not positive.
+func Chunk[S ~[]E, E any](s S, size int) []S {
+	if size <= 0 {
+		panic("collections: Chunk size must be positive")
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	chunks := make([]S, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := min(start+size, len(s))
+		chunks = append(chunks, s[start:end:end])
+	}
+	return chunks
+}
+
+// Uniq returns the elements of s with duplicates removed, keeping the first occurrence of each
+func Uniq[S ~[]E, E comparable](s S) S {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[E]struct{}, len(s))
+	out := make(S, 0, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// UniqBy is Uniq comparing elements by key(v), e.g. UniqBy(users, func(u User) string { return u.ID })
+func UniqBy[S ~[]E, E any, K comparable](s S, key func(E) K) S {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[K]struct{}, len(s))
+	out := make(S, 0, len(s))
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// GroupBy groups the elements of s by key(v), keeping their order within each group
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]S {
+	groups := make(map[K]S)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Difference returns the elements of a that are not in b, in a's order and keeping a's duplicates
+func Difference[S ~[]E, E comparable](a, b S) S {
+	exclude := toSet(b)
+	return Filter(a, func(v E) bool {
+		_, ok := exclude[v]
+		return !ok
+	})
+}
+
+// Intersect returns the distinct elements present in both a and b, in a's order
+func Intersect[S ~[]E, E comparable](a, b S) S {
+	include := toSet(b)
+	return Uniq(Filter(a, func(v E) bool {
+		_, ok := include[v]
+		return ok
+	}))
+}
+
+func toSet[S ~[]E, E comparable](s S) map[E]struct{} {
+	set := make(map[E]struct{}, len(s))
+	for _, v := range s {
+		set[v] = struct{}{}
+	}
+	return set
+}
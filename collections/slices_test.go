@@ -0,0 +1,241 @@
+package collections
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+type user struct {
+	ID   string
+	Team string
+}
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"empty", []int{}, []string{}},
+		{"values", []int{1, 22, -3}, []string{"1", "22", "-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Map(tt.in, strconv.Itoa); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Map() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"nil", nil, nil},
+		{"none kept", []int{1, 3}, []int{}},
+		{"order kept", []int{4, 1, 2, 3, 6}, []int{4, 2, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Filter(tt.in, even); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterDoesNotAlias(t *testing.T) {
+	in := []int{1, 2, 3}
+	out := Filter(in, func(int) bool { return true })
+	out[0] = 100
+	if in[0] != 1 {
+		t.Errorf("Filter() result shares memory with its input: %v", in)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want string
+	}{
+		{"nil returns initial", nil, ">"},
+		{"left to right", []string{"a", "b", "c"}, ">abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reduce(tt.in, ">", func(acc, v string) string { return acc + v })
+			if got != tt.want {
+				t.Errorf("Reduce() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	sum := Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v })
+	if sum != 10 {
+		t.Errorf("Reduce(sum) = %d, want 10", sum)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		size int
+		want [][]int
+	}{
+		{"nil", nil, 2, nil},
+		{"empty", []int{}, 2, nil},
+		{"even split", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"short last chunk", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"size larger than slice", []int{1, 2}, 10, [][]int{{1, 2}}},
+		{"size one", []int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chunk(tt.in, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk(%v, %d) = %v, want %v", tt.in, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkAppendDoesNotOverwrite(t *testing.T) {
+	chunks := Chunk([]int{1, 2, 3, 4}, 2)
+	_ = append(chunks[0], 99)
+	if chunks[1][0] != 3 {
+		t.Errorf("appending to the first chunk overwrote the second: %v", chunks[1])
+	}
+}
+
+func TestChunkPanicsOnInvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Chunk(size %d) did not panic", size)
+				}
+			}()
+			Chunk([]int{1}, size)
+		}()
+	}
+}
+
+func TestUniq(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"empty", []string{}, []string{}},
+		{"no duplicates", []string{"a", "b"}, []string{"a", "b"}},
+		{"first occurrence kept", []string{"b", "a", "b", "c", "a"}, []string{"b", "a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Uniq(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Uniq() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqBy(t *testing.T) {
+	users := []user{{"1", "red"}, {"2", "blue"}, {"1", "green"}, {"3", "red"}}
+	tests := []struct {
+		name string
+		key  func(user) string
+		want []user
+	}{
+		{"by id", func(u user) string { return u.ID }, []user{{"1", "red"}, {"2", "blue"}, {"3", "red"}}},
+		{"by team", func(u user) string { return u.Team }, []user{{"1", "red"}, {"2", "blue"}, {"1", "green"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UniqBy(users, tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UniqBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := UniqBy([]user(nil), func(u user) string { return u.ID }); got != nil {
+		t.Errorf("UniqBy(nil) = %v, want nil", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	users := []user{{"1", "red"}, {"2", "blue"}, {"3", "red"}, {"4", "red"}}
+	got := GroupBy(users, func(u user) string { return u.Team })
+	want := map[string][]user{
+		"red":  {{"1", "red"}, {"3", "red"}, {"4", "red"}},
+		"blue": {{"2", "blue"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy() = %v, want %v", got, want)
+	}
+
+	if got := GroupBy([]user(nil), func(u user) string { return u.Team }); got == nil || len(got) != 0 {
+		t.Errorf("GroupBy(nil) = %#v, want an empty map", got)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want []int
+	}{
+		{"nil a", nil, []int{1}, nil},
+		{"nil b keeps a", []int{1, 2}, nil, []int{1, 2}},
+		{"removes b", []int{1, 2, 3, 4}, []int{2, 4}, []int{1, 3}},
+		{"keeps duplicates of a", []int{1, 1, 2, 3, 3}, []int{2}, []int{1, 1, 3, 3}},
+		{"everything removed", []int{1, 2}, []int{2, 1}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Difference(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Difference(%v, %v) = %#v, want %#v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want []string
+	}{
+		{"nil a", nil, []string{"x"}, nil},
+		{"disjoint", []string{"a"}, []string{"b"}, []string{}},
+		{"a's order", []string{"c", "a", "b"}, []string{"a", "b", "c"}, []string{"c", "a", "b"}},
+		{"distinct", []string{"a", "b", "a", "b"}, []string{"b", "a", "a"}, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Intersect(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Intersect(%v, %v) = %#v, want %#v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// named slice types keep their type through the helpers that return S
+type ids []string
+
+func TestNamedSliceTypes(t *testing.T) {
+	in := ids{"b", "a", "b"}
+	var uniq ids = Uniq(in)
+	var diff ids = Difference(in, ids{"a"})
+	var chunks []ids = Chunk(in, 2)
+	if !slices.Equal(uniq, ids{"b", "a"}) || !slices.Equal(diff, ids{"b", "b"}) || len(chunks) != 2 {
+		t.Errorf("Uniq() = %v, Difference() = %v, Chunk() = %v", uniq, diff, chunks)
+	}
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/json"
+)
+
+var (
+	ErrInvalidTarget = errors.New("config target must be a non-nil pointer to a struct")
+	ErrRequired      = errors.New("required environment variable is not set")
+)
+
+// FieldError is a field Load couldn't populate: Field is its Go path such as "DB.URL" and Env the
+// variable it is read from
+type FieldError struct {
+	Field string
+	Env   string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Field, e.Env, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Option customizes Load
+type Option func(*loader)
+
+type loader struct {
+	prefix   string
+	lookup   func(key string) (string, bool)
+	readFile func(path string) ([]byte, error)
+}
+
+// WithPrefix prepends prefix to every variable name, e.g. WithPrefix("BILLING_") reads BILLING_DB_URL for `env:"DB_URL"`
+func WithPrefix(prefix string) Option {
+	return func(l *loader) {
+		l.prefix = prefix
+	}
+}
+
+// WithLookup reads variables with lookup instead of os.LookupEnv, e.g. from a map in tests
+func WithLookup(lookup func(key string) (string, bool)) Option {
+	return func(l *loader) {
+		l.lookup = lookup
+	}
+}
+
+// Load populates the struct dst points to from environment variables, driven by field tags:
+//
+//	URL      string        `env:"DB_URL" required:"true"`
+//	Timeout  time.Duration `env:"DB_TIMEOUT" default:"5s"`
+//	Password string        `env:"DB_PASSWORD" envFile:"DB_PASSWORD_FILE"`
+//	Hosts    []string      `env:"DB_HOSTS" envSeparator:";"`
+//	Replica  DBConfig      `envPrefix:"REPLICA_"`
+//
+// A variable that is unset falls back to the file named by the envFile variable (for secrets mounted
+// as files, trailing newline trimmed), then to default; a required field with none of those is an error.
+// Strings, booleans, numbers, time.Duration, encoding.TextUnmarshaler implementations and slices of
+// those (comma-separated unless envSeparator says otherwise) are parsed from the text; any other type,
+// such as a map or a struct with an env tag, is decoded as JSON with the json package. Struct fields
+// without an env tag are populated recursively, with envPrefix prepended to the names inside them.
+// Pointer fields with an env tag are allocated only when a value is found; nested struct pointers always are.
+//
+// Load reports every missing or invalid field at once, as *FieldError values joined with errors.Join.
+func Load(dst any, opts ...Option) error {
+	l := loader{lookup: os.LookupEnv, readFile: os.ReadFile}
+	for _, opt := range opts {
+		opt(&l)
+	}
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	var errs []error
+	l.loadStruct(v.Elem(), l.prefix, "", &errs)
+	return errors.Join(errs...)
+}
+
+func (l *loader) loadStruct(v reflect.Value, prefix, path string, errs *[]error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		fieldValue := v.Field(i)
+
+		name, hasEnv := field.Tag.Lookup("env")
+		if !hasEnv {
+			if isNestedStruct(field.Type) {
+				target := fieldValue
+				if field.Type.Kind() == reflect.Pointer {
+					if target.IsNil() {
+						target.Set(reflect.New(field.Type.Elem()))
+					}
+					target = target.Elem()
+				}
+				l.loadStruct(target, prefix+field.Tag.Get("envPrefix"), fieldPath, errs)
+			}
+			continue
+		}
+
+		env := prefix + name
+		fail := func(err error) {
+			*errs = append(*errs, &FieldError{Field: fieldPath, Env: env, Err: err})
+		}
+		raw, ok := l.lookup(env)
+		if !ok {
+			if fileEnv := field.Tag.Get("envFile"); fileEnv != "" {
+				if file, found := l.lookup(prefix + fileEnv); found {
+					content, err := l.readFile(file)
+					if err != nil {
+						fail(fmt.Errorf("reading %s: %w", prefix+fileEnv, err))
+						continue
+					}
+					raw, ok = strings.TrimRight(string(content), "\r\n"), true
+				}
+			}
+		}
+		if !ok {
+			raw, ok = field.Tag.Lookup("default")
+		}
+		if !ok {
+			if field.Tag.Get("required") == "true" {
+				fail(ErrRequired)
+			}
+			continue
+		}
+		if err := setField(fieldValue, raw, field.Tag.Get("envSeparator")); err != nil {
+			fail(err)
+		}
+	}
+}
+
+// isNestedStruct reports whether a field of type t without an env tag should be recursed into
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType) && t != timeType
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+func setField(v reflect.Value, raw, separator string) error {
+	if v.Kind() == reflect.Pointer {
+		target := reflect.New(v.Type().Elem())
+		if err := setField(target.Elem(), raw, separator); err != nil {
+			return err
+		}
+		v.Set(target)
+		return nil
+	}
+	if v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if isScalar(v.Type().Elem()) {
+			return setSlice(v, raw, separator)
+		}
+		return setJSON(v, raw)
+	default:
+		return setJSON(v, raw)
+	}
+	return nil
+}
+
+// isScalar reports whether values of t are parsed from plain text rather than JSON
+func isScalar(t reflect.Type) bool {
+	if t == durationType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func setSlice(v reflect.Value, raw, separator string) error {
+	if separator == "" {
+		separator = ","
+	}
+	if strings.TrimSpace(raw) == "" {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		return nil
+	}
+	parts := strings.Split(raw, separator)
+	slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := setField(slice.Index(i), strings.TrimSpace(part), ""); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+func setJSON(v reflect.Value, raw string) error {
+	target := reflect.New(v.Type())
+	if err := json.CurrentBackend().Unmarshal([]byte(raw), target.Interface()); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	v.Set(target.Elem())
+	return nil
+}
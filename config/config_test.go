@@ -0,0 +1,238 @@
+package config
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mapLookup reads variables from env instead of the process environment
+func mapLookup(env map[string]string) Option {
+	return WithLookup(func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	})
+}
+
+type dbConfig struct {
+	URL     string        `env:"DB_URL" required:"true"`
+	Timeout time.Duration `env:"DB_TIMEOUT" default:"5s"`
+	Pool    int           `env:"DB_POOL" default:"10"`
+}
+
+type appConfig struct {
+	Name     string            `env:"NAME" default:"app"`
+	Debug    bool              `env:"DEBUG"`
+	Ratio    float64           `env:"RATIO"`
+	Port     uint16            `env:"PORT" default:"8080"`
+	Hosts    []string          `env:"HOSTS"`
+	Weights  []int             `env:"WEIGHTS" envSeparator:";"`
+	Waits    []time.Duration   `env:"WAITS"`
+	Labels   map[string]string `env:"LABELS"`
+	Addr     netip.Addr        `env:"ADDR" default:"127.0.0.1"`
+	Limit    *int              `env:"LIMIT"`
+	DB       dbConfig
+	Replica  *dbConfig `envPrefix:"REPLICA_"`
+	internal string    `env:"INTERNAL"`
+}
+
+func TestLoad(t *testing.T) {
+	env := map[string]string{
+		"DEBUG":           "true",
+		"RATIO":           "0.25",
+		"HOSTS":           "a.example, b.example ,c.example",
+		"WEIGHTS":         "1;2;3",
+		"WAITS":           "1s,250ms",
+		"LABELS":          `{"team":"core","tier":"gold"}`,
+		"LIMIT":           "0x10",
+		"DB_URL":          "postgres://primary",
+		"DB_TIMEOUT":      "2m",
+		"REPLICA_DB_URL":  "postgres://replica",
+		"REPLICA_DB_POOL": "3",
+		"INTERNAL":        "ignored",
+	}
+	var cfg appConfig
+	if err := Load(&cfg, mapLookup(env)); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	limit := 16
+	want := appConfig{
+		Name:    "app",
+		Debug:   true,
+		Ratio:   0.25,
+		Port:    8080,
+		Hosts:   []string{"a.example", "b.example", "c.example"},
+		Weights: []int{1, 2, 3},
+		Waits:   []time.Duration{time.Second, 250 * time.Millisecond},
+		Labels:  map[string]string{"team": "core", "tier": "gold"},
+		Addr:    netip.MustParseAddr("127.0.0.1"),
+		Limit:   &limit,
+		DB:      dbConfig{URL: "postgres://primary", Timeout: 2 * time.Minute, Pool: 10},
+		Replica: &dbConfig{URL: "postgres://replica", Timeout: 5 * time.Second, Pool: 3},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() =\n%+v\nwant\n%+v", cfg, want)
+	}
+}
+
+func TestLoadUnsetPointerAndEmptySlice(t *testing.T) {
+	var cfg appConfig
+	env := map[string]string{"DB_URL": "x", "REPLICA_DB_URL": "y", "HOSTS": " "}
+	if err := Load(&cfg, mapLookup(env)); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Limit != nil {
+		t.Errorf("Limit = %v, want nil when LIMIT is unset", *cfg.Limit)
+	}
+	if cfg.Hosts == nil || len(cfg.Hosts) != 0 {
+		t.Errorf("Hosts = %#v, want an empty slice for a blank value", cfg.Hosts)
+	}
+	if cfg.Weights != nil {
+		t.Errorf("Weights = %#v, want nil when unset", cfg.Weights)
+	}
+}
+
+func TestLoadWithPrefix(t *testing.T) {
+	var cfg struct {
+		DB    dbConfig `envPrefix:"MAIN_"`
+		Level string   `env:"LEVEL"`
+	}
+	env := map[string]string{"SVC_MAIN_DB_URL": "postgres://svc", "SVC_LEVEL": "warn", "LEVEL": "debug"}
+	if err := Load(&cfg, WithPrefix("SVC_"), mapLookup(env)); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DB.URL != "postgres://svc" || cfg.Level != "warn" {
+		t.Errorf("Load() = %+v, want the SVC_ variables", cfg)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	type target struct {
+		URL    string            `env:"URL" required:"true"`
+		Port   int               `env:"PORT"`
+		Small  int8              `env:"SMALL"`
+		Wait   time.Duration     `env:"WAIT"`
+		On     bool              `env:"ON"`
+		IDs    []uint            `env:"IDS"`
+		Labels map[string]string `env:"LABELS"`
+		Addr   netip.Addr        `env:"ADDR"`
+		Nested struct {
+			Key string `env:"KEY" required:"true"`
+		} `envPrefix:"NESTED_"`
+	}
+	env := map[string]string{
+		"PORT":   "eighty",
+		"SMALL":  "300",
+		"WAIT":   "5 parsecs",
+		"ON":     "maybe",
+		"IDS":    "1,-2",
+		"LABELS": `{"a":`,
+		"ADDR":   "not-an-ip",
+	}
+	var cfg target
+	err := Load(&cfg, mapLookup(env))
+	if err == nil {
+		t.Fatal("Load() returned no error")
+	}
+
+	var fieldErrs []*FieldError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fe *FieldError
+		if !errors.As(e, &fe) {
+			t.Fatalf("Load() joined %T, want *FieldError", e)
+		}
+		fieldErrs = append(fieldErrs, fe)
+	}
+	wantFields := []string{"URL", "Port", "Small", "Wait", "On", "IDs", "Labels", "Addr", "Nested.Key"}
+	wantEnvs := []string{"URL", "PORT", "SMALL", "WAIT", "ON", "IDS", "LABELS", "ADDR", "NESTED_KEY"}
+	if len(fieldErrs) != len(wantFields) {
+		t.Fatalf("Load() reported %d errors, want %d:\n%v", len(fieldErrs), len(wantFields), err)
+	}
+	for i, fe := range fieldErrs {
+		if fe.Field != wantFields[i] || fe.Env != wantEnvs[i] {
+			t.Errorf("error %d is for %s (%s), want %s (%s)", i, fe.Field, fe.Env, wantFields[i], wantEnvs[i])
+		}
+	}
+	if !errors.Is(fieldErrs[0], ErrRequired) || !errors.Is(fieldErrs[8], ErrRequired) {
+		t.Error("missing required fields are not ErrRequired")
+	}
+	if msg := fieldErrs[5].Error(); !strings.HasPrefix(msg, "IDs (IDS): item 1: ") {
+		t.Errorf("slice error = %q, want the failed item's index", msg)
+	}
+	if msg := fieldErrs[6].Error(); !strings.Contains(msg, "invalid JSON") {
+		t.Errorf("map error = %q, want it reported as invalid JSON", msg)
+	}
+}
+
+func TestLoadInvalidTarget(t *testing.T) {
+	var s struct{}
+	var nilPtr *struct{}
+	n := 1
+	tests := []struct {
+		name string
+		dst  any
+	}{
+		{"nil", nil},
+		{"struct value", s},
+		{"nil pointer", nilPtr},
+		{"pointer to int", &n},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Load(tt.dst); !errors.Is(err, ErrInvalidTarget) {
+				t.Errorf("Load() error = %v, want ErrInvalidTarget", err)
+			}
+		})
+	}
+}
+
+func TestLoadSecretFile(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "password")
+	if err := os.WriteFile(secret, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type target struct {
+		Password string `env:"PASSWORD" envFile:"PASSWORD_FILE" required:"true"`
+	}
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    string
+		wantErr error
+	}{
+		{"from file, newline trimmed", map[string]string{"PASSWORD_FILE": secret}, "s3cret", nil},
+		{"variable wins over file", map[string]string{"PASSWORD": "direct", "PASSWORD_FILE": secret}, "direct", nil},
+		{"missing file", map[string]string{"PASSWORD_FILE": filepath.Join(dir, "absent")}, "", os.ErrNotExist},
+		{"neither set", map[string]string{}, "", ErrRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg target
+			err := Load(&cfg, mapLookup(tt.env))
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("Load() error = %v, want %v", err, tt.wantErr)
+			}
+			if cfg.Password != tt.want {
+				t.Errorf("Password = %q, want %q", cfg.Password, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFromProcessEnvironment(t *testing.T) {
+	t.Setenv("EASY_CONFIG_TEST_URL", "from-env")
+	var cfg struct {
+		URL string `env:"EASY_CONFIG_TEST_URL"`
+	}
+	if err := Load(&cfg); err != nil || cfg.URL != "from-env" {
+		t.Errorf("Load() = %+v, %v, want the process environment read", cfg, err)
+	}
+}
@@ -0,0 +1,161 @@
+package cryptox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Tealseed-Lab/easy_go_lib/hash"
+	"github.com/Tealseed-Lab/easy_go_lib/id_gen"
+)
+
+// KeySize is the length of the AES-256 keys a Keyring holds
+const KeySize = 32
+
+var (
+	ErrInvalidKey   = errors.New("invalid key")
+	ErrUnknownKey   = errors.New("unknown key ID")
+	ErrDecrypt      = errors.New("message could not be decrypted")
+	ErrInvalidToken = errors.New("invalid signed token")
+	ErrTokenExpired = errors.New("signed token has expired")
+)
+
+// Keyring holds named 256-bit keys: new messages are sealed and tokens signed with the primary key,
+// while any key in the ring can still open or verify what it produced, so keys can be rotated by adding
+// a new primary and retiring the old key once its messages have expired or been re-sealed.
+// A Keyring is immutable and safe for concurrent use.
+type Keyring struct {
+	primary string
+	keys    map[string]*ringKey
+}
+
+type ringKey struct {
+	aead    cipher.AEAD
+	signing []byte // HMAC key derived from the master key, so encryption and signing never share a key
+}
+
+// NewKeyring builds a keyring from keys, each exactly KeySize random bytes, keyed by an ID of
+// 1-32 characters of [A-Za-z0-9_-] (e.g. "2024-05" or "k2"). primary must be one of the IDs.
+// The key bytes are copied.
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: primary key %q is not in the keyring", ErrInvalidKey, primary)
+	}
+	ring := &Keyring{primary: primary, keys: make(map[string]*ringKey, len(keys))}
+	for id, key := range keys {
+		if !isValidKeyID(id) {
+			return nil, fmt.Errorf("%w: key ID %q must be 1-32 characters of [A-Za-z0-9_-]", ErrInvalidKey, id)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("%w: key %q is %d bytes, want %d", ErrInvalidKey, id, len(key), KeySize)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		ring.keys[id] = &ringKey{aead: aead, signing: hash.Sign(key, []byte("cryptox token signing"))}
+	}
+	return ring, nil
+}
+
+// GenerateKey returns KeySize bytes from crypto/rand, suitable for NewKeyring
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// PrimaryKeyID returns the ID of the key used for sealing and signing
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+// Seal encrypts plaintext with AES-256-GCM under the primary key and a random nonce, authenticating
+// associatedData (which must be passed to Open unchanged but isn't stored) along with it. The result
+// starts with the key ID, so Open knows which key to use after a rotation.
+func (k *Keyring) Seal(plaintext, associatedData []byte) ([]byte, error) {
+	key := k.keys[k.primary]
+	nonce := make([]byte, key.aead.NonceSize(), key.aead.NonceSize()+len(plaintext)+key.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := key.aead.Seal(nonce, nonce, plaintext, sealAAD(k.primary, associatedData))
+	return append(append([]byte{byte(len(k.primary))}, k.primary...), sealed...), nil
+}
+
+// Open decrypts a message from Seal. Every failure other than an unknown key ID is reported as
+// ErrDecrypt, without saying whether the key, the data or the associated data was wrong.
+func (k *Keyring) Open(sealed, associatedData []byte) ([]byte, error) {
+	if len(sealed) == 0 || len(sealed) < 1+int(sealed[0]) {
+		return nil, ErrDecrypt
+	}
+	id := string(sealed[1 : 1+sealed[0]])
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	body := sealed[1+len(id):]
+	if len(body) < key.aead.NonceSize()+key.aead.Overhead() {
+		return nil, ErrDecrypt
+	}
+	nonce, ciphertext := body[:key.aead.NonceSize()], body[key.aead.NonceSize():]
+	plaintext, err := key.aead.Open(nil, nonce, ciphertext, sealAAD(id, associatedData))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// SealString encrypts plaintext like Seal and returns it as "keyID.base62", safe for URLs, cookies and
+// database text columns
+func (k *Keyring) SealString(plaintext string) (string, error) {
+	sealed, err := k.Seal([]byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return k.primary + "." + id_gen.EncodeBase62Bytes(sealed[1+len(k.primary):]), nil
+}
+
+// OpenString decrypts a string from SealString
+func (k *Keyring) OpenString(sealed string) (string, error) {
+	id, body, ok := strings.Cut(sealed, ".")
+	if !ok || !isValidKeyID(id) {
+		return "", ErrDecrypt
+	}
+	decoded, err := id_gen.DecodeBase62Bytes(body)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	plaintext, err := k.Open(append(append([]byte{byte(len(id))}, id...), decoded...), nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// sealAAD binds the key ID into the authenticated data, so a message can't be relabeled with another key ID
+func sealAAD(id string, associatedData []byte) []byte {
+	return append(append([]byte(id), 0), associatedData...)
+}
+
+func isValidKeyID(id string) bool {
+	if id == "" || len(id) > 32 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package cryptox
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, KeySize)
+}
+
+func mustKeyring(t *testing.T, primary string, keys map[string][]byte) *Keyring {
+	t.Helper()
+	ring, err := NewKeyring(primary, keys)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	return ring
+}
+
+func TestNewKeyringErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary string
+		keys    map[string][]byte
+	}{
+		{"primary missing", "k2", map[string][]byte{"k1": testKey(1)}},
+		{"empty ring", "k1", nil},
+		{"short key", "k1", map[string][]byte{"k1": testKey(1)[:16]}},
+		{"long key", "k1", map[string][]byte{"k1": append(testKey(1), 0)}},
+		{"empty ID", "", map[string][]byte{"": testKey(1)}},
+		{"ID with a dot", "k.1", map[string][]byte{"k.1": testKey(1)}},
+		{"ID too long", strings.Repeat("k", 33), map[string][]byte{strings.Repeat("k", 33): testKey(1)}},
+		{"bad secondary key", "k1", map[string][]byte{"k1": testKey(1), "old": nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyring(tt.primary, tt.keys); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("NewKeyring() error = %v, want ErrInvalidKey", err)
+			}
+		})
+	}
+
+	ring := mustKeyring(t, "2024-05_a", map[string][]byte{"2024-05_a": testKey(1), strings.Repeat("Z", 32): testKey(2)})
+	if ring.PrimaryKeyID() != "2024-05_a" {
+		t.Errorf("PrimaryKeyID() = %q", ring.PrimaryKeyID())
+	}
+}
+
+func TestNewKeyringCopiesKeys(t *testing.T) {
+	key := testKey(7)
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": key})
+	sealed, err := ring.Seal([]byte("secret"), nil)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	key[0] ^= 0xff
+	if _, err := ring.Open(sealed, nil); err != nil {
+		t.Errorf("Open() after the caller changed its key slice error = %v", err)
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	a, err := GenerateKey()
+	if err != nil || len(a) != KeySize {
+		t.Fatalf("GenerateKey() = %d bytes, %v", len(a), err)
+	}
+	b, _ := GenerateKey()
+	if bytes.Equal(a, b) {
+		t.Error("GenerateKey() returned the same key twice")
+	}
+	if _, err := NewKeyring("k", map[string][]byte{"k": a}); err != nil {
+		t.Errorf("NewKeyring() with a generated key error = %v", err)
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	tests := []struct {
+		name      string
+		plaintext []byte
+		aad       []byte
+	}{
+		{"empty", nil, nil},
+		{"text", []byte("card 4242"), nil},
+		{"with associated data", []byte("balance=10"), []byte("user:42")},
+		{"binary", []byte{0, 1, 2, 255}, []byte{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := ring.Seal(tt.plaintext, tt.aad)
+			if err != nil {
+				t.Fatalf("Seal() error = %v", err)
+			}
+			if len(tt.plaintext) > 0 && bytes.Contains(sealed, tt.plaintext) {
+				t.Error("Seal() output contains the plaintext")
+			}
+			got, err := ring.Open(sealed, tt.aad)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.plaintext) {
+				t.Errorf("Open() = %q, want %q", got, tt.plaintext)
+			}
+		})
+	}
+
+	a, _ := ring.Seal([]byte("same"), nil)
+	b, _ := ring.Seal([]byte("same"), nil)
+	if bytes.Equal(a, b) {
+		t.Error("Seal() of the same plaintext twice gave the same output, want a random nonce")
+	}
+}
+
+func TestOpenFailures(t *testing.T) {
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	other := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(2)})
+	sealed, err := ring.Seal([]byte("payload"), []byte("aad"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	flipped := append([]byte(nil), sealed...)
+	flipped[len(flipped)-1] ^= 1
+
+	tests := []struct {
+		name    string
+		ring    *Keyring
+		sealed  []byte
+		aad     []byte
+		wantErr error
+	}{
+		{"wrong associated data", ring, sealed, []byte("other"), ErrDecrypt},
+		{"missing associated data", ring, sealed, nil, ErrDecrypt},
+		{"flipped bit", ring, flipped, []byte("aad"), ErrDecrypt},
+		{"different key, same ID", other, sealed, []byte("aad"), ErrDecrypt},
+		{"truncated", ring, sealed[:10], []byte("aad"), ErrDecrypt},
+		{"empty", ring, nil, nil, ErrDecrypt},
+		{"ID length past the end", ring, []byte{9, 'k'}, nil, ErrDecrypt},
+		{"unknown key ID", mustKeyring(t, "k2", map[string][]byte{"k2": testKey(1)}), sealed, []byte("aad"), ErrUnknownKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.ring.Open(tt.sealed, tt.aad); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Open() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpenRejectsRelabeledKeyID(t *testing.T) {
+	// both IDs hold the same key, so only the binding of the ID into the associated data catches the swap
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1), "k2": testKey(1)})
+	sealed, _ := ring.Seal([]byte("payload"), nil)
+	relabeled := append([]byte(nil), sealed...)
+	relabeled[2] = '2'
+	if _, err := ring.Open(relabeled, nil); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("Open() of a relabeled message error = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	old := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	sealedBefore, err := old.SealString("issued before rotation")
+	if err != nil {
+		t.Fatalf("SealString() error = %v", err)
+	}
+
+	rotated := mustKeyring(t, "k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	if got, err := rotated.OpenString(sealedBefore); err != nil || got != "issued before rotation" {
+		t.Errorf("OpenString() of an old-key message = %q, %v", got, err)
+	}
+	sealedAfter, _ := rotated.SealString("issued after rotation")
+	if !strings.HasPrefix(sealedAfter, "k2.") {
+		t.Errorf("SealString() = %q, want the new primary's ID", sealedAfter)
+	}
+
+	retired := mustKeyring(t, "k2", map[string][]byte{"k2": testKey(2)})
+	if _, err := retired.OpenString(sealedBefore); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("OpenString() after retiring k1 error = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestSealStringOpenString(t *testing.T) {
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	for _, plaintext := range []string{"", "hello", "ünïcode ✓", strings.Repeat("x", 1000)} {
+		sealed, err := ring.SealString(plaintext)
+		if err != nil {
+			t.Fatalf("SealString() error = %v", err)
+		}
+		id, body, _ := strings.Cut(sealed, ".")
+		if id != "k1" || strings.Trim(body, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz") != "" {
+			t.Errorf("SealString() = %q, want k1 and a base62 body", sealed)
+		}
+		if got, err := ring.OpenString(sealed); err != nil || got != plaintext {
+			t.Errorf("OpenString() = %q, %v, want %q", got, err, plaintext)
+		}
+	}
+
+	valid, _ := ring.SealString("x")
+	tests := []struct {
+		name    string
+		sealed  string
+		wantErr error
+	}{
+		{"no separator", "k1", ErrDecrypt},
+		{"invalid key ID", "k!." + valid[3:], ErrDecrypt},
+		{"not base62", "k1.***", ErrDecrypt},
+		{"truncated", valid[:len(valid)-4], ErrDecrypt},
+		{"unknown key", "zz." + valid[3:], ErrUnknownKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ring.OpenString(tt.sealed); !errors.Is(err, tt.wantErr) {
+				t.Errorf("OpenString(%q) error = %v, want %v", tt.sealed, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package cryptox
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/hash"
+	"github.com/Tealseed-Lab/easy_go_lib/id_gen"
+)
+
+// ConstantTimeEqual reports whether a and b are equal without leaking, through timing, how much of
+// them matches; use it to compare secrets such as API keys or signatures
+func ConstantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// SignToken returns a token carrying payload that VerifyToken accepts until ttl has passed, e.g. for
+// email confirmation or download links. Tokens are signed with HMAC-SHA256 under the primary key and
+// read "keyID.base62(expiry+payload).base62(signature)". The payload is readable by anyone holding the
+// token, so seal it first if it is secret.
+func (k *Keyring) SignToken(payload []byte, ttl time.Duration) string {
+	body := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint64(body, uint64(time.Now().Add(ttl).Unix()))
+	body = append(body, payload...)
+	signed := k.primary + "." + id_gen.EncodeBase62Bytes(body)
+	return signed + "." + id_gen.EncodeBase62Bytes(hash.Sign(k.keys[k.primary].signing, []byte(signed)))
+}
+
+// VerifyToken checks a token from SignToken and returns its payload. Tampered or malformed tokens
+// return ErrInvalidToken, tokens from a key no longer in the ring ErrUnknownKey, and tokens past
+// their expiry ErrTokenExpired.
+func (k *Keyring) VerifyToken(token string) ([]byte, error) {
+	dot := strings.LastIndexByte(token, '.')
+	if dot < 0 {
+		return nil, ErrInvalidToken
+	}
+	signed, signature := token[:dot], token[dot+1:]
+	id, encodedBody, ok := strings.Cut(signed, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	key, ok := k.keys[id]
+	if !ok {
+		if !isValidKeyID(id) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	mac, err := id_gen.DecodeBase62Bytes(signature)
+	if err != nil || !hash.Verify(key.signing, []byte(signed), mac) {
+		return nil, ErrInvalidToken
+	}
+	body, err := id_gen.DecodeBase62Bytes(encodedBody)
+	if err != nil || len(body) < 8 {
+		return nil, ErrInvalidToken
+	}
+	if time.Now().Unix() >= int64(binary.BigEndian.Uint64(body)) {
+		return nil, ErrTokenExpired
+	}
+	return body[8:], nil
+}
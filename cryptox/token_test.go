@@ -0,0 +1,97 @@
+package cryptox
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConstantTimeEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"", "", true},
+		{"secret", "secret", true},
+		{"secret", "secreT", false},
+		{"secret", "secret2", false},
+		{"", "x", false},
+	}
+	for _, tt := range tests {
+		if got := ConstantTimeEqual(tt.a, tt.b); got != tt.want {
+			t.Errorf("ConstantTimeEqual(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSignVerifyToken(t *testing.T) {
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	for _, payload := range [][]byte{nil, []byte("user:42"), {0, '.', 255}} {
+		token := ring.SignToken(payload, time.Hour)
+		if parts := strings.Split(token, "."); len(parts) != 3 || parts[0] != "k1" {
+			t.Fatalf("SignToken() = %q, want keyID.body.signature", token)
+		}
+		got, err := ring.VerifyToken(token)
+		if err != nil {
+			t.Fatalf("VerifyToken() error = %v", err)
+		}
+		if !bytes.Equal(got, payload) {
+			t.Errorf("VerifyToken() = %q, want %q", got, payload)
+		}
+	}
+}
+
+func TestVerifyTokenFailures(t *testing.T) {
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	token := ring.SignToken([]byte("user:42"), time.Hour)
+	parts := strings.Split(token, ".")
+	other := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(2)})
+	forgedBody := ring.SignToken([]byte("user:43"), time.Hour)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"empty", "", ErrInvalidToken},
+		{"no dots", "abc", ErrInvalidToken},
+		{"one dot", "k1.abc", ErrInvalidToken},
+		{"swapped body", parts[0] + "." + strings.Split(forgedBody, ".")[1] + "." + parts[2], ErrInvalidToken},
+		{"truncated signature", token[:len(token)-3], ErrInvalidToken},
+		{"signature not base62", parts[0] + "." + parts[1] + ".***", ErrInvalidToken},
+		{"signed by another key", other.SignToken([]byte("user:42"), time.Hour), ErrInvalidToken},
+		{"invalid key ID", "k!." + parts[1] + "." + parts[2], ErrInvalidToken},
+		{"unknown key ID", "k9." + parts[1] + "." + parts[2], ErrUnknownKey},
+		{"expired", ring.SignToken([]byte("user:42"), -time.Second), ErrTokenExpired},
+		{"zero ttl", ring.SignToken([]byte("user:42"), 0), ErrTokenExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ring.VerifyToken(tt.token); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyToken(%q) error = %v, want %v", tt.token, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenKeyRotation(t *testing.T) {
+	old := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	token := old.SignToken([]byte("download:7"), time.Hour)
+
+	rotated := mustKeyring(t, "k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	if got, err := rotated.VerifyToken(token); err != nil || string(got) != "download:7" {
+		t.Errorf("VerifyToken() of an old-key token = %q, %v", got, err)
+	}
+	if fresh := rotated.SignToken(nil, time.Hour); !strings.HasPrefix(fresh, "k2.") {
+		t.Errorf("SignToken() = %q, want the new primary's ID", fresh)
+	}
+}
+
+func TestSigningKeyIsSeparateFromSealingKey(t *testing.T) {
+	ring := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	if bytes.Equal(ring.keys["k1"].signing, testKey(1)) || len(ring.keys["k1"].signing) != 32 {
+		t.Error("the token signing key is the raw encryption key")
+	}
+}
@@ -0,0 +1,53 @@
+package errorsx
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Tealseed-Lab/easy_go_lib/json"
+)
+
+// InternalErrorMessage replaces the message of errors that didn't come from this package in API bodies,
+// so unexpected failures don't leak details to clients
+const InternalErrorMessage = "internal error"
+
+// APIError is the body of a JSON API error response, written as {"error":{"code":...,"message":...}}
+type APIError struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+}
+
+type apiErrorBody struct {
+	Error APIError `json:"error"`
+}
+
+// ToAPIError converts err to the client-facing code and message: the outermost *Error's code and
+// message, or Internal with InternalErrorMessage for any other error. Causes are never included.
+func ToAPIError(err error) APIError {
+	var e *Error
+	if !errors.As(err, &e) {
+		return APIError{Code: Internal, Message: InternalErrorMessage}
+	}
+	message := e.message
+	if message == "" {
+		message = InternalErrorMessage
+	}
+	return APIError{Code: e.code, Message: message}
+}
+
+// HTTPStatus returns the HTTP status for err's code, 500 for errors without one
+func HTTPStatus(err error) int {
+	return CodeOf(err).HTTPStatus()
+}
+
+// MarshalAPIError serializes err as a JSON API error body, e.g. {"error":{"code":"not_found","message":"user not found"}}
+func MarshalAPIError(err error) string {
+	return json.SafeMarshalJson(apiErrorBody{Error: ToAPIError(err)})
+}
+
+// WriteHTTP writes err to w as a JSON API error body with the matching status code
+func WriteHTTP(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(err))
+	w.Write([]byte(MarshalAPIError(err)))
+}
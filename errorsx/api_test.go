@@ -0,0 +1,80 @@
+package errorsx
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestToAPIError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want APIError
+	}{
+		{"coded", New(NotFound, "user not found"), APIError{Code: NotFound, Message: "user not found"}},
+		{"cause hidden", Wrap(io.ErrUnexpectedEOF, Invalid, "malformed body"), APIError{Code: Invalid, Message: "malformed body"}},
+		{"outermost message", Wrap(New(NotFound, "row missing"), Conflict, "stale version"), APIError{Code: Conflict, Message: "stale version"}},
+		{"through fmt wrapping", fmt.Errorf("repo: %w", New(NotFound, "order not found")), APIError{Code: NotFound, Message: "order not found"}},
+		{"foreign error", io.EOF, APIError{Code: Internal, Message: InternalErrorMessage}},
+		{"empty message", Wrap(io.EOF, Internal, ""), APIError{Code: Internal, Message: InternalErrorMessage}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToAPIError(tt.err); got != tt.want {
+				t.Errorf("ToAPIError() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalAPIError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"not found", New(NotFound, "user not found"), `{"error":{"code":"not_found","message":"user not found"}}`},
+		{"html escaped", New(Invalid, "<name> is required"), `{"error":{"code":"invalid","message":"\u003cname\u003e is required"}}`},
+		{"foreign error", io.EOF, `{"error":{"code":"internal","message":"internal error"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarshalAPIError(tt.err); got != tt.want {
+				t.Errorf("MarshalAPIError() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"conflict", New(Conflict, "already exists"), http.StatusConflict},
+		{"invalid", New(Invalid, "bad"), http.StatusBadRequest},
+		{"foreign error", io.EOF, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			WriteHTTP(recorder, tt.err)
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if got := recorder.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+			if got, want := recorder.Body.String(), MarshalAPIError(tt.err); got != want {
+				t.Errorf("body = %s, want %s", got, want)
+			}
+			if HTTPStatus(tt.err) != tt.wantStatus {
+				t.Errorf("HTTPStatus() = %d, want %d", HTTPStatus(tt.err), tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package errorsx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Code classifies an error. Codes are errors themselves, so errors.Is(err, errorsx.NotFound) reports
+// whether err, or anything it wraps, carries that code.
+type Code string
+
+const (
+	NotFound Code = "not_found"
+	Invalid  Code = "invalid"
+	Conflict Code = "conflict"
+	Internal Code = "internal"
+)
+
+func (c Code) Error() string {
+	return string(c)
+}
+
+// HTTPStatus returns the HTTP status code conventionally used for c; unknown codes map to 500
+func (c Code) HTTPStatus() int {
+	switch c {
+	case NotFound:
+		return http.StatusNotFound
+	case Invalid:
+		return http.StatusBadRequest
+	case Conflict:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Error is an error with a Code, a message safe to show to API clients, an optional cause and the
+// stack trace captured where the error chain first came from this package
+type Error struct {
+	code    Code
+	message string
+	cause   error
+	stack   Stack
+}
+
+// New returns an error with code and message, capturing the caller's stack
+func New(code Code, message string) error {
+	return &Error{code: code, message: message, stack: captureStack(0)}
+}
+
+// Newf is New with a formatted message
+func Newf(code Code, format string, args ...any) error {
+	return &Error{code: code, message: fmt.Sprintf(format, args...), stack: captureStack(0)}
+}
+
+// Wrap annotates err with code and message, returning nil if err is nil. The stack is captured only if
+// nothing in err's chain already carries one, so wrapping at every layer keeps the original trace.
+func Wrap(err error, code Code, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{code: code, message: message, cause: err, stack: stackOrCapture(err)}
+}
+
+// Wrapf is Wrap with a formatted message
+func Wrapf(err error, code Code, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{code: code, message: fmt.Sprintf(format, args...), cause: err, stack: stackOrCapture(err)}
+}
+
+// Code returns the error's code
+func (e *Error) Code() Code {
+	return e.code
+}
+
+// Message returns the message without the cause
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Stack returns the stack trace captured for the error chain
+func (e *Error) Stack() Stack {
+	return e.stack
+}
+
+func (e *Error) Error() string {
+	if e.cause == nil {
+		return e.message
+	}
+	if e.message == "" {
+		return e.cause.Error()
+	}
+	return e.message + ": " + e.cause.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is matches a target Code against the error's code, so errors.Is(err, errorsx.Conflict) works through
+// any amount of wrapping
+func (e *Error) Is(target error) bool {
+	code, ok := target.(Code)
+	return ok && code == e.code
+}
+
+// Format prints the error like Error for %s and %v, and adds the stack trace for %+v
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, e.Error())
+		if s.Flag('+') && len(e.stack) > 0 {
+			io.WriteString(s, "\n"+e.stack.String())
+		}
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// CodeOf returns the code of the outermost *Error in err's chain, Internal if there is none, or "" for a nil error
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return Internal
+}
+
+// StackOf returns the stack trace carried by err's chain, or nil if none was captured
+func StackOf(err error) Stack {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.stack
+	}
+	return nil
+}
+
+func stackOrCapture(err error) Stack {
+	if stack := StackOf(err); stack != nil {
+		return stack
+	}
+	return captureStack(1)
+}
@@ -0,0 +1,134 @@
+package errorsx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCodeHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code Code
+		want int
+	}{
+		{NotFound, http.StatusNotFound},
+		{Invalid, http.StatusBadRequest},
+		{Conflict, http.StatusConflict},
+		{Internal, http.StatusInternalServerError},
+		{Code("teapot"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			if got := tt.code.HTTPStatus(); got != tt.want {
+				t.Errorf("%s.HTTPStatus() = %d, want %d", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"New", New(NotFound, "user not found"), "user not found"},
+		{"Newf", Newf(Invalid, "field %q is required", "email"), `field "email" is required`},
+		{"Wrap", Wrap(io.ErrUnexpectedEOF, Internal, "reading body"), "reading body: unexpected EOF"},
+		{"Wrapf", Wrapf(io.EOF, Conflict, "version %d", 3), "version 3: EOF"},
+		{"Wrap without message", Wrap(io.EOF, Internal, ""), "EOF"},
+		{"nested", Wrap(Wrap(io.EOF, NotFound, "load"), Internal, "handler"), "handler: load: EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if err := Wrap(nil, Internal, "x"); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+	if err := Wrapf(nil, Internal, "x %d", 1); err != nil {
+		t.Errorf("Wrapf(nil) = %v, want nil", err)
+	}
+}
+
+func TestErrorMatching(t *testing.T) {
+	inner := New(NotFound, "user not found")
+	err := fmt.Errorf("handler: %w", Wrap(inner, Conflict, "update"))
+
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{"outer code", Conflict, true},
+		{"inner code", NotFound, true},
+		{"absent code", Invalid, false},
+		{"inner error value", inner, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(err, %v) = %v, want %v", tt.target, got, tt.want)
+			}
+		})
+	}
+
+	var e *Error
+	if !errors.As(err, &e) || e.Code() != Conflict || e.Message() != "update" {
+		t.Errorf("errors.As() = %v, want the outermost *Error", e)
+	}
+	if !errors.Is(Wrap(io.EOF, Internal, "x"), io.EOF) {
+		t.Error("errors.Is() does not see a wrapped cause")
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"nil", nil, ""},
+		{"plain error", io.EOF, Internal},
+		{"coded", New(Invalid, "bad"), Invalid},
+		{"outermost wins", Wrap(New(NotFound, "x"), Conflict, "y"), Conflict},
+		{"through fmt wrapping", fmt.Errorf("ctx: %w", New(NotFound, "x")), NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorFormat(t *testing.T) {
+	err := Wrap(io.EOF, Internal, "reading")
+	tests := []struct {
+		format, want string
+	}{
+		{"%s", "reading: EOF"},
+		{"%v", "reading: EOF"},
+		{"%q", `"reading: EOF"`},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%s) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(verbose, "reading: EOF\n") || !strings.Contains(verbose, "TestErrorFormat") {
+		t.Errorf("Sprintf(%%+v) = %q, want the message followed by a stack trace", verbose)
+	}
+}
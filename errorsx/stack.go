@@ -0,0 +1,49 @@
+package errorsx
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+const maxStackDepth = 32
+
+// Stack is a captured call stack, innermost frame first
+type Stack []uintptr
+
+// captureStack records the stack of the caller of the exported constructor that called it, skipping
+// skip further frames of internal helpers in between
+func captureStack(skip int) Stack {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(3+skip, pcs[:])
+	return Stack(pcs[:n:n])
+}
+
+// Frames resolves the stack to function names, files and lines
+func (s Stack) Frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(s)
+	var out []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		out = append(out, frame)
+		if !more {
+			return out
+		}
+	}
+}
+
+// String formats the stack one frame per two lines, like a goroutine trace:
+// the function name, then the tab-indented file:line
+func (s Stack) String() string {
+	var b strings.Builder
+	for i, frame := range s.Frames() {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package errorsx
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// newFromHelper creates an error one call below the test, so the first frame is this function
+func newFromHelper() error {
+	return New(NotFound, "x")
+}
+
+func TestStackCapturesCaller(t *testing.T) {
+	tests := []struct {
+		name, wantFirst string
+		err             error
+	}{
+		{"New", "errorsx.newFromHelper", newFromHelper()},
+		{"Newf", "errorsx.TestStackCapturesCaller", Newf(Invalid, "%d", 1)},
+		{"Wrap", "errorsx.TestStackCapturesCaller", Wrap(io.EOF, Internal, "x")},
+		{"Wrapf", "errorsx.TestStackCapturesCaller", Wrapf(io.EOF, Internal, "%s", "x")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := StackOf(tt.err).Frames()
+			if len(frames) == 0 {
+				t.Fatal("StackOf() captured no frames")
+			}
+			if !strings.HasSuffix(frames[0].Function, tt.wantFirst) {
+				t.Errorf("first frame = %s, want %s", frames[0].Function, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestWrapKeepsOriginalStack(t *testing.T) {
+	original := newFromHelper()
+	wrapped := Wrap(Wrapf(original, Internal, "middle"), Conflict, "outer")
+
+	want, got := StackOf(original), StackOf(wrapped)
+	if len(got) == 0 || &got[0] != &want[0] {
+		t.Errorf("wrapped stack starts at %s, want the original from newFromHelper", got.Frames()[0].Function)
+	}
+	if e := wrapped.(*Error); &e.Stack()[0] != &want[0] {
+		t.Error("Error.Stack() does not return the original stack")
+	}
+}
+
+func TestStackOfForeignError(t *testing.T) {
+	if stack := StackOf(io.EOF); stack != nil {
+		t.Errorf("StackOf(io.EOF) = %v, want nil", stack)
+	}
+	var empty Stack
+	if frames := empty.Frames(); frames != nil {
+		t.Errorf("empty Stack.Frames() = %v, want nil", frames)
+	}
+	if s := empty.String(); s != "" {
+		t.Errorf("empty Stack.String() = %q, want empty", s)
+	}
+}
+
+func TestStackString(t *testing.T) {
+	lines := strings.Split(StackOf(newFromHelper()).String(), "\n")
+	if len(lines) < 4 || len(lines)%2 != 0 {
+		t.Fatalf("Stack.String() has %d lines, want function and file line pairs", len(lines))
+	}
+	if !strings.HasSuffix(lines[0], "errorsx.newFromHelper") {
+		t.Errorf("first line = %q, want the function name", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "\t") || !strings.Contains(lines[1], "stack_test.go:") {
+		t.Errorf("second line = %q, want a tab-indented file:line", lines[1])
+	}
+	if depth := len(StackOf(newFromHelper())); depth > maxStackDepth {
+		t.Errorf("captured %d frames, want at most %d", depth, maxStackDepth)
+	}
+}
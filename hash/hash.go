@@ -0,0 +1,47 @@
+package hash
+
+import (
+	"hash/fnv"
+
+	"github.com/Tealseed-Lab/easy_go_lib/json"
+)
+
+// Bytes64 returns the 64-bit xxHash (XXH64, seed 0) of data. It is fast and stable across processes,
+// platforms and releases, so it can be persisted or used to pick shards, but it is not a cryptographic hash.
+func Bytes64(data []byte) uint64 {
+	return xxh64(data, 0)
+}
+
+// String64 is Bytes64 for a string
+func String64(s string) uint64 {
+	return xxh64([]byte(s), 0)
+}
+
+// Seeded64 is Bytes64 with a seed, for deriving independent hash functions from the same data
+func Seeded64(data []byte, seed uint64) uint64 {
+	return xxh64(data, seed)
+}
+
+// FNV64a returns the 64-bit FNV-1a hash of s, for interoperating with systems that already use FNV
+func FNV64a(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// FNV32a returns the 32-bit FNV-1a hash of s
+func FNV32a(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+// Value64 hashes any JSON-serializable value by its canonical JSON (sorted keys, compact), so equal
+// structs and maps hash the same regardless of map iteration order
+func Value64(v any) (uint64, error) {
+	canonical, err := json.MarshalCanonical(v)
+	if err != nil {
+		return 0, err
+	}
+	return String64(canonical), nil
+}
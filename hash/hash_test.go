@@ -0,0 +1,70 @@
+package hash
+
+import "testing"
+
+func TestStringHashes(t *testing.T) {
+	tests := []struct {
+		name string
+		got  uint64
+		want uint64
+	}{
+		{"Bytes64", Bytes64([]byte("abc")), 0x44bc2cf5ad770999},
+		{"String64", String64("abc"), 0x44bc2cf5ad770999},
+		{"Seeded64", Seeded64([]byte("xxhash"), 20141025), 0xb559b98d844e0635},
+		{"Seeded64 with seed 0", Seeded64([]byte("abc"), 0), 0x44bc2cf5ad770999},
+		{"FNV64a", FNV64a("a"), 0xaf63dc4c8601ec8c},
+		{"FNV64a empty", FNV64a(""), 0xcbf29ce484222325},
+		{"FNV32a", uint64(FNV32a("a")), 0xe40c292c},
+		{"FNV32a empty", uint64(FNV32a("")), 0x811c9dc5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %x, want %x", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValue64(t *testing.T) {
+	type item struct {
+		ID   int               `json:"id"`
+		Tags map[string]string `json:"tags"`
+	}
+	a := item{ID: 1, Tags: map[string]string{"x": "1", "y": "2", "z": "3"}}
+	b := item{ID: 1, Tags: map[string]string{"z": "3", "y": "2", "x": "1"}}
+
+	tests := []struct {
+		name  string
+		left  any
+		right any
+		equal bool
+	}{
+		{"equal structs", a, b, true},
+		{"struct and equivalent map", a, map[string]any{"tags": map[string]any{"y": "2", "x": "1", "z": "3"}, "id": 1}, true},
+		{"different values", a, item{ID: 2, Tags: a.Tags}, false},
+		{"number vs string", 1, "1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, err := Value64(tt.left)
+			if err != nil {
+				t.Fatalf("Value64() error = %v", err)
+			}
+			right, err := Value64(tt.right)
+			if err != nil {
+				t.Fatalf("Value64() error = %v", err)
+			}
+			if (left == right) != tt.equal {
+				t.Errorf("Value64() = %x and %x, want equal %v", left, right, tt.equal)
+			}
+		})
+	}
+
+	if got, _ := Value64(map[string]int{"a": 1}); got != String64(`{"a":1}`) {
+		t.Errorf("Value64() = %x, want the hash of the canonical JSON", got)
+	}
+	if _, err := Value64(make(chan int)); err == nil {
+		t.Error("Value64(chan) returned no error")
+	}
+}
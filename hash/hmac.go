@@ -0,0 +1,31 @@
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign returns the HMAC-SHA256 of data under secret
+func Sign(secret, data []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Verify reports whether signature is the HMAC-SHA256 of data under secret, in constant time
+func Verify(secret, data, signature []byte) bool {
+	return hmac.Equal(Sign(secret, data), signature)
+}
+
+// SignHex returns the hex HMAC-SHA256 of message under secret, the form webhook signature headers usually carry
+func SignHex(secret []byte, message string) string {
+	return hex.EncodeToString(Sign(secret, []byte(message)))
+}
+
+// VerifyHex reports whether signature is the hex HMAC-SHA256 of message under secret, in constant time.
+// Upper- and lowercase hex digits are both accepted.
+func VerifyHex(secret []byte, message, signature string) bool {
+	decoded, err := hex.DecodeString(signature)
+	return err == nil && Verify(secret, []byte(message), decoded)
+}
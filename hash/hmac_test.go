@@ -0,0 +1,68 @@
+package hash
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	// RFC 4231 test cases 2 and 3
+	tests := []struct {
+		name         string
+		secret, data []byte
+		want         string
+	}{
+		{"case 2", []byte("Jefe"), []byte("what do ya want for nothing?"), "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+		{"case 3", bytesOf(0xaa, 20), bytesOf(0xdd, 50), "773ea91e36800e46854db8ebd09181a72959098b3ef8c122d9635514ced565fe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(Sign(tt.secret, tt.data)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+			if got := SignHex(tt.secret, string(tt.data)); got != tt.want {
+				t.Errorf("SignHex() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func bytesOf(b byte, n int) []byte {
+	return []byte(strings.Repeat(string([]byte{b}), n))
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("webhook-secret")
+	message := `{"event":"paid"}`
+	signature := SignHex(secret, message)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		message   string
+		signature string
+		want      bool
+	}{
+		{"valid", secret, message, signature, true},
+		{"uppercase hex", secret, message, strings.ToUpper(signature), true},
+		{"changed message", secret, `{"event":"refunded"}`, signature, false},
+		{"wrong secret", []byte("other"), message, signature, false},
+		{"truncated", secret, message, signature[:62], false},
+		{"odd length", secret, message, signature[:63], false},
+		{"not hex", secret, message, "zz" + signature[2:], false},
+		{"empty", secret, message, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyHex(tt.secret, tt.message, tt.signature); got != tt.want {
+				t.Errorf("VerifyHex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	raw := Sign(secret, []byte(message))
+	if !Verify(secret, []byte(message), raw) || Verify(secret, []byte(message), raw[:31]) {
+		t.Error("Verify() does not match Sign()")
+	}
+}
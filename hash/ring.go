@@ -0,0 +1,129 @@
+package hash
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultReplicas is the number of points each node gets on a Ring unless NewRing is told otherwise
+const DefaultReplicas = 160
+
+// Ring is a consistent hash ring mapping keys to nodes such that adding or removing a node only moves
+// the keys that node gains or loses. Each node is placed at several virtual points to even out the
+// load. It is safe for concurrent use.
+type Ring struct {
+	mutex    sync.RWMutex
+	replicas int
+	points   []uint64          // sorted
+	owners   map[uint64]string // point -> node
+	nodes    map[string]struct{}
+}
+
+// NewRing returns an empty ring placing each node at replicas points (DefaultReplicas if replicas < 1)
+func NewRing(replicas int) *Ring {
+	if replicas < 1 {
+		replicas = DefaultReplicas
+	}
+	return &Ring{replicas: replicas, owners: map[uint64]string{}, nodes: map[string]struct{}{}}
+}
+
+// AddNode adds nodes to the ring; nodes already present are ignored
+func (r *Ring) AddNode(nodes ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, node := range nodes {
+		if _, ok := r.nodes[node]; ok {
+			continue
+		}
+		r.nodes[node] = struct{}{}
+		for i := 0; i < r.replicas; i++ {
+			point := r.point(node, i)
+			if _, taken := r.owners[point]; taken {
+				// a collision between virtual points is astronomically rare; the first owner keeps it
+				continue
+			}
+			r.owners[point] = node
+			r.points = append(r.points, point)
+		}
+	}
+	slices.Sort(r.points)
+}
+
+// RemoveNode removes node from the ring, reporting whether it was present
+func (r *Ring) RemoveNode(node string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.nodes[node]; !ok {
+		return false
+	}
+	delete(r.nodes, node)
+	points := r.points[:0]
+	for _, point := range r.points {
+		if r.owners[point] == node {
+			delete(r.owners, point)
+			continue
+		}
+		points = append(points, point)
+	}
+	r.points = points
+	return true
+}
+
+// GetNode returns the node key belongs to, or false if the ring is empty
+func (r *Ring) GetNode(key string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if len(r.points) == 0 {
+		return "", false
+	}
+	return r.owners[r.points[r.search(String64(key))]], true
+}
+
+// GetNodes returns up to n distinct nodes for key in ring order, the first being GetNode's, e.g. to
+// choose replicas
+func (r *Ring) GetNodes(key string, n int) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	n = min(n, len(r.nodes))
+	if n <= 0 {
+		return nil
+	}
+	nodes := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+	start := r.search(String64(key))
+	for i := 0; i < len(r.points) && len(nodes) < n; i++ {
+		owner := r.owners[r.points[(start+i)%len(r.points)]]
+		if _, ok := seen[owner]; !ok {
+			seen[owner] = struct{}{}
+			nodes = append(nodes, owner)
+		}
+	}
+	return nodes
+}
+
+// Nodes returns the nodes on the ring in sorted order
+func (r *Ring) Nodes() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	nodes := make([]string, 0, len(r.nodes))
+	for node := range r.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// search returns the index of the first point at or after hash, wrapping around to 0
+func (r *Ring) search(hash uint64) int {
+	i, _ := slices.BinarySearch(r.points, hash)
+	if i == len(r.points) {
+		return 0
+	}
+	return i
+}
+
+func (r *Ring) point(node string, replica int) uint64 {
+	return String64(node + "#" + strconv.Itoa(replica))
+}
@@ -0,0 +1,201 @@
+package hash
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func ringOf(nodes ...string) *Ring {
+	r := NewRing(0)
+	r.AddNode(nodes...)
+	return r
+}
+
+func keyOwners(r *Ring, keys int) map[string]string {
+	owners := make(map[string]string, keys)
+	for i := 0; i < keys; i++ {
+		key := "key-" + strconv.Itoa(i)
+		owners[key], _ = r.GetNode(key)
+	}
+	return owners
+}
+
+func TestRingEmpty(t *testing.T) {
+	r := NewRing(10)
+	if node, ok := r.GetNode("k"); ok || node != "" {
+		t.Errorf("GetNode() on an empty ring = %q, %v, want false", node, ok)
+	}
+	if nodes := r.GetNodes("k", 3); nodes != nil {
+		t.Errorf("GetNodes() on an empty ring = %v, want nil", nodes)
+	}
+	if r.RemoveNode("missing") {
+		t.Error("RemoveNode() of an absent node = true")
+	}
+}
+
+func TestRingNodes(t *testing.T) {
+	r := ringOf("c", "a", "b", "a")
+	if got, want := r.Nodes(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+	if len(r.points) != 3*DefaultReplicas {
+		t.Errorf("ring has %d points, want %d for 3 nodes", len(r.points), 3*DefaultReplicas)
+	}
+	if !r.RemoveNode("b") || !reflect.DeepEqual(r.Nodes(), []string{"a", "c"}) {
+		t.Errorf("Nodes() after RemoveNode(b) = %v", r.Nodes())
+	}
+	if len(r.points) != 2*DefaultReplicas || len(r.owners) != 2*DefaultReplicas {
+		t.Errorf("RemoveNode() left %d points and %d owners", len(r.points), len(r.owners))
+	}
+}
+
+func TestRingIsDeterministic(t *testing.T) {
+	// insertion order doesn't matter, so every process builds the same ring
+	a := keyOwners(ringOf("n1", "n2", "n3", "n4"), 500)
+	b := keyOwners(ringOf("n4", "n2", "n1", "n3"), 500)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("rings with the same nodes map keys differently")
+	}
+}
+
+func TestRingBalance(t *testing.T) {
+	const nodes, keys = 5, 50000
+	r := NewRing(0)
+	for i := 0; i < nodes; i++ {
+		r.AddNode("node-" + strconv.Itoa(i))
+	}
+	counts := map[string]int{}
+	for _, node := range keyOwners(r, keys) {
+		counts[node]++
+	}
+	for node, n := range counts {
+		// with 160 points per node each share stays well within 30% of the mean
+		if mean := keys / nodes; n < mean*7/10 || n > mean*13/10 {
+			t.Errorf("%s owns %d of %d keys, want about %d", node, n, keys, mean)
+		}
+	}
+}
+
+func TestRingMinimalMovement(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(r *Ring)
+		// the only node keys may move to or from
+		node string
+	}{
+		{"add", func(r *Ring) { r.AddNode("e") }, "e"},
+		{"remove", func(r *Ring) { r.RemoveNode("b") }, "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ringOf("a", "b", "c", "d")
+			before := keyOwners(r, 5000)
+			tt.change(r)
+			after := keyOwners(r, 5000)
+			moved := 0
+			for key, owner := range before {
+				if after[key] != owner {
+					moved++
+					if owner != tt.node && after[key] != tt.node {
+						t.Fatalf("%s moved from %s to %s, want only moves involving %s", key, owner, after[key], tt.node)
+					}
+				}
+			}
+			if moved == 0 || moved > 2000 {
+				t.Errorf("%d of 5000 keys moved, want roughly a fifth to a quarter", moved)
+			}
+		})
+	}
+}
+
+func TestRingGetNodes(t *testing.T) {
+	r := ringOf("a", "b", "c")
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 1},
+		{2, 2},
+		{3, 3},
+		{10, 3},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.n), func(t *testing.T) {
+			nodes := r.GetNodes("user-1", tt.n)
+			if len(nodes) != tt.want {
+				t.Fatalf("GetNodes(%d) = %v, want %d nodes", tt.n, nodes, tt.want)
+			}
+			seen := map[string]bool{}
+			for _, node := range nodes {
+				if seen[node] {
+					t.Errorf("GetNodes(%d) = %v repeats %s", tt.n, nodes, node)
+				}
+				seen[node] = true
+			}
+			if first, _ := r.GetNode("user-1"); len(nodes) > 0 && nodes[0] != first {
+				t.Errorf("GetNodes()[0] = %s, want GetNode()'s %s", nodes[0], first)
+			}
+		})
+	}
+}
+
+func TestRingWrapsAround(t *testing.T) {
+	r := NewRing(1)
+	r.AddNode("only")
+	for _, key := range []string{"", "a", "zzzz", "key-123"} {
+		if node, ok := r.GetNode(key); !ok || node != "only" {
+			t.Errorf("GetNode(%q) = %q, %v, want the single node", key, node, ok)
+		}
+	}
+	// a hash past the last point belongs to the first
+	if last := r.points[len(r.points)-1]; last < ^uint64(0) {
+		if i := r.search(last + 1); i != 0 {
+			t.Errorf("search() past the last point = %d, want a wrap to 0", i)
+		}
+	}
+}
+
+func TestRingConcurrent(t *testing.T) {
+	r := ringOf("a", "b")
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			node := "extra-" + strconv.Itoa(g)
+			for i := 0; i < 50; i++ {
+				r.AddNode(node)
+				r.RemoveNode(node)
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				if _, ok := r.GetNode(strconv.Itoa(i)); !ok {
+					t.Error("GetNode() found no node while a and b stay on the ring")
+					return
+				}
+				r.GetNodes(strconv.Itoa(i), 2)
+			}
+		}()
+	}
+	wg.Wait()
+	if !reflect.DeepEqual(r.Nodes(), []string{"a", "b"}) {
+		t.Errorf("Nodes() = %v, want a and b", r.Nodes())
+	}
+}
+
+func BenchmarkRingGetNode(b *testing.B) {
+	r := NewRing(0)
+	for i := 0; i < 16; i++ {
+		r.AddNode("node-" + strconv.Itoa(i))
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.GetNode("user-42")
+	}
+}
@@ -0,0 +1,74 @@
+package hash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// XXH64 primes, from the xxHash specification
+const (
+	prime64x1 uint64 = 11400714785074694791
+	prime64x2 uint64 = 14029467366897019727
+	prime64x3 uint64 = 1609587929392839161
+	prime64x4 uint64 = 9650029242287828579
+	prime64x5 uint64 = 2870177450012600261
+)
+
+// xxh64 is the 64-bit xxHash of data with the given seed
+func xxh64(data []byte, seed uint64) uint64 {
+	n := len(data)
+	var h uint64
+	if n >= 32 {
+		v1 := seed + prime64x1 + prime64x2
+		v2 := seed + prime64x2
+		v3 := seed
+		v4 := seed - prime64x1
+		for len(data) >= 32 {
+			v1 = xxh64Round(v1, binary.LittleEndian.Uint64(data[0:]))
+			v2 = xxh64Round(v2, binary.LittleEndian.Uint64(data[8:]))
+			v3 = xxh64Round(v3, binary.LittleEndian.Uint64(data[16:]))
+			v4 = xxh64Round(v4, binary.LittleEndian.Uint64(data[24:]))
+			data = data[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxh64Merge(h, v1)
+		h = xxh64Merge(h, v2)
+		h = xxh64Merge(h, v3)
+		h = xxh64Merge(h, v4)
+	} else {
+		h = seed + prime64x5
+	}
+	h += uint64(n)
+
+	for ; len(data) >= 8; data = data[8:] {
+		h ^= xxh64Round(0, binary.LittleEndian.Uint64(data))
+		h = bits.RotateLeft64(h, 27)*prime64x1 + prime64x4
+	}
+	if len(data) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(data)) * prime64x1
+		h = bits.RotateLeft64(h, 23)*prime64x2 + prime64x3
+		data = data[4:]
+	}
+	for _, b := range data {
+		h ^= uint64(b) * prime64x5
+		h = bits.RotateLeft64(h, 11) * prime64x1
+	}
+
+	h ^= h >> 33
+	h *= prime64x2
+	h ^= h >> 29
+	h *= prime64x3
+	h ^= h >> 32
+	return h
+}
+
+func xxh64Round(acc, input uint64) uint64 {
+	acc += input * prime64x2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * prime64x1
+}
+
+func xxh64Merge(acc, v uint64) uint64 {
+	acc ^= xxh64Round(0, v)
+	return acc*prime64x1 + prime64x4
+}
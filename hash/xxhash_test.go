@@ -0,0 +1,65 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestXXH64(t *testing.T) {
+	// reference values from the xxHash reference implementation
+	tests := []struct {
+		input string
+		seed  uint64
+		want  uint64
+	}{
+		{"", 0, 0xef46db3751d8e999},
+		{"a", 0, 0xd24ec4f1a98c6e5b},
+		{"abc", 0, 0x44bc2cf5ad770999},
+		{"xxhash", 0, 0x32dd38952c4bc720},
+		{"xxhash", 20141025, 0xb559b98d844e0635},
+		{"Nobody inspects the spammish repetition", 0, 0xfbcea83c8a378bf1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := xxh64([]byte(tt.input), tt.seed); got != tt.want {
+				t.Errorf("xxh64(%q, %d) = %016x, want %016x", tt.input, tt.seed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXXH64Lengths(t *testing.T) {
+	// every tail length and the 32-byte stripe boundary hash differently and deterministically
+	data := []byte(strings.Repeat("0123456789abcdef", 8))
+	seen := map[uint64]int{}
+	for n := 0; n <= len(data); n++ {
+		h := xxh64(data[:n], 0)
+		if previous, ok := seen[h]; ok {
+			t.Errorf("xxh64 of %d and %d bytes collide at %016x", previous, n, h)
+		}
+		seen[h] = n
+		if again := xxh64(data[:n], 0); again != h {
+			t.Errorf("xxh64 of %d bytes is not deterministic", n)
+		}
+	}
+}
+
+func FuzzXXH64(f *testing.F) {
+	f.Add([]byte(""), uint64(0))
+	f.Add([]byte("Nobody inspects the spammish repetition"), uint64(7))
+	f.Fuzz(func(t *testing.T, data []byte, seed uint64) {
+		copied := append([]byte(nil), data...)
+		if xxh64(data, seed) != xxh64(copied, seed) {
+			t.Error("xxh64 depends on more than the bytes and the seed")
+		}
+	})
+}
+
+func BenchmarkBytes64(b *testing.B) {
+	data := []byte(strings.Repeat("x", 1024))
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Bytes64(data)
+	}
+}
@@ -0,0 +1,82 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// base58Alphabet is the Bitcoin alphabet, which leaves out 0, O, I and l
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var bigBase58 = big.NewInt(58)
+
+var ErrInvalidBase58 = errors.New("invalid base58 string")
+
+// EncodeBase58 encodes b in Bitcoin-style base58. Each leading zero byte is written as a '1', so the
+// exact byte length survives the round trip through DecodeBase58.
+func EncodeBase58(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(b[zeros:])
+	var digits []byte
+	remainder := new(big.Int)
+	for n.Sign() > 0 {
+		n.QuoRem(n, bigBase58, remainder)
+		digits = append(digits, base58Alphabet[remainder.Int64()])
+	}
+	out := make([]byte, zeros, zeros+len(digits))
+	for i := range out {
+		out[i] = base58Alphabet[0]
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
+
+// DecodeBase58 decodes a string produced by EncodeBase58
+func DecodeBase58(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	n := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		index := strings.IndexByte(base58Alphabet, s[i])
+		if index < 0 {
+			return nil, fmt.Errorf("%w: unexpected character %q", ErrInvalidBase58, s[i])
+		}
+		n.Mul(n, bigBase58)
+		n.Add(n, big.NewInt(int64(index)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// UUIDToBase58 parses a UUID in any form accepted by uuid.Parse and encodes its 16 bytes in base58,
+// giving a token of at most 22 characters
+func UUIDToBase58(s string) (string, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+	return EncodeBase58(u[:]), nil
+}
+
+// UUIDFromBase58 decodes a token produced by UUIDToBase58 back to the canonical UUID string
+func UUIDFromBase58(s string) (string, error) {
+	b, err := DecodeBase58(s)
+	if err != nil {
+		return "", err
+	}
+	u, err := uuid.FromBytes(b)
+	if err != nil {
+		return "", fmt.Errorf("%w: decodes to %d bytes, not 16", ErrInvalidBase58, len(b))
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,130 @@
+package id_gen
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEncodeBase58(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		want string
+	}{
+		{"empty", nil, ""},
+		{"hello world", []byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+		{"leading zeros", []byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd}, "11233QC4"},
+		{"all zeros", make([]byte, 16), "1111111111111111"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EncodeBase58(tt.b); got != tt.want {
+				t.Errorf("EncodeBase58(%v) = %q, want %q", tt.b, got, tt.want)
+			}
+			got, err := DecodeBase58(tt.want)
+			if err != nil {
+				t.Fatalf("DecodeBase58(%q) error = %v", tt.want, err)
+			}
+			if !bytes.Equal(got, tt.b) {
+				t.Errorf("DecodeBase58(%q) = %v, want %v", tt.want, got, tt.b)
+			}
+		})
+	}
+}
+
+func TestDecodeBase58Invalid(t *testing.T) {
+	// 0, O, I and l are not in the alphabet
+	for _, s := range []string{"0abc", "abcO", "Iabc", "abcl", "ab-c"} {
+		if _, err := DecodeBase58(s); !errors.Is(err, ErrInvalidBase58) {
+			t.Errorf("DecodeBase58(%q) error = %v, want ErrInvalidBase58", s, err)
+		}
+	}
+}
+
+func TestUUIDBase58(t *testing.T) {
+	tests := []struct {
+		name, uuid, want string
+	}{
+		{"rfc 4122 example", "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", "Xe22UfxT3rxcKJEAfL5373"},
+		{"urn form", "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", "Xe22UfxT3rxcKJEAfL5373"},
+		{"nil uuid", "00000000-0000-0000-0000-000000000000", "1111111111111111"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UUIDToBase58(tt.uuid)
+			if err != nil {
+				t.Fatalf("UUIDToBase58(%q) error = %v", tt.uuid, err)
+			}
+			if got != tt.want {
+				t.Errorf("UUIDToBase58(%q) = %q, want %q", tt.uuid, got, tt.want)
+			}
+			back, err := UUIDFromBase58(got)
+			if err != nil {
+				t.Fatalf("UUIDFromBase58(%q) error = %v", got, err)
+			}
+			if want := uuid.MustParse(tt.uuid).String(); back != want {
+				t.Errorf("UUIDFromBase58(%q) = %q, want %q", got, back, want)
+			}
+		})
+	}
+}
+
+func TestUUIDBase58Invalid(t *testing.T) {
+	if _, err := UUIDToBase58("not-a-uuid"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("UUIDToBase58(not-a-uuid) error = %v, want ErrInvalidUUID", err)
+	}
+	tests := []struct {
+		name, s string
+	}{
+		{"bad character", "Xe22UfxT3rxcKJEAfL537O"},
+		{"too short", "2NEpo7TZRRrLZSi2U"},
+		{"too long", "1Xe22UfxT3rxcKJEAfL5373"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := UUIDFromBase58(tt.s); !errors.Is(err, ErrInvalidBase58) {
+				t.Errorf("UUIDFromBase58(%q) error = %v, want ErrInvalidBase58", tt.s, err)
+			}
+		})
+	}
+}
+
+func FuzzBase58(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd})
+	f.Add([]byte("Hello World!"))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		encoded := EncodeBase58(b)
+		got, err := DecodeBase58(encoded)
+		if err != nil {
+			t.Fatalf("DecodeBase58(%q) error = %v", encoded, err)
+		}
+		if !bytes.Equal(got, b) {
+			t.Errorf("DecodeBase58(EncodeBase58(%v)) = %v", b, got)
+		}
+	})
+}
+
+func FuzzUUIDBase58(f *testing.F) {
+	f.Add([]byte("0123456789abcdef"))
+	f.Add(make([]byte, 16))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		u, err := uuid.FromBytes(b)
+		if err != nil {
+			return
+		}
+		encoded, err := UUIDToBase58(u.String())
+		if err != nil {
+			t.Fatalf("UUIDToBase58(%s) error = %v", u, err)
+		}
+		if len(encoded) > 22 {
+			t.Errorf("UUIDToBase58(%s) = %q, want at most 22 characters", u, encoded)
+		}
+		if got, err := UUIDFromBase58(encoded); err != nil || got != u.String() {
+			t.Errorf("UUIDFromBase58(%q) = %q, %v, want %s", encoded, got, err, u)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// base62Alphabet is in ascending ASCII order, so fixed-width encodings sort like the numbers they encode
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var bigBase62 = big.NewInt(62)
+
+var ErrInvalidBase62 = errors.New("invalid base62 string")
+
+// EncodeBase62 renders id in base62 without padding, e.g. a Snowflake ID as 11 URL-safe characters.
+// Negative values are encoded as their two's complement bits, so every int64 round-trips through DecodeBase62.
+func EncodeBase62(id int64) string {
+	n := uint64(id)
+	if n == 0 {
+		return "0"
+	}
+	var buf [11]byte // 62^11 > 2^64
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = base62Alphabet[n%62]
+		n /= 62
+	}
+	return string(buf[i:])
+}
+
+// DecodeBase62 parses a string produced by EncodeBase62
+func DecodeBase62(s string) (int64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty string", ErrInvalidBase62)
+	}
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		index := strings.IndexByte(base62Alphabet, s[i])
+		if index < 0 {
+			return 0, fmt.Errorf("%w: unexpected character %q", ErrInvalidBase62, s[i])
+		}
+		if n > (^uint64(0)-uint64(index))/62 {
+			return 0, fmt.Errorf("%w: %q overflows 64 bits", ErrInvalidBase62, s)
+		}
+		n = n*62 + uint64(index)
+	}
+	return int64(n), nil
+}
+
+// EncodeBase62Bytes encodes b in base62 using the same alphabet as EncodeBase62. Each leading zero byte
+// is written as a '0', so the exact byte length survives the round trip through DecodeBase62Bytes.
+func EncodeBase62Bytes(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(b[zeros:])
+	var digits []byte
+	remainder := new(big.Int)
+	for n.Sign() > 0 {
+		n.QuoRem(n, bigBase62, remainder)
+		digits = append(digits, base62Alphabet[remainder.Int64()])
+	}
+	out := make([]byte, zeros, zeros+len(digits))
+	for i := range out {
+		out[i] = base62Alphabet[0]
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
+
+// DecodeBase62Bytes decodes a string produced by EncodeBase62Bytes
+func DecodeBase62Bytes(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base62Alphabet[0] {
+		zeros++
+	}
+	n := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		index := strings.IndexByte(base62Alphabet, s[i])
+		if index < 0 {
+			return nil, fmt.Errorf("%w: unexpected character %q", ErrInvalidBase62, s[i])
+		}
+		n.Mul(n, bigBase62)
+		n.Add(n, big.NewInt(int64(index)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// encodeBase62Fixed encodes b, read as a big-endian number, as exactly width base62 characters
+func encodeBase62Fixed(b []byte, width int) string {
+	n := new(big.Int).SetBytes(b)
+	out := make([]byte, width)
+	remainder := new(big.Int)
+	for i := width - 1; i >= 0; i-- {
+		n.QuoRem(n, bigBase62, remainder)
+		out[i] = base62Alphabet[remainder.Int64()]
+	}
+	return string(out)
+}
+
+// decodeBase62Fixed decodes a base62 string into exactly size big-endian bytes
+func decodeBase62Fixed(s string, size int) ([]byte, bool) {
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		index := strings.IndexByte(base62Alphabet, s[i])
+		if index < 0 {
+			return nil, false
+		}
+		n.Mul(n, bigBase62)
+		n.Add(n, big.NewInt(int64(index)))
+	}
+	if n.BitLen() > size*8 {
+		return nil, false
+	}
+	return n.FillBytes(make([]byte, size)), true
+}
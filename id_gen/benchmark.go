@@ -0,0 +1,50 @@
+package id_gen
+
+import "time"
+
+// benchmarkedGenerators lists the schemes timed by BenchmarkGenerators
+var benchmarkedGenerators = map[string]func() func(){
+	"uuid":   func() func() { return func() { GenerateUUID() } },
+	"uuidv7": func() func() { return func() { GenerateUUIDv7() } },
+	"ulid":   func() func() { return func() { GenerateSortableId() } },
+	"ksuid":  func() func() { return func() { GenerateKSUID() } },
+	"snowflake": func() func() {
+		// a private generator keeps the benchmark from consuming the singleton's sequence
+		generator := NewSnowflakeGenerator(getMachineID())
+		return func() { generator.GenerateSnowflakeID() }
+	},
+	"snowflake_atomic": func() func() {
+		generator := NewAtomicSnowflakeGenerator(getMachineID())
+		return func() { generator.GenerateSnowflakeID() }
+	},
+	"hex":                   func() func() { return func() { GenerateRandomHexString(16) } },
+	"hybrid":                func() func() { return func() { GenerateHybridID() } },
+	"second_sortable":       func() func() { return func() { GenerateSecondSortableID() } },
+	"hlc":                   func() func() { return func() { GenerateHLCID() } },
+	"checked":               func() func() { return func() { GenerateCheckedID(12) } },
+	"ksortable":             func() func() { return func() { GenerateKSortableID(PrecisionMillisecond) } },
+	"random_node_snowflake": func() func() { return func() { GenerateRandomNodeSnowflake() } },
+	"push_id":               func() func() { return func() { GeneratePushID() } },
+	"short_distributed":     func() func() { return func() { GenerateShortDistributedID() } },
+	"reverse_sortable":      func() func() { return func() { GenerateReverseSortableID() } },
+	"versioned":             func() func() { return func() { GenerateVersionedID() } },
+	"short_id":              func() func() { return func() { GenerateShortID(21) } },
+}
+
+// BenchmarkGenerators generates iterations IDs with each scheme in this package and returns the
+// total time taken per scheme, keyed by scheme name. Package-level generators still report to OnGenerate.
+func BenchmarkGenerators(iterations int) map[string]time.Duration {
+	if iterations <= 0 {
+		iterations = 1
+	}
+	results := make(map[string]time.Duration, len(benchmarkedGenerators))
+	for name, setup := range benchmarkedGenerators {
+		generate := setup()
+		start := time.Now()
+		for i := 0; i < iterations; i++ {
+			generate()
+		}
+		results[name] = max(time.Since(start), time.Nanosecond)
+	}
+	return results
+}
@@ -0,0 +1,41 @@
+package id_gen
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestBenchmarkGenerators(t *testing.T) {
+	results := BenchmarkGenerators(50)
+	for _, name := range []string{"uuid", "uuidv7", "ulid", "snowflake", "short_id", "hex"} {
+		if _, ok := results[name]; !ok {
+			t.Errorf("BenchmarkGenerators() has no %q result", name)
+		}
+	}
+	if len(results) != len(benchmarkedGenerators) {
+		t.Errorf("BenchmarkGenerators() returned %d results, want %d", len(results), len(benchmarkedGenerators))
+	}
+	for name, duration := range results {
+		if duration <= 0 {
+			t.Errorf("BenchmarkGenerators()[%q] = %v, want positive", name, duration)
+		}
+	}
+}
+
+func BenchmarkAll(b *testing.B) {
+	names := make([]string, 0, len(benchmarkedGenerators))
+	for name := range benchmarkedGenerators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.Run(name, func(b *testing.B) {
+			generate := benchmarkedGenerators[name]()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				generate()
+			}
+		})
+	}
+}
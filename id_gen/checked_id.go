@@ -0,0 +1,79 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidCheckedID = errors.New("invalid checked ID")
+
+// checkedIDNormalizer undoes the usual transcription slips: lowercase letters, hyphens and spaces used as
+// separators, and the look-alike letters Crockford base32 leaves out (I and L for 1, O for 0)
+var checkedIDNormalizer = strings.NewReplacer("-", "", " ", "", "I", "1", "L", "1", "O", "0")
+
+// GenerateCheckedID generates payloadLen random Crockford base32 characters followed by a
+// Crockford mod 37 check symbol. Because 37 is prime and larger than the alphabet, every
+// single-character substitution and every adjacent transposition changes the check symbol,
+// so ValidateCheckedID catches the typical mistakes made when reading an ID aloud.
+// It returns an empty string if payloadLen isn't positive or entropy fails.
+func GenerateCheckedID(payloadLen int) string {
+	if payloadLen <= 0 {
+		return ""
+	}
+	random := make([]byte, payloadLen)
+	if _, err := rand.Read(random); err != nil {
+		return ""
+	}
+	id := make([]byte, payloadLen, payloadLen+1)
+	for i, b := range random {
+		// 256 is a multiple of 32, so masking keeps the distribution uniform
+		id[i] = crockfordAlphabet[b&0x1F]
+	}
+	id = append(id, crockfordCheckSymbols[base32DigitsMod37(string(id))])
+	notifyGenerate("checked")
+	return string(id)
+}
+
+// ValidateCheckedID reports whether id is a well-formed ID from GenerateCheckedID with a matching check symbol
+func ValidateCheckedID(id string) bool {
+	if len(id) < 2 {
+		return false
+	}
+	payload, check := id[:len(id)-1], id[len(id)-1]
+	for i := 0; i < len(payload); i++ {
+		if strings.IndexByte(crockfordAlphabet, payload[i]) < 0 {
+			return false
+		}
+	}
+	return crockfordCheckSymbols[base32DigitsMod37(payload)] == check
+}
+
+// VerifyCheckedID normalizes a human-entered ID from GenerateCheckedID (case, separators, I/L/O ambiguity),
+// verifies its check symbol and returns the canonical form; "abc-io-v", for instance, normalizes to "ABC10V"
+func VerifyCheckedID(id string) (string, error) {
+	normalized := checkedIDNormalizer.Replace(strings.ToUpper(id))
+	if len(normalized) < 2 {
+		return "", fmt.Errorf("%w: %q is too short", ErrInvalidCheckedID, id)
+	}
+	payload := normalized[:len(normalized)-1]
+	for i := 0; i < len(payload); i++ {
+		if strings.IndexByte(crockfordAlphabet, payload[i]) < 0 {
+			return "", fmt.Errorf("%w: unexpected character %q", ErrInvalidCheckedID, payload[i])
+		}
+	}
+	if !ValidateCheckedID(normalized) {
+		return "", fmt.Errorf("%w: check symbol mismatch in %q", ErrInvalidCheckedID, id)
+	}
+	return normalized, nil
+}
+
+// base32DigitsMod37 computes the value of a Crockford base32 digit string modulo 37
+func base32DigitsMod37(digits string) int {
+	remainder := 0
+	for i := 0; i < len(digits); i++ {
+		remainder = (remainder*32 + strings.IndexByte(crockfordAlphabet, digits[i])) % 37
+	}
+	return remainder
+}
@@ -0,0 +1,117 @@
+package id_gen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCheckedIDValidates(t *testing.T) {
+	for _, payloadLen := range []int{1, 8, 12, 32} {
+		id := GenerateCheckedID(payloadLen)
+		if len(id) != payloadLen+1 {
+			t.Fatalf("GenerateCheckedID(%d) = %q, want %d characters", payloadLen, id, payloadLen+1)
+		}
+		if !ValidateCheckedID(id) {
+			t.Errorf("ValidateCheckedID(%q) = false for a generated ID", id)
+		}
+	}
+	if got := GenerateCheckedID(0); got != "" {
+		t.Errorf("GenerateCheckedID(0) = %q, want empty", got)
+	}
+}
+
+func TestValidateCheckedIDCatchesSingleErrors(t *testing.T) {
+	for n := 0; n < 20; n++ {
+		id := GenerateCheckedID(12)
+		payload := id[:len(id)-1]
+
+		// every single-character substitution in the payload
+		for i := 0; i < len(payload); i++ {
+			for j := 0; j < len(crockfordAlphabet); j++ {
+				if crockfordAlphabet[j] == payload[i] {
+					continue
+				}
+				mutated := payload[:i] + string(crockfordAlphabet[j]) + payload[i+1:] + id[len(id)-1:]
+				if ValidateCheckedID(mutated) {
+					t.Fatalf("substitution %q -> %q passed validation", id, mutated)
+				}
+			}
+		}
+		// every adjacent transposition of distinct payload characters
+		for i := 0; i+1 < len(payload); i++ {
+			if payload[i] == payload[i+1] {
+				continue
+			}
+			mutated := payload[:i] + string(payload[i+1]) + string(payload[i]) + id[i+2:]
+			if ValidateCheckedID(mutated) {
+				t.Fatalf("transposition %q -> %q passed validation", id, mutated)
+			}
+		}
+	}
+}
+
+func TestValidateCheckedIDRejectsMalformed(t *testing.T) {
+	for _, id := range []string{"", "A", "ABCI0", "abc1", "ABC-1"} {
+		if ValidateCheckedID(id) {
+			t.Errorf("ValidateCheckedID(%q) = true, want false", id)
+		}
+	}
+}
+
+func TestVerifyCheckedID(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"canonical", "ABC10V", "ABC10V"},
+		{"lowercase", "abc10v", "ABC10V"},
+		{"ambiguous letters", "abc-io-v", "ABC10V"},
+		{"separators", "AB C1-0V", "ABC10V"},
+		{"lowercase l", "l1", "11"},
+		{"star check symbol", "10*", "10*"},
+		{"dollar check symbol", "12$", "12$"},
+		{"u check symbol", "14u", "14U"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyCheckedID(tt.input)
+			if err != nil {
+				t.Fatalf("VerifyCheckedID(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("VerifyCheckedID(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyCheckedIDGenerated(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id := GenerateCheckedID(10)
+		entered := strings.ToLower(id[:5] + "-" + id[5:])
+		if got, err := VerifyCheckedID(entered); err != nil || got != id {
+			t.Fatalf("VerifyCheckedID(%q) = %q, %v, want %q", entered, got, err, id)
+		}
+	}
+}
+
+func TestVerifyCheckedIDErrors(t *testing.T) {
+	tests := []struct {
+		name, input string
+	}{
+		{"empty", ""},
+		{"only separators", "- -"},
+		{"single character", "A"},
+		{"wrong check symbol", "ABC10W"},
+		{"check symbol in payload", "AB*10V"},
+		{"u in payload", "ABU10V"},
+		{"transposed", "BAC10V"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := VerifyCheckedID(tt.input); !errors.Is(err, ErrInvalidCheckedID) || got != "" {
+				t.Errorf("VerifyCheckedID(%q) = %q, %v, want ErrInvalidCheckedID", tt.input, got, err)
+			}
+		})
+	}
+}
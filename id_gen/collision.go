@@ -0,0 +1,49 @@
+package id_gen
+
+import "math"
+
+const (
+	// uuidV4RandomBits is the number of random bits in a version 4 UUID (6 bits are version/variant)
+	uuidV4RandomBits = 122
+	// ulidRandomBits is the number of random bits in a ULID; collisions only matter within the same millisecond
+	ulidRandomBits = 80
+)
+
+// EstimateCollisionProbability returns the birthday-paradox approximation of the probability
+// that at least two of count uniformly random IDs with the given number of bits collide.
+func EstimateCollisionProbability(bits int, count uint64) float64 {
+	if count < 2 || bits <= 0 {
+		return 0
+	}
+	n := float64(count)
+	space := math.Exp2(float64(bits))
+	// 1 - e^(-n(n-1)/2N), using Expm1 to keep precision for tiny probabilities
+	return -math.Expm1(-n * (n - 1) / (2 * space))
+}
+
+// EstimateUUIDCollisionProbability estimates the collision probability for count random (v4) UUIDs
+func EstimateUUIDCollisionProbability(count uint64) float64 {
+	return EstimateCollisionProbability(uuidV4RandomBits, count)
+}
+
+// EstimateULIDCollisionProbability estimates the collision probability for count ULIDs minted in the same millisecond
+func EstimateULIDCollisionProbability(countPerMillisecond uint64) float64 {
+	return EstimateCollisionProbability(ulidRandomBits, countPerMillisecond)
+}
+
+// EstimateHexStringCollisionProbability estimates the collision probability for count GenerateRandomHexString(length) values
+func EstimateHexStringCollisionProbability(length int, count uint64) float64 {
+	return EstimateCollisionProbability(length*8, count)
+}
+
+// EstimateAlphabetCollisionProbability estimates the collision probability for count random IDs
+// of the given size drawn uniformly from an alphabet (e.g. NanoID-style short IDs)
+func EstimateAlphabetCollisionProbability(alphabetSize, size int, count uint64) float64 {
+	if alphabetSize < 2 || size <= 0 || count < 2 {
+		return 0
+	}
+	n := float64(count)
+	// Work in log space so large alphabets/sizes don't overflow
+	logSpace := float64(size) * math.Log(float64(alphabetSize))
+	return -math.Expm1(-math.Exp(math.Log(n*(n-1)/2) - logSpace))
+}
@@ -0,0 +1,54 @@
+package id_gen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateCollisionProbability(t *testing.T) {
+	tests := []struct {
+		name      string
+		got       float64
+		want      float64
+		tolerance float64 // relative
+	}{
+		{"32 bits, 77163 ids", EstimateCollisionProbability(32, 77163), 0.5, 0.01},
+		{"32 bits, 9292 ids", EstimateCollisionProbability(32, 9292), 0.01, 0.01},
+		{"64 bits, 5.06e9 ids", EstimateCollisionProbability(64, 5_060_000_000), 0.5, 0.01},
+		// the figures usually quoted for random UUIDs
+		{"uuid, 2.71e18 ids", EstimateUUIDCollisionProbability(2_710_000_000_000_000_000), 0.5, 0.01},
+		{"uuid, 103e12 ids", EstimateUUIDCollisionProbability(103_000_000_000_000), 1e-9, 0.01},
+		{"ulid, 1e6 per ms", EstimateULIDCollisionProbability(1_000_000), 4.136e-13, 0.01},
+		{"hex of 4 bytes", EstimateHexStringCollisionProbability(4, 77163), 0.5, 0.01},
+		{"alphabet matches bits", EstimateAlphabetCollisionProbability(16, 8, 77163), EstimateCollisionProbability(32, 77163), 1e-9},
+		{"nanoid default", EstimateAlphabetCollisionProbability(64, 21, 1_000_000_000), EstimateCollisionProbability(126, 1_000_000_000), 1e-6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > tt.tolerance*tt.want {
+				t.Errorf("got %g, want %g within %g%%", tt.got, tt.want, tt.tolerance*100)
+			}
+		})
+	}
+}
+
+func TestEstimateCollisionProbabilityEdges(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"no ids", EstimateCollisionProbability(32, 0), 0},
+		{"one id", EstimateCollisionProbability(32, 1), 0},
+		{"no bits", EstimateCollisionProbability(0, 100), 0},
+		{"tiny alphabet", EstimateAlphabetCollisionProbability(1, 10, 100), 0},
+		{"saturated", EstimateCollisionProbability(8, 10_000), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %g, want %g", tt.got, tt.want)
+			}
+		})
+	}
+}
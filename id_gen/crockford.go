@@ -0,0 +1,125 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// crockfordAlphabet is Crockford's base32 alphabet; it is in ascending ASCII order so encoded values sort like the numbers
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordCheckSymbols extends the alphabet with the five extra check symbols for values 32-36
+const crockfordCheckSymbols = crockfordAlphabet + "*~$=U"
+
+var ErrInvalidCrockford = errors.New("invalid Crockford base32")
+
+// crockfordDecodeMap maps an (upper or lower case) character to its 5-bit value, or 0xFF if invalid
+var crockfordDecodeMap = func() [256]byte {
+	var m [256]byte
+	for i := range m {
+		m[i] = 0xFF
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		m[crockfordAlphabet[i]] = byte(i)
+		m[strings.ToLower(crockfordAlphabet[i : i+1])[0]] = byte(i)
+	}
+	return m
+}()
+
+// EncodeCrockford encodes b as uppercase Crockford base32, 5 bits per character, most significant bit first.
+// The final character is zero-padded when len(b)*8 isn't a multiple of 5.
+func EncodeCrockford(b []byte) string {
+	out := make([]byte, 0, (len(b)*8+4)/5)
+	var buffer uint16
+	bits := 0
+	for _, c := range b {
+		buffer = buffer<<8 | uint16(c)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out = append(out, crockfordAlphabet[(buffer>>uint(bits))&0x1F])
+		}
+	}
+	if bits > 0 {
+		out = append(out, crockfordAlphabet[(buffer<<uint(5-bits))&0x1F])
+	}
+	return string(out)
+}
+
+// DecodeCrockford decodes a Crockford base32 string produced by EncodeCrockford. Decoding is
+// case-insensitive and ignores hyphens; the excluded letters I, L, O and U are rejected.
+func DecodeCrockford(s string) ([]byte, error) {
+	s = strings.ReplaceAll(s, "-", "")
+	out := make([]byte, 0, len(s)*5/8)
+	var buffer uint16
+	bits := 0
+	for i := 0; i < len(s); i++ {
+		value := crockfordDecodeMap[s[i]]
+		if value == 0xFF {
+			return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrInvalidCrockford, s[i], i)
+		}
+		buffer = buffer<<5 | uint16(value)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			out = append(out, byte(buffer>>uint(bits)))
+		}
+	}
+	if bits >= 5 || buffer&(1<<uint(bits)-1) != 0 {
+		return nil, fmt.Errorf("%w: trailing bits are not zero padding", ErrInvalidCrockford)
+	}
+	return out, nil
+}
+
+// EncodeCrockfordWithCheck encodes b like EncodeCrockford and appends Crockford's mod 37 check symbol
+func EncodeCrockfordWithCheck(b []byte) string {
+	return EncodeCrockford(b) + string(crockfordCheckSymbols[crockfordMod37(b)])
+}
+
+// DecodeCrockfordWithCheck decodes a string produced by EncodeCrockfordWithCheck, verifying its check symbol
+func DecodeCrockfordWithCheck(s string) ([]byte, error) {
+	s = strings.ReplaceAll(s, "-", "")
+	if s == "" {
+		return nil, fmt.Errorf("%w: missing check symbol", ErrInvalidCrockford)
+	}
+	b, err := DecodeCrockford(s[:len(s)-1])
+	if err != nil {
+		return nil, err
+	}
+	check := strings.IndexByte(crockfordCheckSymbols, strings.ToUpper(s[len(s)-1:])[0])
+	if check < 0 || check != crockfordMod37(b) {
+		return nil, fmt.Errorf("%w: check symbol mismatch", ErrInvalidCrockford)
+	}
+	return b, nil
+}
+
+// crockfordMod37 computes the value of b, read as a big-endian number, modulo 37
+func crockfordMod37(b []byte) int {
+	remainder := 0
+	for _, c := range b {
+		remainder = (remainder*256 + int(c)) % 37
+	}
+	return remainder
+}
+
+// appendBase32Uint appends v as exactly width Crockford base32 characters, most significant first
+func appendBase32Uint(dst []byte, v uint64, width int) []byte {
+	for i := width - 1; i >= 0; i-- {
+		dst = append(dst, crockfordAlphabet[(v>>(uint(i)*5))&0x1F])
+	}
+	return dst
+}
+
+// parseBase32Uint decodes up to 12 uppercase Crockford base32 characters into a number
+func parseBase32Uint(s string) (uint64, bool) {
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		index := strings.IndexByte(crockfordAlphabet, s[i])
+		if index < 0 {
+			return 0, false
+		}
+		v = v<<5 | uint64(index)
+	}
+	return v, true
+}
@@ -0,0 +1,93 @@
+package id_gen
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCrockfordRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{"empty", []byte{}, ""},
+		{"one byte", []byte("f"), "CR"},
+		{"five bytes", []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, "ZZZZZZZZ"},
+		{"zeros", []byte{0, 0, 0, 0, 0}, "00000000"},
+		{"text", []byte("hello"), "D1JPRV3F"},
+		{"binary", []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF}, "04HMASW9NF6YY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodeCrockford(tt.input)
+			if got != tt.want {
+				t.Errorf("EncodeCrockford() = %s, want %s", got, tt.want)
+			}
+			back, err := DecodeCrockford(got)
+			if err != nil {
+				t.Fatalf("DecodeCrockford() error = %v", err)
+			}
+			if !bytes.Equal(back, tt.input) {
+				t.Errorf("DecodeCrockford() = %x, want %x", back, tt.input)
+			}
+		})
+	}
+}
+
+func TestDecodeCrockfordCaseAndHyphens(t *testing.T) {
+	for _, input := range []string{"D1JPRV3F", "d1jprv3f", "D1jp-Rv3f", "d1jp-rv3f"} {
+		got, err := DecodeCrockford(input)
+		if err != nil || string(got) != "hello" {
+			t.Errorf("DecodeCrockford(%q) = %q, %v, want hello", input, got, err)
+		}
+	}
+}
+
+func TestDecodeCrockfordRejects(t *testing.T) {
+	tests := []struct {
+		name, input string
+	}{
+		{"excluded I", "D1JPRVIF"},
+		{"excluded L", "D1JPRVLF"},
+		{"excluded O", "D1JPRVOF"},
+		{"excluded U", "D1JPRVUF"},
+		{"lowercase excluded", "d1jprvof"},
+		{"not in alphabet", "D1JP*V3F"},
+		{"non-zero padding", "CS"},
+		{"dangling character", "CRC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := DecodeCrockford(tt.input); !errors.Is(err, ErrInvalidCrockford) {
+				t.Errorf("DecodeCrockford(%q) = %x, %v, want ErrInvalidCrockford", tt.input, got, err)
+			}
+		})
+	}
+}
+
+func TestCrockfordCheckSymbol(t *testing.T) {
+	encoded := EncodeCrockfordWithCheck([]byte("f"))
+	if encoded != "CRW" {
+		t.Errorf("EncodeCrockfordWithCheck(f) = %s, want CRW", encoded)
+	}
+	for _, input := range []string{"CRW", "crw", "C-R-W"} {
+		if got, err := DecodeCrockfordWithCheck(input); err != nil || string(got) != "f" {
+			t.Errorf("DecodeCrockfordWithCheck(%q) = %q, %v, want f", input, got, err)
+		}
+	}
+	for _, input := range []string{"CRX", "", "CR*"} {
+		if _, err := DecodeCrockfordWithCheck(input); !errors.Is(err, ErrInvalidCrockford) {
+			t.Errorf("DecodeCrockfordWithCheck(%q) error = %v, want ErrInvalidCrockford", input, err)
+		}
+	}
+
+	// values whose remainder needs one of the five extra check symbols still round-trip
+	for i := 0; i < 256; i++ {
+		input := []byte{byte(i)}
+		if got, err := DecodeCrockfordWithCheck(EncodeCrockfordWithCheck(input)); err != nil || !bytes.Equal(got, input) {
+			t.Fatalf("check round trip of %x = %x, %v", input, got, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package id_gen
+
+import (
+	"errors"
+	"sync"
+)
+
+// defaultDedupRetries is used when NewDedupGenerator is given a negative retry count
+const defaultDedupRetries = 3
+
+var ErrDuplicateID = errors.New("every generated candidate was already seen")
+
+// SeenFilter remembers IDs, typically probabilistically like a bloom filter: Seen may report false
+// positives but should not report false negatives
+type SeenFilter interface {
+	Seen(id string) bool
+	Add(id string)
+}
+
+// DedupGenerator wraps an IDGenerator and discards candidates the filter reports as already seen,
+// guarding at-least-once pipelines against reusing an ID. A false positive only costs a regeneration.
+type DedupGenerator struct {
+	generator  IDGenerator
+	filter     SeenFilter
+	maxRetries int
+
+	mutex sync.Mutex // makes the Seen check and Add atomic across callers
+}
+
+// NewDedupGenerator wraps generator so each ID is checked against and then added to filter. A probable
+// hit is retried up to maxRetries times; a negative maxRetries selects the default of 3.
+func NewDedupGenerator(generator IDGenerator, filter SeenFilter, maxRetries int) *DedupGenerator {
+	if maxRetries < 0 {
+		maxRetries = defaultDedupRetries
+	}
+	return &DedupGenerator{generator: generator, filter: filter, maxRetries: maxRetries}
+}
+
+// Generate returns an ID the filter hasn't seen, or "" if every attempt was reported as seen
+func (g *DedupGenerator) Generate() string {
+	id, _ := g.TryGenerate()
+	return id
+}
+
+// TryGenerate returns an ID the filter hasn't seen, recording it in the filter, or ErrDuplicateID
+// if the retries run out
+func (g *DedupGenerator) TryGenerate() (string, error) {
+	for attempt := 0; attempt <= g.maxRetries; attempt++ {
+		id := g.generator.Generate()
+		if id == "" {
+			continue
+		}
+		g.mutex.Lock()
+		seen := g.filter.Seen(id)
+		if !seen {
+			g.filter.Add(id)
+		}
+		g.mutex.Unlock()
+		if !seen {
+			return id, nil
+		}
+	}
+	return "", ErrDuplicateID
+}
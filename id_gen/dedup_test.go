@@ -0,0 +1,65 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeSeenFilter reports the first reportSeen candidates as seen and records everything added
+type fakeSeenFilter struct {
+	reportSeen int
+	checked    []string
+	added      []string
+}
+
+func (f *fakeSeenFilter) Seen(id string) bool {
+	f.checked = append(f.checked, id)
+	return len(f.checked) <= f.reportSeen
+}
+
+func (f *fakeSeenFilter) Add(id string) {
+	f.added = append(f.added, id)
+}
+
+func TestDedupGenerator(t *testing.T) {
+	filter := &fakeSeenFilter{reportSeen: 1}
+	generator := NewDedupGenerator(GeneratorFunc(GenerateUUID), filter, 3)
+
+	id, err := generator.TryGenerate()
+	if err != nil {
+		t.Fatalf("TryGenerate() error = %v", err)
+	}
+	if len(filter.checked) != 2 || id == filter.checked[0] || id != filter.checked[1] {
+		t.Errorf("TryGenerate() = %q after checking %v, want the fresh second candidate", id, filter.checked)
+	}
+	if len(filter.added) != 1 || filter.added[0] != id {
+		t.Errorf("filter received %v, want only the returned ID %q", filter.added, id)
+	}
+}
+
+func TestDedupGeneratorRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		attempts   int
+	}{
+		{"no retries", 0, 1},
+		{"explicit retries", 5, 6},
+		{"default retries", -1, defaultDedupRetries + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &fakeSeenFilter{reportSeen: 100}
+			generator := NewDedupGenerator(GeneratorFunc(GenerateUUID), filter, tt.maxRetries)
+			if _, err := generator.TryGenerate(); !errors.Is(err, ErrDuplicateID) {
+				t.Errorf("TryGenerate() error = %v, want ErrDuplicateID", err)
+			}
+			if got := generator.Generate(); got != "" {
+				t.Errorf("Generate() = %q, want empty when every candidate is seen", got)
+			}
+			if len(filter.checked) != 2*tt.attempts || len(filter.added) != 0 {
+				t.Errorf("checked %d and added %d candidates, want %d attempts per call and none added", len(filter.checked), len(filter.added), tt.attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package id_gen
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultSchemeEnvVar names the environment variable that selects the scheme used by GenerateID
+const DefaultSchemeEnvVar = "EASYGO_ID_SCHEME"
+
+// OnDefaultSchemeWarning, if non-nil, is called when DefaultSchemeEnvVar names an unknown scheme
+// and GenerateID falls back to "uuid". The environment is read lazily on first use, so a hook
+// installed at the start of main still observes the warning.
+var OnDefaultSchemeWarning func(err error)
+
+var (
+	defaultSchemeMutex sync.RWMutex
+	defaultSchemeOnce  sync.Once
+	defaultScheme      = "uuid"
+)
+
+// GenerateID generates an ID with the default scheme, which is taken from DefaultSchemeEnvVar
+// (e.g. EASYGO_ID_SCHEME=ulid) and falls back to "uuid" when unset or invalid
+func GenerateID() string {
+	id, err := Generate(DefaultScheme())
+	if err != nil {
+		// the scheme was validated when selected, so this only happens if it was unregistered since
+		return GenerateUUID()
+	}
+	return id
+}
+
+// DefaultScheme returns the name of the scheme used by GenerateID
+func DefaultScheme() string {
+	defaultSchemeOnce.Do(func() { _ = loadDefaultSchemeFromEnv() })
+	defaultSchemeMutex.RLock()
+	defer defaultSchemeMutex.RUnlock()
+	return defaultScheme
+}
+
+// SetDefaultScheme selects the scheme used by GenerateID, overriding the environment.
+// The name must be registered (see RegisterGenerator).
+func SetDefaultScheme(name string) error {
+	if !isRegisteredGenerator(name) {
+		return fmt.Errorf("%w: %q", ErrUnknownGenerator, name)
+	}
+	defaultSchemeOnce.Do(func() {})
+	defaultSchemeMutex.Lock()
+	defer defaultSchemeMutex.Unlock()
+	defaultScheme = name
+	return nil
+}
+
+// LoadDefaultSchemeFromEnv re-reads DefaultSchemeEnvVar and resets the default scheme accordingly,
+// returning the validation error (after reporting it to OnDefaultSchemeWarning) if the value is unknown
+func LoadDefaultSchemeFromEnv() error {
+	defaultSchemeOnce.Do(func() {})
+	return loadDefaultSchemeFromEnv()
+}
+
+func loadDefaultSchemeFromEnv() error {
+	name := os.Getenv(DefaultSchemeEnvVar)
+	var err error
+	switch {
+	case name == "":
+		name = "uuid"
+	case !isRegisteredGenerator(name):
+		err = fmt.Errorf("%w: %s=%q, falling back to \"uuid\"", ErrUnknownGenerator, DefaultSchemeEnvVar, name)
+		name = "uuid"
+		if hook := OnDefaultSchemeWarning; hook != nil {
+			hook(err)
+		}
+	}
+
+	defaultSchemeMutex.Lock()
+	defer defaultSchemeMutex.Unlock()
+	defaultScheme = name
+	return err
+}
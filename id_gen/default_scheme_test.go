@@ -0,0 +1,65 @@
+package id_gen
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestGenerateIDFromEnv(t *testing.T) {
+	// runs after t.Setenv restores the environment
+	t.Cleanup(func() { _ = LoadDefaultSchemeFromEnv() })
+
+	tests := []struct {
+		scheme string
+		shape  *regexp.Regexp
+	}{
+		{"uuid", regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"uuidv7", regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"ulid", regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)},
+		{"snowflake", regexp.MustCompile(`^[1-9][0-9]{17,18}$`)},
+		{"hex", regexp.MustCompile(`^[0-9a-f]{32}$`)},
+		{"ksuid", regexp.MustCompile(`^[0-9A-Za-z]{27}$`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			t.Setenv(DefaultSchemeEnvVar, tt.scheme)
+			if err := LoadDefaultSchemeFromEnv(); err != nil {
+				t.Fatalf("LoadDefaultSchemeFromEnv() error = %v", err)
+			}
+			if got := DefaultScheme(); got != tt.scheme {
+				t.Errorf("DefaultScheme() = %q, want %q", got, tt.scheme)
+			}
+			if id := GenerateID(); !tt.shape.MatchString(id) {
+				t.Errorf("GenerateID() = %q, want a %s-shaped ID", id, tt.scheme)
+			}
+		})
+	}
+}
+
+func TestGenerateIDUnknownScheme(t *testing.T) {
+	t.Cleanup(func() { _ = LoadDefaultSchemeFromEnv() })
+	var warned error
+	OnDefaultSchemeWarning = func(err error) { warned = err }
+	defer func() { OnDefaultSchemeWarning = nil }()
+
+	t.Setenv(DefaultSchemeEnvVar, "no_such_scheme")
+	if err := LoadDefaultSchemeFromEnv(); !errors.Is(err, ErrUnknownGenerator) {
+		t.Errorf("LoadDefaultSchemeFromEnv() error = %v, want ErrUnknownGenerator", err)
+	}
+	if !errors.Is(warned, ErrUnknownGenerator) {
+		t.Errorf("OnDefaultSchemeWarning got %v, want ErrUnknownGenerator", warned)
+	}
+	if got := DefaultScheme(); got != "uuid" {
+		t.Errorf("DefaultScheme() = %q, want the uuid fallback", got)
+	}
+
+	t.Setenv(DefaultSchemeEnvVar, "")
+	_ = LoadDefaultSchemeFromEnv()
+	if err := SetDefaultScheme("ulid"); err != nil || DefaultScheme() != "ulid" {
+		t.Errorf("SetDefaultScheme(ulid) = %v, DefaultScheme() = %q", err, DefaultScheme())
+	}
+	if err := SetDefaultScheme("no_such_scheme"); !errors.Is(err, ErrUnknownGenerator) {
+		t.Errorf("SetDefaultScheme(unknown) error = %v, want ErrUnknownGenerator", err)
+	}
+}
@@ -0,0 +1,138 @@
+package id_gen
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+)
+
+// GeneratorOption customizes a generator built by New. Options a scheme has no use for are ignored.
+type GeneratorOption func(*generatorOptions)
+
+type generatorOptions struct {
+	prefix           string
+	entropy          io.Reader
+	machineID        *int64
+	snowflakeOptions []SnowflakeOption
+	clock            timeutil.Clock
+}
+
+// WithIDPrefix joins prefix to every string ID with a hyphen, like GenerateUuidWithPrefix.
+// GenerateInt64 is unaffected.
+func WithIDPrefix(prefix string) GeneratorOption {
+	return func(o *generatorOptions) {
+		o.prefix = prefix
+	}
+}
+
+// WithEntropy makes "ulid" read its random bits from entropy, e.g. a seeded math/rand source for
+// reproducible tests. The reader is not locked, so it must be safe for concurrent use if the generator is shared.
+func WithEntropy(entropy io.Reader) GeneratorOption {
+	return func(o *generatorOptions) {
+		o.entropy = entropy
+	}
+}
+
+// WithMachineID gives "snowflake" a fixed machine ID instead of the one resolved for GenerateSnowflakeID
+func WithMachineID(machineID int64) GeneratorOption {
+	return func(o *generatorOptions) {
+		o.machineID = &machineID
+	}
+}
+
+// WithSnowflakeOptions passes opts (WithBorrowAhead, WithMaxWait, ...) to the "snowflake" generator
+func WithSnowflakeOptions(opts ...SnowflakeOption) GeneratorOption {
+	return func(o *generatorOptions) {
+		o.snowflakeOptions = append(o.snowflakeOptions, opts...)
+	}
+}
+
+// WithGeneratorClock makes "ulid", "ksuid" and "snowflake" read time from clock instead of the wall
+// clock, e.g. a timeutil.FakeClock so tests get IDs with known timestamps
+func WithGeneratorClock(clock timeutil.Clock) GeneratorOption {
+	return func(o *generatorOptions) {
+		o.clock = clock
+	}
+}
+
+// New builds an IDGenerator for the named scheme, so the strategy can be chosen per entity or injected in tests.
+// "uuid", "uuidv7", "ulid", "ksuid" and "snowflake" get dedicated generators, and "snowflake" returns an
+// Int64IDGenerator backed by its own SnowflakeGenerator; any other name registered with RegisterGenerator
+// is wrapped as-is. Unknown names return ErrUnknownGenerator.
+func New(name string, opts ...GeneratorOption) (IDGenerator, error) {
+	var options generatorOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var generator IDGenerator
+	switch name {
+	case "uuid":
+		generator = GeneratorFunc(GenerateUUID)
+	case "uuidv7":
+		generator = GeneratorFunc(GenerateUUIDv7)
+	case "ulid":
+		entropy, clock := options.entropy, options.clock
+		if entropy == nil {
+			entropy = defaultEntropy
+		}
+		if clock == nil {
+			clock = timeutil.Real
+		}
+		generator = GeneratorFunc(func() string { return generateSortableID(clock.Now(), entropy) })
+	case "ksuid":
+		if clock := options.clock; clock != nil {
+			generator = GeneratorFunc(func() string { return generateKSUIDAt(clock.Now()) })
+		} else {
+			generator = GeneratorFunc(GenerateKSUID)
+		}
+	case "snowflake":
+		machineID := resolveMachineID()
+		if options.machineID != nil {
+			machineID = *options.machineID
+		}
+		snowflakeOptions := options.snowflakeOptions
+		if options.clock != nil {
+			snowflakeOptions = append([]SnowflakeOption{WithClock(options.clock)}, snowflakeOptions...)
+		}
+		snowflake := NewSnowflakeGenerator(machineID, snowflakeOptions...)
+		if options.prefix == "" {
+			return snowflake, nil
+		}
+		return &prefixedInt64Generator{Int64IDGenerator: snowflake, prefix: options.prefix}, nil
+	default:
+		if !isRegisteredGenerator(name) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownGenerator, name)
+		}
+		generator = GeneratorFunc(func() string {
+			id, _ := Generate(name)
+			return id
+		})
+	}
+
+	if prefix := options.prefix; prefix != "" {
+		inner := generator
+		generator = GeneratorFunc(func() string { return prefixID(prefix, inner.Generate()) })
+	}
+	return generator, nil
+}
+
+// prefixedInt64Generator applies WithIDPrefix to Generate while leaving GenerateInt64 alone
+type prefixedInt64Generator struct {
+	Int64IDGenerator
+	prefix string
+}
+
+func (g *prefixedInt64Generator) Generate() string {
+	return prefixID(g.prefix, strconv.FormatInt(g.GenerateInt64(), 10))
+}
+
+// prefixID joins prefix and id with a hyphen, keeping a failed (empty) ID empty
+func prefixID(prefix, id string) string {
+	if id == "" {
+		return ""
+	}
+	return prefix + "-" + id
+}
@@ -0,0 +1,148 @@
+package id_gen
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid func(string) bool
+	}{
+		{"uuid", func(id string) bool { u, err := uuid.Parse(id); return err == nil && u.Version() == 4 }},
+		{"uuidv7", func(id string) bool { u, err := uuid.Parse(id); return err == nil && u.Version() == 7 }},
+		{"ulid", func(id string) bool { _, err := ulid.ParseStrict(id); return err == nil }},
+		{"ksuid", func(id string) bool { _, err := ParseKSUID(id); return err == nil }},
+		{"snowflake", func(id string) bool { n, err := strconv.ParseInt(id, 10, 64); return err == nil && n > 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := New(tt.name)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", tt.name, err)
+			}
+			first, second := generator.Generate(), generator.Generate()
+			if !tt.valid(first) || !tt.valid(second) {
+				t.Errorf("Generate() = %q, %q, want valid %s IDs", first, second, tt.name)
+			}
+			if first == second {
+				t.Errorf("Generate() returned %q twice", first)
+			}
+		})
+	}
+}
+
+func TestNewUnknownGenerator(t *testing.T) {
+	if _, err := New("no-such-scheme"); !errors.Is(err, ErrUnknownGenerator) {
+		t.Errorf("New(no-such-scheme) error = %v, want ErrUnknownGenerator", err)
+	}
+}
+
+func TestNewRegisteredGenerator(t *testing.T) {
+	RegisterGenerator("test_factory", func() string { return "registered" })
+	defer func() {
+		registryMutex.Lock()
+		delete(registry, "test_factory")
+		registryMutex.Unlock()
+	}()
+
+	generator, err := New("test_factory", WithIDPrefix("p"))
+	if err != nil {
+		t.Fatalf("New(test_factory) error = %v", err)
+	}
+	if got := generator.Generate(); got != "p-registered" {
+		t.Errorf("Generate() = %q, want p-registered", got)
+	}
+}
+
+func TestNewWithIDPrefix(t *testing.T) {
+	for _, name := range []string{"uuid", "uuidv7", "ulid", "ksuid", "snowflake"} {
+		t.Run(name, func(t *testing.T) {
+			generator, err := New(name, WithIDPrefix("order"))
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", name, err)
+			}
+			if got := generator.Generate(); !strings.HasPrefix(got, "order-") || len(got) <= len("order-") {
+				t.Errorf("Generate() = %q, want an order- prefix", got)
+			}
+		})
+	}
+}
+
+func TestNewSnowflakeInt64(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	generator, err := New("snowflake", WithMachineID(7), WithGeneratorClock(clock), WithIDPrefix("evt"))
+	if err != nil {
+		t.Fatalf("New(snowflake) error = %v", err)
+	}
+	int64Generator, ok := generator.(Int64IDGenerator)
+	if !ok {
+		t.Fatalf("New(snowflake) = %T, want an Int64IDGenerator", generator)
+	}
+
+	id := int64Generator.GenerateInt64()
+	components := DecodeSnowflakeID(id)
+	if components.MachineID != 7 {
+		t.Errorf("DecodeSnowflakeID().MachineID = %d, want 7", components.MachineID)
+	}
+	if !components.Timestamp.Equal(clock.Now()) {
+		t.Errorf("DecodeSnowflakeID().Timestamp = %v, want %v", components.Timestamp, clock.Now())
+	}
+
+	text := int64Generator.Generate()
+	next, err := strconv.ParseInt(strings.TrimPrefix(text, "evt-"), 10, 64)
+	if err != nil || !strings.HasPrefix(text, "evt-") || next <= id {
+		t.Errorf("Generate() = %q after %d, want a larger prefixed Snowflake ID", text, id)
+	}
+}
+
+func TestNewWithGeneratorClock(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := timeutil.NewFakeClock(at)
+
+	ulidGenerator, err := New("ulid", WithGeneratorClock(clock))
+	if err != nil {
+		t.Fatalf("New(ulid) error = %v", err)
+	}
+	parsed, err := ulid.ParseStrict(ulidGenerator.Generate())
+	if err != nil {
+		t.Fatalf("ulid.ParseStrict() error = %v", err)
+	}
+	if got := ulid.Time(parsed.Time()); !got.Equal(at) {
+		t.Errorf("ULID time = %v, want %v", got, at)
+	}
+
+	ksuidGenerator, err := New("ksuid", WithGeneratorClock(clock))
+	if err != nil {
+		t.Fatalf("New(ksuid) error = %v", err)
+	}
+	k, err := ParseKSUID(ksuidGenerator.Generate())
+	if err != nil {
+		t.Fatalf("ParseKSUID() error = %v", err)
+	}
+	if got := k.Time(); !got.Equal(at) {
+		t.Errorf("KSUID time = %v, want %v", got, at)
+	}
+}
+
+func TestNewWithEntropy(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	newULID := func() string {
+		generator, err := New("ulid", WithGeneratorClock(clock), WithEntropy(strings.NewReader(strings.Repeat("x", 64))))
+		if err != nil {
+			t.Fatalf("New(ulid) error = %v", err)
+		}
+		return generator.Generate()
+	}
+	if first, second := newULID(), newULID(); first != second {
+		t.Errorf("ULIDs from the same clock and entropy = %q, %q, want equal", first, second)
+	}
+}
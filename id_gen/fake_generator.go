@@ -0,0 +1,48 @@
+package id_gen
+
+import (
+	"strconv"
+	"sync"
+)
+
+// FakeGenerator is a deterministic Int64IDGenerator for unit tests: it counts up from 1, returning the
+// counter from GenerateInt64 and prefix followed by the counter from Generate, so NewFakeGenerator("user-")
+// yields "user-1", "user-2", ... Both methods share one counter. It is safe for concurrent use.
+type FakeGenerator struct {
+	mutex  sync.Mutex
+	prefix string
+	next   int64
+}
+
+// NewFakeGenerator creates a FakeGenerator whose string IDs start with prefix
+func NewFakeGenerator(prefix string) *FakeGenerator {
+	return &FakeGenerator{prefix: prefix, next: 1}
+}
+
+// Generate returns the next ID as prefix followed by the counter
+func (g *FakeGenerator) Generate() string {
+	return g.prefix + strconv.FormatInt(g.GenerateInt64(), 10)
+}
+
+// GenerateInt64 returns the next counter value
+func (g *FakeGenerator) GenerateInt64() int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	id := g.next
+	g.next++
+	return id
+}
+
+// Count returns how many IDs have been generated
+func (g *FakeGenerator) Count() int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.next - 1
+}
+
+// Reset starts the sequence over from 1
+func (g *FakeGenerator) Reset() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.next = 1
+}
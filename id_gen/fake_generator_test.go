@@ -0,0 +1,68 @@
+package id_gen
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestFakeGenerator(t *testing.T) {
+	generator := NewFakeGenerator("user-")
+	tests := []struct {
+		name string
+		got  func() string
+		want string
+	}{
+		{"first string", generator.Generate, "user-1"},
+		{"second string", generator.Generate, "user-2"},
+		{"shared counter", func() string { return strconv.FormatInt(generator.GenerateInt64(), 10) }, "3"},
+		{"after int64", generator.Generate, "user-4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := generator.Count(); got != 4 {
+		t.Errorf("Count() = %d, want 4", got)
+	}
+	generator.Reset()
+	if got := generator.Count(); got != 0 {
+		t.Errorf("Count() after Reset() = %d, want 0", got)
+	}
+	if got := generator.Generate(); got != "user-1" {
+		t.Errorf("Generate() after Reset() = %q, want user-1", got)
+	}
+}
+
+func TestFakeGeneratorConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 500
+	generator := NewFakeGenerator("")
+	ids := make(chan int64, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ids <- generator.GenerateInt64()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int64]bool, workers*perWorker)
+	for id := range ids {
+		if seen[id] || id < 1 || id > workers*perWorker {
+			t.Fatalf("GenerateInt64() returned %d, want each of 1..%d once", id, workers*perWorker)
+		}
+		seen[id] = true
+	}
+	if got := generator.Count(); got != workers*perWorker {
+		t.Errorf("Count() = %d, want %d", got, workers*perWorker)
+	}
+}
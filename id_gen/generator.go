@@ -0,0 +1,42 @@
+package id_gen
+
+import "strconv"
+
+// IDGenerator produces string IDs
+type IDGenerator interface {
+	Generate() string
+}
+
+// Int64IDGenerator is an IDGenerator whose IDs are integers, such as Snowflake IDs.
+// Generate returns the same kind of ID as GenerateInt64, in decimal.
+type Int64IDGenerator interface {
+	IDGenerator
+	GenerateInt64() int64
+}
+
+// GeneratorFunc adapts a plain function such as GenerateUUID to IDGenerator
+type GeneratorFunc func() string
+
+func (f GeneratorFunc) Generate() string {
+	return f()
+}
+
+// Generate returns a new Snowflake ID in decimal, so a SnowflakeGenerator can be used as an IDGenerator
+func (sg *SnowflakeGenerator) Generate() string {
+	return strconv.FormatInt(sg.GenerateSnowflakeID(), 10)
+}
+
+// GenerateInt64 is GenerateSnowflakeID, implementing Int64IDGenerator
+func (sg *SnowflakeGenerator) GenerateInt64() int64 {
+	return sg.GenerateSnowflakeID()
+}
+
+// Generate returns a new Snowflake ID in decimal, so an AtomicSnowflakeGenerator can be used as an IDGenerator
+func (g *AtomicSnowflakeGenerator) Generate() string {
+	return strconv.FormatInt(g.GenerateSnowflakeID(), 10)
+}
+
+// GenerateInt64 is GenerateSnowflakeID, implementing Int64IDGenerator
+func (g *AtomicSnowflakeGenerator) GenerateInt64() int64 {
+	return g.GenerateSnowflakeID()
+}
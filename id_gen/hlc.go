@@ -0,0 +1,111 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	hlcLogicalBits = 16
+	hlcLogicalMask = 1<<hlcLogicalBits - 1
+	hlcIDLength    = 16 // zero-padded hex keeps string IDs sortable
+)
+
+var ErrInvalidHLCID = errors.New("invalid HLC ID")
+
+// HLC is a hybrid logical clock: it packs physical Unix milliseconds (high 48 bits) with a 16-bit
+// logical counter, so values are strictly increasing across concurrent callers even if the wall
+// clock stalls or moves backwards.
+type HLC struct {
+	mutex    sync.Mutex
+	now      func() time.Time
+	physical int64
+	logical  int64
+}
+
+// NewHLC creates a hybrid logical clock driven by the wall clock
+func NewHLC() *HLC {
+	return NewHLCWithClock(time.Now)
+}
+
+// NewHLCWithClock creates a hybrid logical clock reading physical time from now, e.g. a frozen clock in tests
+func NewHLCWithClock(now func() time.Time) *HLC {
+	return &HLC{now: now}
+}
+
+// Now returns the next packed HLC timestamp, strictly greater than any previously returned or observed
+func (h *HLC) Now() int64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	wall := h.now().UnixMilli()
+	if wall > h.physical {
+		h.physical = wall
+		h.logical = 0
+	} else {
+		h.tick()
+	}
+	return h.physical<<hlcLogicalBits | h.logical
+}
+
+// Update merges a timestamp received from another node, so that subsequent local timestamps
+// order after it, and returns the new local timestamp
+func (h *HLC) Update(remote int64) int64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	wall := h.now().UnixMilli()
+	remotePhysical, remoteLogical := remote>>hlcLogicalBits, remote&hlcLogicalMask
+	switch {
+	case wall > h.physical && wall > remotePhysical:
+		h.physical, h.logical = wall, 0
+	case remotePhysical > h.physical:
+		h.physical, h.logical = remotePhysical, remoteLogical
+		h.tick()
+	case remotePhysical == h.physical:
+		h.logical = max(h.logical, remoteLogical)
+		h.tick()
+	default:
+		h.tick()
+	}
+	return h.physical<<hlcLogicalBits | h.logical
+}
+
+// tick advances the logical counter, rolling over into the physical part when it overflows
+func (h *HLC) tick() {
+	h.logical++
+	if h.logical > hlcLogicalMask {
+		h.physical++
+		h.logical = 0
+	}
+}
+
+// DecodeHLC splits a packed HLC timestamp into its physical time and logical counter
+func DecodeHLC(ts int64) (physical time.Time, logical uint16) {
+	return time.UnixMilli(ts >> hlcLogicalBits), uint16(ts & hlcLogicalMask)
+}
+
+var defaultHLC = NewHLC()
+
+// GenerateHLCID returns the next timestamp of the process-wide HLC as a 16 character zero-padded
+// hex string, so IDs also sort lexicographically
+func GenerateHLCID() string {
+	id := fmt.Sprintf("%016x", defaultHLC.Now())
+	notifyGenerate("hlc")
+	return id
+}
+
+// ParseHLCID converts an ID produced by GenerateHLCID back into its packed timestamp
+func ParseHLCID(id string) (int64, error) {
+	if len(id) != hlcIDLength {
+		return 0, ErrInvalidHLCID
+	}
+	ts, err := strconv.ParseInt(id, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidHLCID, err)
+	}
+	return ts, nil
+}
@@ -0,0 +1,83 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHLCFrozenClock(t *testing.T) {
+	frozen := time.UnixMilli(1_700_000_000_000)
+	clock := NewHLCWithClock(func() time.Time { return frozen })
+
+	previous := clock.Now()
+	for i := 1; i < 1000; i++ {
+		next := clock.Now()
+		if next <= previous {
+			t.Fatalf("Now() #%d = %d after %d, want strictly increasing", i, next, previous)
+		}
+		previous = next
+	}
+	physical, logical := DecodeHLC(previous)
+	if !physical.Equal(frozen) || logical != 999 {
+		t.Errorf("DecodeHLC() = %v, %d, want %v, 999", physical, logical, frozen)
+	}
+}
+
+func TestHLCClockMovesBackwards(t *testing.T) {
+	wall := time.UnixMilli(1_700_000_000_000)
+	clock := NewHLCWithClock(func() time.Time { return wall })
+	first := clock.Now()
+	wall = wall.Add(-time.Second)
+	if second := clock.Now(); second <= first {
+		t.Errorf("Now() after a rollback = %d, want > %d", second, first)
+	}
+}
+
+func TestHLCLogicalOverflow(t *testing.T) {
+	frozen := time.UnixMilli(1_700_000_000_000)
+	clock := NewHLCWithClock(func() time.Time { return frozen })
+	var last int64
+	for i := 0; i <= hlcLogicalMask+1; i++ {
+		last = clock.Now()
+	}
+	physical, logical := DecodeHLC(last)
+	if !physical.Equal(frozen.Add(time.Millisecond)) || logical != 0 {
+		t.Errorf("after overflowing the counter DecodeHLC() = %v, %d, want the next millisecond, 0", physical, logical)
+	}
+}
+
+func TestHLCUpdate(t *testing.T) {
+	wall := time.UnixMilli(1_000)
+	clock := NewHLCWithClock(func() time.Time { return wall })
+	remote := int64(5_000)<<hlcLogicalBits | 7
+	if got := clock.Update(remote); got <= remote {
+		t.Errorf("Update(%d) = %d, want it to order after the remote timestamp", remote, got)
+	}
+	if got := clock.Now(); got <= remote {
+		t.Errorf("Now() after Update = %d, want > %d", got, remote)
+	}
+}
+
+func TestHLCID(t *testing.T) {
+	previous := GenerateHLCID()
+	for i := 0; i < 100; i++ {
+		id := GenerateHLCID()
+		if len(id) != 16 || id <= previous {
+			t.Fatalf("GenerateHLCID() = %s after %s, want increasing 16 character IDs", id, previous)
+		}
+		previous = id
+	}
+	ts, err := ParseHLCID(previous)
+	if err != nil {
+		t.Fatalf("ParseHLCID() error = %v", err)
+	}
+	if physical, _ := DecodeHLC(ts); time.Since(physical) > time.Minute {
+		t.Errorf("ParseHLCID() gave physical time %v, want about now", physical)
+	}
+	for _, invalid := range []string{"", "123", "zzzzzzzzzzzzzzzz"} {
+		if _, err := ParseHLCID(invalid); !errors.Is(err, ErrInvalidHLCID) {
+			t.Errorf("ParseHLCID(%q) error = %v, want ErrInvalidHLCID", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"errors"
+	"time"
+)
+
+const (
+	hybridTimeChars   = 10 // 50 bits, enough for a 48-bit millisecond timestamp
+	hybridRandomBytes = 10 // 80 bits of entropy per ID
+	hybridIDLength    = hybridTimeChars + hybridRandomBytes*8/5
+)
+
+var ErrInvalidHybridID = errors.New("invalid hybrid ID")
+
+// GenerateHybridID generates a 26 character ID made of a base32 millisecond timestamp followed by
+// 80 random bits, so IDs sort by creation time (to the millisecond) while staying collision safe.
+// IDs created within the same millisecond are not ordered relative to each other.
+func GenerateHybridID() string {
+	random := make([]byte, hybridRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return ""
+	}
+
+	id := make([]byte, 0, hybridIDLength)
+	id = appendBase32Uint(id, uint64(time.Now().UnixMilli()), hybridTimeChars)
+	id = appendBase32Bytes(id, random)
+	notifyGenerate("hybrid")
+	return string(id)
+}
+
+// appendBase32Bytes appends b, whose length must be a multiple of 5, as Crockford base32, 8 characters per 5 bytes
+func appendBase32Bytes(dst, b []byte) []byte {
+	for i := 0; i < len(b); i += 5 {
+		chunk := uint64(b[i])<<32 | uint64(b[i+1])<<24 | uint64(b[i+2])<<16 | uint64(b[i+3])<<8 | uint64(b[i+4])
+		dst = appendBase32Uint(dst, chunk, 8)
+	}
+	return dst
+}
+
+// HybridIDTime extracts the creation time embedded in an ID produced by GenerateHybridID
+func HybridIDTime(id string) (time.Time, error) {
+	if len(id) != hybridIDLength {
+		return time.Time{}, ErrInvalidHybridID
+	}
+	millis, ok := parseBase32Uint(id[:hybridTimeChars])
+	if !ok {
+		return time.Time{}, ErrInvalidHybridID
+	}
+	if _, ok := parseBase32Uint(id[hybridTimeChars : hybridTimeChars+8]); !ok {
+		return time.Time{}, ErrInvalidHybridID
+	}
+	if _, ok := parseBase32Uint(id[hybridTimeChars+8:]); !ok {
+		return time.Time{}, ErrInvalidHybridID
+	}
+	return time.UnixMilli(int64(millis)), nil
+}
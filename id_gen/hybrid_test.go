@@ -0,0 +1,70 @@
+package id_gen
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerateHybridIDOrdering(t *testing.T) {
+	previous := GenerateHybridID()
+	for i := 0; i < 5; i++ {
+		time.Sleep(2 * time.Millisecond)
+		next := GenerateHybridID()
+		if next <= previous {
+			t.Fatalf("GenerateHybridID() = %s after %s, want it to sort later", next, previous)
+		}
+		previous = next
+	}
+}
+
+func TestGenerateHybridIDConcurrentUniqueness(t *testing.T) {
+	const goroutines, perGoroutine = 8, 2000
+	var (
+		mutex sync.Mutex
+		seen  = make(map[string]struct{}, goroutines*perGoroutine)
+		wg    sync.WaitGroup
+	)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]string, perGoroutine)
+			for i := range ids {
+				ids[i] = GenerateHybridID()
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, id := range ids {
+				seen[id] = struct{}{}
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != goroutines*perGoroutine {
+		t.Errorf("got %d distinct IDs, want %d", len(seen), goroutines*perGoroutine)
+	}
+}
+
+func TestHybridIDTime(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	id := GenerateHybridID()
+	after := time.Now()
+	if len(id) != 26 {
+		t.Fatalf("GenerateHybridID() = %q, want 26 characters", id)
+	}
+	created, err := HybridIDTime(id)
+	if err != nil {
+		t.Fatalf("HybridIDTime() error = %v", err)
+	}
+	if created.Before(before) || created.After(after) {
+		t.Errorf("HybridIDTime() = %v, want between %v and %v", created, before, after)
+	}
+
+	for _, invalid := range []string{"", id[:25], id[:10] + "UUUUUUUUUUUUUUUU", "!" + id[1:]} {
+		if _, err := HybridIDTime(invalid); !errors.Is(err, ErrInvalidHybridID) {
+			t.Errorf("HybridIDTime(%q) error = %v, want ErrInvalidHybridID", invalid, err)
+		}
+	}
+}
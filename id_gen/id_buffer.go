@@ -0,0 +1,85 @@
+package id_gen
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrIDBufferClosed = errors.New("ID buffer closed")
+
+// IDBuffer keeps up to size IDs pre-generated by a background goroutine, which refills the buffer as
+// IDs are taken, so fetching an ID during a traffic spike is a channel receive instead of a generator call
+// (and, for Snowflake IDs, a possible wait for the next millisecond).
+type IDBuffer struct {
+	ids  chan string
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// contextIDGenerator is implemented by generators that can abandon a blocking Generate, such as
+// RateLimitedGenerator
+type contextIDGenerator interface {
+	GenerateContext(ctx context.Context) (string, error)
+}
+
+// NewIDBuffer starts filling a buffer of size IDs from generator, e.g. a SnowflakeGenerator or
+// GeneratorFunc(GenerateSortableId). A size below 1 is treated as 1. Call Close to stop the refill goroutine.
+// If generator has a GenerateContext method, as RateLimitedGenerator does, Close also interrupts a
+// pending call to it; otherwise Close waits for the generator call in progress to return.
+func NewIDBuffer(generator IDGenerator, size int) *IDBuffer {
+	ctx, stop := context.WithCancel(context.Background())
+	b := &IDBuffer{
+		ids:  make(chan string, max(size, 1)),
+		stop: stop,
+		done: make(chan struct{}),
+	}
+	go b.fill(ctx, generator)
+	return b
+}
+
+// Next returns a buffered ID, waiting for the refill goroutine if the buffer is empty. It returns
+// ctx.Err() if ctx is done first, and ErrIDBufferClosed once the buffer is closed and drained.
+func (b *IDBuffer) Next(ctx context.Context) (string, error) {
+	select {
+	case id, ok := <-b.ids:
+		if !ok {
+			return "", ErrIDBufferClosed
+		}
+		return id, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Len returns the number of IDs currently buffered
+func (b *IDBuffer) Len() int {
+	return len(b.ids)
+}
+
+// Close stops refilling the buffer and waits for the refill goroutine to exit. IDs already buffered
+// can still be taken with Next. Close may be called more than once.
+func (b *IDBuffer) Close() {
+	b.stop()
+	<-b.done
+}
+
+func (b *IDBuffer) fill(ctx context.Context, generator IDGenerator) {
+	defer close(b.done)
+	defer close(b.ids)
+	for ctx.Err() == nil {
+		var id string
+		if cg, ok := generator.(contextIDGenerator); ok {
+			var err error
+			if id, err = cg.GenerateContext(ctx); err != nil {
+				return
+			}
+		} else {
+			id = generator.Generate()
+		}
+		select {
+		case b.ids <- id:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
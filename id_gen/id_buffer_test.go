@@ -0,0 +1,155 @@
+package id_gen
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForLen polls until buffer holds want IDs, failing the test after a second
+func waitForLen(t *testing.T, buffer *IDBuffer, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for buffer.Len() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Len() = %d, want %d", buffer.Len(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestIDBufferFillsAndRefills(t *testing.T) {
+	buffer := NewIDBuffer(NewFakeGenerator("id-"), 4)
+	defer buffer.Close()
+	waitForLen(t, buffer, 4)
+
+	for _, want := range []string{"id-1", "id-2", "id-3"} {
+		got, err := buffer.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Next() = %q, want %q", got, want)
+		}
+	}
+	waitForLen(t, buffer, 4)
+	if got, _ := buffer.Next(context.Background()); got != "id-4" {
+		t.Errorf("Next() after the refill = %q, want id-4", got)
+	}
+}
+
+func TestIDBufferMinimumSize(t *testing.T) {
+	for _, size := range []int{0, -5} {
+		buffer := NewIDBuffer(NewFakeGenerator(""), size)
+		waitForLen(t, buffer, 1)
+		if got, err := buffer.Next(context.Background()); err != nil || got != "1" {
+			t.Errorf("NewIDBuffer(%d).Next() = %q, %v, want 1", size, got, err)
+		}
+		buffer.Close()
+	}
+}
+
+func TestIDBufferNextContext(t *testing.T) {
+	release := make(chan struct{})
+	blocked := GeneratorFunc(func() string {
+		<-release
+		return "late"
+	})
+	buffer := NewIDBuffer(blocked, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := buffer.Next(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Next() with a stalled generator error = %v, want DeadlineExceeded", err)
+	}
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if _, err := buffer.Next(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Next() with a canceled context error = %v, want Canceled", err)
+	}
+
+	close(release)
+	if got, err := buffer.Next(context.Background()); err != nil || got != "late" {
+		t.Errorf("Next() after the generator recovers = %q, %v, want late", got, err)
+	}
+	buffer.Close()
+}
+
+func TestIDBufferClose(t *testing.T) {
+	buffer := NewIDBuffer(NewFakeGenerator(""), 3)
+	waitForLen(t, buffer, 3)
+	buffer.Close()
+	buffer.Close()
+
+	// IDs buffered before Close are still handed out, then Next reports the buffer closed
+	for i := 0; i < 3; i++ {
+		if _, err := buffer.Next(context.Background()); err != nil {
+			t.Fatalf("Next() of a buffered ID after Close() error = %v", err)
+		}
+	}
+	if _, err := buffer.Next(context.Background()); !errors.Is(err, ErrIDBufferClosed) {
+		t.Errorf("Next() on a drained closed buffer error = %v, want ErrIDBufferClosed", err)
+	}
+}
+
+func TestIDBufferConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 500
+	buffer := NewIDBuffer(NewSnowflakeGenerator(1), 64)
+	defer buffer.Close()
+
+	var (
+		mutex sync.Mutex
+		seen  = make(map[string]bool, workers*perWorker)
+		wg    sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id, err := buffer.Next(context.Background())
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				mutex.Lock()
+				if seen[id] {
+					t.Errorf("Next() returned %s twice", id)
+				}
+				seen[id] = true
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkIDBufferNext(b *testing.B) {
+	buffer := NewIDBuffer(NewSnowflakeGenerator(1), 4096)
+	defer buffer.Close()
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = buffer.Next(ctx)
+	}
+}
+
+func TestIDBufferCloseInterruptsRateLimitedGenerator(t *testing.T) {
+	// one token per hour: after the burst the refill goroutine is left waiting on the limiter
+	buffer := NewIDBuffer(NewRateLimitedGenerator(NewFakeGenerator(""), 1.0/3600, 1), 5)
+	waitForLen(t, buffer, 1)
+
+	closed := make(chan struct{})
+	go func() {
+		buffer.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close() blocked on a generator waiting for its rate limit")
+	}
+}
@@ -2,31 +2,108 @@ package id_gen
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	mrand "math/rand"
 	"net"
 	"os"
 	"sync"
 	"time"
 
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
 	"github.com/google/uuid"
 	"github.com/oklog/ulid/v2"
 )
 
 // region interface
+
+// OnGenerate, if non-nil, is invoked after each package-level generator produces an ID, with kind
+// naming the scheme ("uuid", "snowflake", "ulid", "hex", ...). Install it during initialization,
+// before IDs are generated; it is called from every generating goroutine so it must be concurrency safe.
+var OnGenerate func(kind string)
+
+// notifyGenerate reports a generated ID to OnGenerate
+func notifyGenerate(kind string) {
+	if hook := OnGenerate; hook != nil {
+		hook(kind)
+	}
+}
+
 // GenerateUUID generates a new UUID
 func GenerateUUID() string {
-	return uuid.New().String()
+	id := uuid.New().String()
+	notifyGenerate("uuid")
+	return id
 }
 
 func GenerateUuidWithPrefix(prefix string) string {
 	return prefix + "-" + GenerateUUID()
 }
 
+// GenerateUUIDv7 generates a time-ordered version 7 UUID (48-bit millisecond timestamp followed by
+// random bits), which keeps B-tree indexes compact when used as a primary key. Returns "" on failure.
+func GenerateUUIDv7() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return ""
+	}
+	notifyGenerate("uuidv7")
+	return id.String()
+}
+
+// GenerateUUIDv7WithPrefix generates a version 7 UUID joined to prefix with a hyphen, like GenerateUuidWithPrefix
+func GenerateUUIDv7WithPrefix(prefix string) string {
+	return prefix + "-" + GenerateUUIDv7()
+}
+
+// UUIDv7Time extracts the millisecond creation time embedded in a version 7 UUID
+func UUIDv7Time(s string) (time.Time, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+	if u.Version() != 7 {
+		return time.Time{}, fmt.Errorf("%w: version %d is not 7", ErrInvalidUUID, u.Version())
+	}
+	sec, nsec := u.Time().UnixTime()
+	return time.Unix(sec, nsec), nil
+}
+
 // GenerateSnowflakeID generates a new Snowflake ID using the singleton generator
 func GenerateSnowflakeID() int64 {
 	once.Do(initSnowflakeGenerator)
-	return snowflakeGenerator.GenerateSnowflakeID()
+	id := snowflakeGenerator.GenerateSnowflakeID()
+	notifyGenerate("snowflake")
+	return id
+}
+
+// GenerateSnowflakeIDSafe generates a new Snowflake ID using the singleton generator, returning an error
+// instead of a possibly duplicate ID when the clock moved backwards or the sequence is exhausted
+func GenerateSnowflakeIDSafe() (int64, error) {
+	once.Do(initSnowflakeGenerator)
+	id, err := snowflakeGenerator.GenerateSnowflakeIDSafe()
+	if err != nil {
+		return 0, err
+	}
+	notifyGenerate("snowflake")
+	return id, nil
+}
+
+// MeasureSnowflakeThroughput generates as many Snowflake IDs as possible within d and returns the count.
+// It uses a fresh generator, with the machine ID the singleton would resolve, so the singleton's sequence
+// state is left untouched.
+func MeasureSnowflakeThroughput(d time.Duration) int64 {
+	generator := NewSnowflakeGenerator(resolveMachineID())
+	var count int64
+	deadline := time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		generator.GenerateSnowflakeID()
+		count++
+	}
+	return count
 }
 
 func GenerateRandomHexString(length int) string {
@@ -34,12 +111,105 @@ func GenerateRandomHexString(length int) string {
 	if _, err := rand.Read(bytes); err != nil {
 		return ""
 	}
+	notifyGenerate("hex")
 	return hex.EncodeToString(bytes)
 }
 
+// GenerateSortableId generates a ULID using the shared monotonic entropy source,
+// so IDs generated within the same millisecond still sort in generation order
 func GenerateSortableId() string {
-	entropy := ulid.Monotonic(mrand.New(mrand.NewSource(time.Now().UnixNano())), 0)
-	return ulid.MustNew(ulid.Timestamp(time.Now()), entropy).String()
+	return GenerateSortableIdAt(time.Now())
+}
+
+// GenerateSortableIdAt generates a ULID with t as its timestamp (e.g. for backfilling records with their
+// original creation time) using the shared monotonic entropy source. It returns an empty string if t is
+// outside the range ULIDs can represent or entropy fails.
+func GenerateSortableIdAt(t time.Time) string {
+	return generateSortableID(t, defaultEntropy)
+}
+
+// GenerateSortableIdWithEntropy generates a ULID reading its random component from entropy.
+// Passing a deterministic reader (e.g. a seeded math/rand source) yields reproducible ULIDs for tests.
+// It returns an empty string if entropy fails.
+func GenerateSortableIdWithEntropy(entropy io.Reader) string {
+	return generateSortableID(time.Now(), entropy)
+}
+
+// generateSortableID generates a ULID stamped with t, reading its random component from entropy
+func generateSortableID(t time.Time, entropy io.Reader) string {
+	id, err := ulid.New(ulid.Timestamp(t), entropy)
+	if err != nil {
+		return ""
+	}
+	notifyGenerate("ulid")
+	return id.String()
+}
+
+// GenerateCaseInsensitiveSortableID generates a time-ordered ID that only uses uppercase Crockford base32
+// (no I, L, O or U), so it sorts identically whether compared exactly or case-folded and is safe for
+// case-insensitive filesystems and collations. It is equivalent to GenerateSortableId, whose ULIDs are
+// already uppercase; the name documents the guarantee at call sites that depend on it. The trade-off
+// versus a base62 ID is density: a ULID carries 48 bits of time and 80 random bits in 26 characters,
+// whereas base62 fits ~131 bits into the same length but only sorts correctly when compared case-sensitively.
+func GenerateCaseInsensitiveSortableID() string {
+	return GenerateSortableId()
+}
+
+// GenerateUUIDPair generates a random (v4) UUID and returns both its canonical text and its 16 bytes
+func GenerateUUIDPair() (text string, bytes [16]byte) {
+	id := uuid.New()
+	notifyGenerate("uuid")
+	return id.String(), id
+}
+
+// GenerateULIDPair generates a ULID from the shared monotonic entropy source and returns both its
+// 26 character text and its 16 bytes. It returns an empty string and zero bytes if entropy fails.
+func GenerateULIDPair() (text string, bytes [16]byte) {
+	id, err := ulid.New(ulid.Timestamp(time.Now()), defaultEntropy)
+	if err != nil {
+		return "", [16]byte{}
+	}
+	notifyGenerate("ulid")
+	return id.String(), id
+}
+
+// endregion
+
+// region ULID entropy details
+
+// defaultEntropy is the package-wide monotonic ULID entropy source. One locked reader is shared by
+// every caller, instead of allocating a math/rand source per ID, which also keeps IDs minted in the
+// same millisecond monotonic across goroutines.
+var defaultEntropy = &lockedMonotonicReader{
+	reader: ulid.Monotonic(mrand.New(mrand.NewSource(cryptoSeed())), 0),
+}
+
+// cryptoSeed returns a math/rand seed read from crypto/rand, so the ULID random bits of different
+// processes started at the same instant don't collide. It falls back to the clock if crypto/rand fails.
+func cryptoSeed() int64 {
+	var seed [8]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.BigEndian.Uint64(seed[:]))
+}
+
+// lockedMonotonicReader makes a ulid.MonotonicReader safe for concurrent use
+type lockedMonotonicReader struct {
+	mutex  sync.Mutex
+	reader *ulid.MonotonicEntropy
+}
+
+func (r *lockedMonotonicReader) Read(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.reader.Read(p)
+}
+
+func (r *lockedMonotonicReader) MonotonicRead(ms uint64, p []byte) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.reader.MonotonicRead(ms, p)
 }
 
 // endregion
@@ -53,7 +223,7 @@ var (
 
 // initSnowflakeGenerator initializes the singleton SnowflakeGenerator
 func initSnowflakeGenerator() {
-	machineID := getMachineID()
+	machineID := resolveMachineID()
 	snowflakeGenerator = NewSnowflakeGenerator(machineID)
 }
 
@@ -91,45 +261,217 @@ type SnowflakeGenerator struct {
 	lastTimestamp int64
 	sequence      int64
 	machineID     int64
+	layout        snowflakeLayout
+	borrowAhead   int64          // timestamp ticks the generator may run ahead of the wall clock
+	maxWait       time.Duration  // how long to wait for the next millisecond once the sequence is exhausted
+	rollbackWait  time.Duration  // how long GenerateSnowflakeIDSafe waits for a clock that moved backwards
+	lastClock     int64          // wall clock reading at the last generation, to tell rollbacks from running ahead
+	peakTimestamp int64          // highest timestamp issued before GenerateSnowflakeID followed the clock backwards
+	peakSequence  int64          // last sequence issued at peakTimestamp
+	clock         timeutil.Clock // time source, nil for the wall clock
+}
+
+// ErrSequenceExhausted is returned by TryGenerateSnowflakeID when no sequence slot frees up within MaxWait
+var ErrSequenceExhausted = errors.New("snowflake sequence exhausted")
+
+// ErrClockMovedBackwards is returned by GenerateSnowflakeIDSafe when the wall clock is behind the last
+// issued timestamp and doesn't catch up within the rollback tolerance
+var ErrClockMovedBackwards = errors.New("clock moved backwards")
+
+// OnClockRollback, if non-nil, is called whenever a Snowflake generator sees the wall clock behind
+// its last issued timestamp, with the size of the regression, so clock drift can be alerted on.
+// It is called with the generator's lock held, so it must be fast and must not generate IDs.
+var OnClockRollback func(drift time.Duration)
+
+// OnTimestampFabricated, if non-nil, is called whenever GenerateSnowflakeID runs out of sequence,
+// waits out MaxWait and stamps the ID with a timestamp the clock hasn't reached yet, with how far ahead
+// of the clock that timestamp is, so the drift can be tracked. It is called with the generator's lock
+// held, so it must be fast and must not generate IDs.
+var OnTimestampFabricated func(ahead time.Duration)
+
+// defaultSnowflakeMaxWait is the default time spent waiting for the clock after sequence exhaustion
+const defaultSnowflakeMaxWait = time.Millisecond
+
+// SnowflakeOption customizes a SnowflakeGenerator
+type SnowflakeOption func(*SnowflakeGenerator)
+
+// WithBorrowAhead lets the generator borrow up to maxLookahead of future milliseconds when the
+// 12-bit sequence is exhausted, instead of blocking until the wall clock advances. This absorbs
+// bursts above 4096 IDs/ms, but the timestamp embedded in such IDs can be slightly ahead of real time.
+func WithBorrowAhead(maxLookahead time.Duration) SnowflakeOption {
+	return func(sg *SnowflakeGenerator) {
+		sg.borrowAhead = int64(maxLookahead / sg.layout.unit)
+	}
+}
+
+// WithMaxWait bounds how long the generator busy-waits for the next millisecond once the sequence
+// is exhausted (default 1ms). When it elapses, GenerateSnowflakeID keeps throughput up by stamping
+// the ID with lastTimestamp+1, which may be ahead of the real clock and is reported to
+// OnTimestampFabricated, while TryGenerateSnowflakeID keeps timestamps accurate by returning
+// ErrSequenceExhausted instead.
+func WithMaxWait(maxWait time.Duration) SnowflakeOption {
+	return func(sg *SnowflakeGenerator) {
+		sg.maxWait = maxWait
+	}
+}
+
+// WithRollbackTolerance lets GenerateSnowflakeIDSafe wait up to tolerance for the wall clock to catch
+// up after it moved backwards (e.g. an NTP correction) before failing with ErrClockMovedBackwards.
+// The default is 0: fail immediately.
+func WithRollbackTolerance(tolerance time.Duration) SnowflakeOption {
+	return func(sg *SnowflakeGenerator) {
+		sg.rollbackWait = tolerance
+	}
+}
+
+// WithClock makes the generator read time from clock, e.g. a timeutil.FakeClock for deterministic tests.
+// Waits for sequence exhaustion and rollback tolerance are still measured in real time.
+func WithClock(clock timeutil.Clock) SnowflakeOption {
+	return func(sg *SnowflakeGenerator) {
+		sg.clock = clock
+	}
 }
 
 // NewSnowflakeGenerator creates a new SnowflakeGenerator
-func NewSnowflakeGenerator(machineID int64) *SnowflakeGenerator {
-	return &SnowflakeGenerator{
+func NewSnowflakeGenerator(machineID int64, opts ...SnowflakeOption) *SnowflakeGenerator {
+	return newSnowflakeGenerator(machineID, defaultSnowflakeLayout, opts)
+}
+
+func newSnowflakeGenerator(machineID int64, layout snowflakeLayout, opts []SnowflakeOption) *SnowflakeGenerator {
+	sg := &SnowflakeGenerator{
 		lastTimestamp: 0,
 		sequence:      0,
-		machineID:     machineID & 0x3FF, // Ensure machineID is 10 bits
+		machineID:     machineID & layout.machineMask(), // Ensure machineID fits the layout
+		layout:        layout,
+		maxWait:       defaultSnowflakeMaxWait,
+	}
+	for _, opt := range opts {
+		opt(sg)
 	}
+	return sg
 }
 
-// GenerateSnowflakeID generates a new Snowflake ID
+// GenerateSnowflakeID generates a new Snowflake ID. It never fails: if the sequence stays exhausted for
+// MaxWait it stamps the ID ahead of the clock and reports that to OnTimestampFabricated.
 func (sg *SnowflakeGenerator) GenerateSnowflakeID() int64 {
+	id, _ := sg.generate(false, false)
+	return id
+}
+
+// TryGenerateSnowflakeID generates a new Snowflake ID, returning ErrSequenceExhausted if the sequence
+// for the current millisecond is used up and the clock doesn't advance within MaxWait
+func (sg *SnowflakeGenerator) TryGenerateSnowflakeID() (int64, error) {
+	return sg.generate(true, false)
+}
+
+// GenerateSnowflakeIDSafe is TryGenerateSnowflakeID with clock rollback protection: if the wall clock
+// reads earlier than it did at the previous generation, it waits up to the rollback tolerance for the
+// clock to catch up and otherwise returns ErrClockMovedBackwards, instead of minting IDs that may
+// duplicate earlier ones. It never issues a timestamp below one already issued. Rollbacks seen by any
+// generation method are reported to OnClockRollback.
+func (sg *SnowflakeGenerator) GenerateSnowflakeIDSafe() (int64, error) {
+	return sg.generate(true, true)
+}
+
+// generate produces the next ID; in strict mode it fails rather than fabricating a future timestamp,
+// and with rollbackSafe it fails rather than reusing timestamps after the clock moved backwards
+func (sg *SnowflakeGenerator) generate(strict, rollbackSafe bool) (int64, error) {
 	sg.mutex.Lock()
 	defer sg.mutex.Unlock()
+	return sg.generateLocked(strict, rollbackSafe)
+}
 
-	timestamp := time.Now().UnixMilli()
+// generateLocked is generate for callers already holding the mutex
+func (sg *SnowflakeGenerator) generateLocked(strict, rollbackSafe bool) (int64, error) {
+	now := sg.now()
+	if drift := sg.lastClock - now; drift > 0 {
+		if hook := OnClockRollback; hook != nil {
+			hook(time.Duration(drift) * sg.layout.unit)
+		}
+		if rollbackSafe {
+			if now = sg.awaitClock(sg.lastClock); now < sg.lastClock {
+				return 0, fmt.Errorf("%w by %v", ErrClockMovedBackwards, time.Duration(sg.lastClock-now)*sg.layout.unit)
+			}
+		}
+	}
+	sg.lastClock = now
+	if rollbackSafe {
+		sg.resumeFromPeak()
+	}
+	timestamp := now
+	if (sg.borrowAhead > 0 || rollbackSafe) && timestamp < sg.lastTimestamp {
+		// Still inside a borrowed or fabricated future millisecond
+		timestamp = sg.lastTimestamp
+	}
 
+	sequence := int64(0)
 	if timestamp == sg.lastTimestamp {
-		sg.sequence = (sg.sequence + 1) & 0xFFF
-		if sg.sequence == 0 {
-			timeout := time.After(time.Millisecond)
+		sequence = (sg.sequence + 1) & sg.layout.sequenceMask()
+		if sequence == 0 {
+			if sg.borrowAhead > 0 && sg.lastTimestamp+1-now <= sg.borrowAhead {
+				timestamp = sg.lastTimestamp + 1
+			}
+			deadline := time.Now().Add(sg.maxWait)
 			for timestamp <= sg.lastTimestamp {
-				select {
-				case <-timeout:
+				if time.Now().After(deadline) {
+					if strict {
+						return 0, ErrSequenceExhausted
+					}
 					// If we've waited too long, generate a new timestamp
 					timestamp = sg.lastTimestamp + 1
-				default:
-					timestamp = time.Now().UnixMilli()
+					if hook := OnTimestampFabricated; hook != nil {
+						hook(time.Duration(timestamp-now) * sg.layout.unit)
+					}
+					break
 				}
+				timestamp = sg.now()
 			}
 		}
-	} else {
-		sg.sequence = 0
 	}
 
+	if timestamp < sg.lastTimestamp {
+		// remember where the IDs issued so far end before following the clock backwards
+		if sg.lastTimestamp > sg.peakTimestamp {
+			sg.peakTimestamp, sg.peakSequence = sg.lastTimestamp, sg.sequence
+		} else if sg.lastTimestamp == sg.peakTimestamp {
+			sg.peakSequence = max(sg.peakSequence, sg.sequence)
+		}
+	}
+	sg.sequence = sequence
 	sg.lastTimestamp = timestamp
 
-	return (timestamp << 22) | (sg.machineID << 12) | sg.sequence
+	return sg.layout.compose(timestamp, sg.machineID, sg.sequence), nil
+}
+
+// resumeFromPeak moves the generator's state back above every ID issued so far, in case GenerateSnowflakeID
+// followed the clock backwards, so the rollback-safe paths never reissue an ID
+func (sg *SnowflakeGenerator) resumeFromPeak() {
+	if sg.lastTimestamp < sg.peakTimestamp || sg.lastTimestamp == sg.peakTimestamp && sg.sequence < sg.peakSequence {
+		sg.lastTimestamp, sg.sequence = sg.peakTimestamp, sg.peakSequence
+	}
+}
+
+// now returns the current timestamp in the generator's layout, read from its clock
+func (sg *SnowflakeGenerator) now() int64 {
+	if sg.clock == nil {
+		return sg.layout.now()
+	}
+	return sg.layout.ticks(sg.clock.Now())
+}
+
+// awaitClock sleeps until the clock reaches target or the rollback tolerance runs out, returning the latest reading
+func (sg *SnowflakeGenerator) awaitClock(target int64) int64 {
+	deadline := time.Now().Add(sg.rollbackWait)
+	now := sg.now()
+	for now < target {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		time.Sleep(min(time.Duration(target-now)*sg.layout.unit, remaining))
+		now = sg.now()
+	}
+	return now
 }
 
 // endregion
@@ -0,0 +1,401 @@
+package id_gen
+
+import (
+	"errors"
+	"io"
+	mrand "math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+func TestOnGenerate(t *testing.T) {
+	var kinds []string
+	OnGenerate = func(kind string) { kinds = append(kinds, kind) }
+	defer func() { OnGenerate = nil }()
+
+	tests := []struct {
+		kind     string
+		generate func()
+	}{
+		{"uuid", func() { GenerateUUID() }},
+		{"uuid", func() { GenerateUuidWithPrefix("user_") }},
+		{"uuidv7", func() { GenerateUUIDv7() }},
+		{"snowflake", func() { GenerateSnowflakeID() }},
+		{"ulid", func() { GenerateSortableId() }},
+		{"hex", func() { GenerateRandomHexString(8) }},
+		{"hybrid", func() { GenerateHybridID() }},
+	}
+	for _, tt := range tests {
+		kinds = nil
+		tt.generate()
+		if len(kinds) != 1 || kinds[0] != tt.kind {
+			t.Errorf("generating a %s ID reported %v, want [%s]", tt.kind, kinds, tt.kind)
+		}
+	}
+}
+
+func TestMeasureSnowflakeThroughput(t *testing.T) {
+	count := MeasureSnowflakeThroughput(50 * time.Millisecond)
+	// a 4096-per-millisecond sequence comfortably clears this even on a slow CI runner
+	if count < 10_000 {
+		t.Errorf("MeasureSnowflakeThroughput(50ms) = %d, want at least 10000", count)
+	}
+
+	before := GenerateSnowflakeID()
+	MeasureSnowflakeThroughput(10 * time.Millisecond)
+	if after := GenerateSnowflakeID(); after <= before {
+		t.Errorf("GenerateSnowflakeID() after measuring = %d, want > %d", after, before)
+	}
+}
+
+func TestGenerateSortableIdWithEntropy(t *testing.T) {
+	first := GenerateSortableIdWithEntropy(mrand.New(mrand.NewSource(1)))
+	second := GenerateSortableIdWithEntropy(mrand.New(mrand.NewSource(1)))
+	if len(first) != 26 || len(second) != 26 {
+		t.Fatalf("GenerateSortableIdWithEntropy() = %q, %q, want 26 characters", first, second)
+	}
+	// the first 10 characters are the millisecond timestamp, the rest comes from entropy
+	if first[10:] != second[10:] {
+		t.Errorf("random components %s and %s differ for the same seed", first[10:], second[10:])
+	}
+	if got := GenerateSortableIdWithEntropy(iotest.ErrReader(io.ErrUnexpectedEOF)); got != "" {
+		t.Errorf("GenerateSortableIdWithEntropy(failing reader) = %q, want empty", got)
+	}
+}
+
+func TestGenerateSortableIdMonotonic(t *testing.T) {
+	previous := GenerateSortableId()
+	for i := 0; i < 10_000; i++ {
+		next := GenerateSortableId()
+		if next <= previous {
+			t.Fatalf("GenerateSortableId() = %s after %s, want strictly increasing", next, previous)
+		}
+		previous = next
+	}
+}
+
+func TestGenerateSortableIdAt(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+		want string // the 10 character timestamp prefix, or "" for an unrepresentable time
+	}{
+		{"unix epoch", time.UnixMilli(0), "0000000000"},
+		{"backfill", time.Date(2016, 7, 30, 23, 54, 10, 259_000_000, time.UTC), "01ARZ3NDEK"},
+		{"max representable", time.UnixMilli(int64(ulid.MaxTime())), "7ZZZZZZZZZ"},
+		{"past max", time.UnixMilli(int64(ulid.MaxTime()) + 1), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateSortableIdAt(tt.at)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("GenerateSortableIdAt(%v) = %q, want empty", tt.at, got)
+				}
+				return
+			}
+			parsed, err := ulid.ParseStrict(got)
+			if err != nil {
+				t.Fatalf("GenerateSortableIdAt(%v) = %q: %v", tt.at, got, err)
+			}
+			if got[:10] != tt.want || !ulid.Time(parsed.Time()).Equal(tt.at) {
+				t.Errorf("GenerateSortableIdAt(%v) = %q, want timestamp %s", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSortableIdAtSameMillisecond(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	previous := GenerateSortableIdAt(at)
+	for i := 0; i < 1000; i++ {
+		next := GenerateSortableIdAt(at)
+		if next[:10] != previous[:10] || next <= previous {
+			t.Fatalf("GenerateSortableIdAt() = %s after %s in the same millisecond, want a larger ID", next, previous)
+		}
+		previous = next
+	}
+}
+
+func TestGenerateSortableIdConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 2000
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	results := make([][]string, workers)
+	var wg sync.WaitGroup
+	for w := range results {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			ids := make([]string, perWorker)
+			for i := range ids {
+				ids[i] = GenerateSortableIdAt(at)
+			}
+			results[w] = ids
+		}(w)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, workers*perWorker)
+	for _, ids := range results {
+		for i, id := range ids {
+			if seen[id] {
+				t.Fatalf("GenerateSortableIdAt() returned %s twice across goroutines", id)
+			}
+			seen[id] = true
+			// the shared reader hands out increasing entropy, so each goroutine sees its own IDs increase
+			if i > 0 && id <= ids[i-1] {
+				t.Fatalf("GenerateSortableIdAt() = %s after %s in one goroutine, want a larger ID", id, ids[i-1])
+			}
+		}
+	}
+}
+
+// BenchmarkGenerateSortableIdNewSource is the old approach of seeding a math/rand source per ID,
+// kept as the baseline for the shared monotonic reader below
+func BenchmarkGenerateSortableIdNewSource(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		entropy := ulid.Monotonic(mrand.New(mrand.NewSource(time.Now().UnixNano())), 0)
+		_ = ulid.MustNew(ulid.Timestamp(time.Now()), entropy).String()
+	}
+}
+
+func BenchmarkGenerateSortableId(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GenerateSortableId()
+	}
+}
+
+func BenchmarkGenerateSortableIdParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			GenerateSortableId()
+		}
+	})
+}
+
+func TestGenerateCaseInsensitiveSortableID(t *testing.T) {
+	ids := make([]string, 2000)
+	for i := range ids {
+		ids[i] = GenerateCaseInsensitiveSortableID()
+		if ids[i] != strings.ToUpper(ids[i]) || strings.ContainsAny(ids[i], "ILOU") {
+			t.Fatalf("GenerateCaseInsensitiveSortableID() = %s, want uppercase Crockford base32", ids[i])
+		}
+	}
+	for i := 1; i < len(ids); i++ {
+		a, b := ids[i-1], ids[i]
+		exact := strings.Compare(a, b)
+		folded := strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		if exact != folded || exact >= 0 {
+			t.Fatalf("%s vs %s: exact comparison %d, case-folded %d, want both -1", a, b, exact, folded)
+		}
+	}
+}
+
+func TestWithBorrowAhead(t *testing.T) {
+	// a frozen clock means every ID past the first 4096 has to come from a borrowed millisecond
+	clock := timeutil.NewFakeClock(time.Now())
+	generator := NewSnowflakeGenerator(1, WithClock(clock), WithBorrowAhead(10*time.Millisecond), WithMaxWait(time.Second))
+
+	start := time.Now()
+	previous := int64(0)
+	for i := 0; i < 3*4096; i++ {
+		id := generator.GenerateSnowflakeID()
+		if id <= previous {
+			t.Fatalf("ID %d = %d after %d, want strictly increasing", i, id, previous)
+		}
+		previous = id
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("a 3x4096 burst took %v, want it to borrow instead of waiting", elapsed)
+	}
+}
+
+func TestTryGenerateSnowflakeIDExhausted(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Now())
+	generator := NewSnowflakeGenerator(1, WithClock(clock), WithMaxWait(5*time.Millisecond))
+	for i := 0; i < 4096; i++ {
+		if _, err := generator.TryGenerateSnowflakeID(); err != nil {
+			t.Fatalf("ID %d within the sequence: error = %v", i, err)
+		}
+	}
+
+	start := time.Now()
+	_, err := generator.TryGenerateSnowflakeID()
+	if !errors.Is(err, ErrSequenceExhausted) {
+		t.Fatalf("TryGenerateSnowflakeID() past the sequence error = %v, want ErrSequenceExhausted", err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("TryGenerateSnowflakeID() gave up after %v, want it to wait out MaxWait", elapsed)
+	}
+
+	clock.Advance(time.Millisecond)
+	if _, err := generator.TryGenerateSnowflakeID(); err != nil {
+		t.Errorf("TryGenerateSnowflakeID() after the clock advanced: error = %v", err)
+	}
+}
+
+func TestGenerateSnowflakeIDReportsFabrication(t *testing.T) {
+	var ahead []time.Duration
+	OnTimestampFabricated = func(d time.Duration) { ahead = append(ahead, d) }
+	defer func() { OnTimestampFabricated = nil }()
+
+	clock := timeutil.NewFakeClock(time.Now())
+	generator := NewSnowflakeGenerator(1, WithClock(clock), WithMaxWait(time.Millisecond))
+	previous := int64(0)
+	for i := 0; i <= 4096; i++ {
+		id := generator.GenerateSnowflakeID()
+		if id <= previous {
+			t.Fatalf("ID %d = %d after %d, want strictly increasing", i, id, previous)
+		}
+		previous = id
+	}
+	if len(ahead) != 1 || ahead[0] != time.Millisecond {
+		t.Errorf("OnTimestampFabricated received %v, want one 1ms lead", ahead)
+	}
+}
+
+func TestGenerateIDPairs(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() (string, [16]byte)
+		encode   func([16]byte) string
+	}{
+		{"uuid", GenerateUUIDPair, func(b [16]byte) string { return uuid.UUID(b).String() }},
+		{"ulid", GenerateULIDPair, func(b [16]byte) string { return ulid.ULID(b).String() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := map[string]bool{}
+			for i := 0; i < 100; i++ {
+				text, bytes := tt.generate()
+				if text == "" {
+					t.Fatal("generated an empty ID")
+				}
+				if encoded := tt.encode(bytes); encoded != text {
+					t.Fatalf("bytes re-encode to %q, want the returned text %q", encoded, text)
+				}
+				if seen[text] {
+					t.Fatalf("generated %q twice", text)
+				}
+				seen[text] = true
+			}
+		})
+	}
+
+	if text, _ := GenerateUUIDPair(); uuid.MustParse(text).Version() != 4 {
+		t.Errorf("GenerateUUIDPair() = %q, want a version 4 UUID", text)
+	}
+}
+
+func TestGenerateUUIDv7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i] = GenerateUUIDv7()
+	}
+	after := time.Now()
+
+	for i, id := range ids {
+		u, err := uuid.Parse(id)
+		if err != nil || u.Version() != 7 || u.Variant() != uuid.RFC4122 {
+			t.Fatalf("GenerateUUIDv7() = %q, want an RFC 4122 version 7 UUID", id)
+		}
+		createdAt, err := UUIDv7Time(id)
+		if err != nil {
+			t.Fatalf("UUIDv7Time() error = %v", err)
+		}
+		if createdAt.Before(before) || createdAt.After(after) {
+			t.Errorf("UUIDv7Time() = %v, want within [%v, %v]", createdAt, before, after)
+		}
+		if i > 0 && id <= ids[i-1] {
+			t.Errorf("GenerateUUIDv7() = %q after %q, want time ordering", id, ids[i-1])
+		}
+	}
+
+	prefixed := GenerateUUIDv7WithPrefix("ord")
+	if !strings.HasPrefix(prefixed, "ord-") {
+		t.Fatalf("GenerateUUIDv7WithPrefix() = %q, want the ord- prefix", prefixed)
+	}
+	if _, err := UUIDv7Time(strings.TrimPrefix(prefixed, "ord-")); err != nil {
+		t.Errorf("GenerateUUIDv7WithPrefix() = %q does not hold a version 7 UUID: %v", prefixed, err)
+	}
+}
+
+func TestUUIDv7TimeErrors(t *testing.T) {
+	for _, id := range []string{"", "not-a-uuid", GenerateUUID()} {
+		if _, err := UUIDv7Time(id); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("UUIDv7Time(%q) error = %v, want ErrInvalidUUID", id, err)
+		}
+	}
+}
+
+func TestGenerateSnowflakeIDSafeRollback(t *testing.T) {
+	var drifts []time.Duration
+	OnClockRollback = func(drift time.Duration) { drifts = append(drifts, drift) }
+	defer func() { OnClockRollback = nil }()
+
+	start := time.Now()
+	clock := timeutil.NewFakeClock(start)
+	generator := NewSnowflakeGenerator(1, WithClock(clock))
+	first, err := generator.GenerateSnowflakeIDSafe()
+	if err != nil {
+		t.Fatalf("GenerateSnowflakeIDSafe() error = %v", err)
+	}
+
+	clock.Set(start.Add(-5 * time.Millisecond))
+	if _, err := generator.GenerateSnowflakeIDSafe(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Errorf("GenerateSnowflakeIDSafe() after a rollback error = %v, want ErrClockMovedBackwards", err)
+	}
+	if len(drifts) != 1 || drifts[0] != 5*time.Millisecond {
+		t.Errorf("OnClockRollback received %v, want one 5ms drift", drifts)
+	}
+
+	// the unsafe path keeps going but still reports the drift
+	generator.GenerateSnowflakeID()
+	if len(drifts) != 2 {
+		t.Errorf("OnClockRollback called %d times, want 2", len(drifts))
+	}
+	// neither the reserving nor the safe path reissues IDs from before the rollback
+	if start, _, err := generator.ReserveSnowflakeRange(2); err != nil || start <= first {
+		t.Errorf("ReserveSnowflakeRange() after the unsafe path = %d, %v, want a range after %d", start, err, first)
+	}
+
+	clock.Set(start)
+	next, err := generator.GenerateSnowflakeIDSafe()
+	if err != nil || next <= first {
+		t.Errorf("GenerateSnowflakeIDSafe() once the clock caught up = %d, %v, want an ID after %d", next, err, first)
+	}
+}
+
+func TestGenerateSnowflakeIDSafeTolerance(t *testing.T) {
+	start := time.Now()
+	clock := timeutil.NewFakeClock(start)
+	generator := NewSnowflakeGenerator(1, WithClock(clock), WithRollbackTolerance(time.Second))
+	first, _ := generator.GenerateSnowflakeIDSafe()
+
+	clock.Set(start.Add(-3 * time.Millisecond))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		clock.Set(start.Add(time.Millisecond))
+	}()
+	next, err := generator.GenerateSnowflakeIDSafe()
+	if err != nil {
+		t.Fatalf("GenerateSnowflakeIDSafe() within the tolerance error = %v", err)
+	}
+	if next <= first {
+		t.Errorf("GenerateSnowflakeIDSafe() = %d after %d, want increasing IDs", next, first)
+	}
+	if got := generator.Decode(next).Timestamp; got.Before(start) {
+		t.Errorf("GenerateSnowflakeIDSafe() minted timestamp %v, want no earlier than %v", got, start)
+	}
+}
@@ -0,0 +1,73 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerateKSortableID(t *testing.T) {
+	tests := []struct {
+		precision TimePrecision
+		length    int
+		unit      time.Duration
+	}{
+		{PrecisionSecond, 21, time.Second},
+		{PrecisionMillisecond, 22, time.Millisecond},
+		{PrecisionMicrosecond, 23, time.Microsecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.precision.String(), func(t *testing.T) {
+			before := time.Now().Truncate(tt.unit)
+			first := GenerateKSortableID(tt.precision)
+			// wait for the next tick so the two IDs fall in different windows
+			time.Sleep(time.Until(time.Now().Truncate(tt.unit).Add(tt.unit)))
+			second := GenerateKSortableID(tt.precision)
+			after := time.Now()
+
+			if len(first) != tt.length || len(second) != tt.length {
+				t.Fatalf("GenerateKSortableID() = %q, %q, want %d characters", first, second, tt.length)
+			}
+			if first >= second {
+				t.Errorf("GenerateKSortableID() = %q then %q a tick later, want ascending", first, second)
+			}
+
+			firstTime, err := KSortableIDTime(first, tt.precision)
+			if err != nil {
+				t.Fatalf("KSortableIDTime() error = %v", err)
+			}
+			secondTime, err := KSortableIDTime(second, tt.precision)
+			if err != nil {
+				t.Fatalf("KSortableIDTime() error = %v", err)
+			}
+			if !firstTime.Equal(firstTime.Truncate(tt.unit)) {
+				t.Errorf("KSortableIDTime() = %v, want a time truncated to %v", firstTime, tt.unit)
+			}
+			if firstTime.Before(before) || !firstTime.Before(secondTime) || secondTime.After(after) {
+				t.Errorf("KSortableIDTime() = %v, %v, want within [%v, %v] and ascending", firstTime, secondTime, before, after)
+			}
+		})
+	}
+}
+
+func TestKSortableIDTimeErrors(t *testing.T) {
+	if id := GenerateKSortableID(TimePrecision(9)); id != "" {
+		t.Errorf("GenerateKSortableID(unknown) = %q, want empty", id)
+	}
+	tests := []struct {
+		name      string
+		id        string
+		precision TimePrecision
+	}{
+		{"wrong precision", GenerateKSortableID(PrecisionSecond), PrecisionMillisecond},
+		{"unknown precision", GenerateKSortableID(PrecisionSecond), TimePrecision(9)},
+		{"invalid characters", "!!!!!!!!!!!!!!!!!!!!!", PrecisionSecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := KSortableIDTime(tt.id, tt.precision); !errors.Is(err, ErrInvalidKSortableID) {
+				t.Errorf("KSortableIDTime(%q, %v) error = %v, want ErrInvalidKSortableID", tt.id, tt.precision, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	ksuidBytes        = 20 // 4 bytes of timestamp + 16 random bytes
+	ksuidTimestampLen = 4
+	ksuidLength       = 27 // base62 characters
+	// ksuidEpoch is the KSUID epoch, 2014-05-13T16:53:20Z, in Unix seconds
+	ksuidEpoch = 1400000000
+)
+
+var ErrInvalidKSUID = errors.New("invalid KSUID")
+
+// KSUID is a Segment K-Sortable Unique IDentifier: a 32-bit second timestamp counted from 2014-05-13
+// followed by 128 random bits, written as 27 base62 characters
+type KSUID [ksuidBytes]byte
+
+// String returns the 27 character base62 form
+func (k KSUID) String() string {
+	return encodeBase62Fixed(k[:], ksuidLength)
+}
+
+// Time returns the creation time, with second precision
+func (k KSUID) Time() time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(k[:ksuidTimestampLen]))+ksuidEpoch, 0)
+}
+
+// Payload returns the 16 random bytes
+func (k KSUID) Payload() []byte {
+	return append([]byte(nil), k[ksuidTimestampLen:]...)
+}
+
+// GenerateKSUID generates a KSUID compatible with github.com/segmentio/ksuid. IDs sort by creation
+// second; IDs created within the same second are not ordered. Returns "" on failure.
+func GenerateKSUID() string {
+	return generateKSUIDAt(time.Now())
+}
+
+// generateKSUIDAt generates a KSUID stamped with t
+func generateKSUIDAt(t time.Time) string {
+	var k KSUID
+	binary.BigEndian.PutUint32(k[:ksuidTimestampLen], uint32(t.Unix()-ksuidEpoch))
+	if _, err := rand.Read(k[ksuidTimestampLen:]); err != nil {
+		return ""
+	}
+	notifyGenerate("ksuid")
+	return k.String()
+}
+
+// GenerateKSUIDWithPrefix generates a KSUID joined to prefix with a hyphen, like GenerateUuidWithPrefix
+func GenerateKSUIDWithPrefix(prefix string) string {
+	return prefix + "-" + GenerateKSUID()
+}
+
+// ParseKSUID parses the 27 character base62 form of a KSUID
+func ParseKSUID(s string) (KSUID, error) {
+	if len(s) != ksuidLength {
+		return KSUID{}, fmt.Errorf("%w: expected %d characters, got %d", ErrInvalidKSUID, ksuidLength, len(s))
+	}
+	decoded, ok := decodeBase62Fixed(s, ksuidBytes)
+	if !ok {
+		return KSUID{}, ErrInvalidKSUID
+	}
+	var k KSUID
+	copy(k[:], decoded)
+	return k, nil
+}
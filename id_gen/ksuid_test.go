@@ -0,0 +1,73 @@
+package id_gen
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKSUIDKnownValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		raw     string
+		created time.Time
+	}{
+		// the example from the segmentio/ksuid README
+		{"reference", "0ujtsYcgvSTl8PAuAdqWYSMnLOv", "0669F7EFB5A1CD34B5F99D1154FB6853345C9735", time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC)},
+		{"nil", "000000000000000000000000000", strings.Repeat("00", 20), time.Unix(ksuidEpoch, 0)},
+		{"max", "aWgEPTl1tmebfsQzFP4bxwgy80V", strings.Repeat("FF", 20), time.Unix(ksuidEpoch+1<<32-1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := ParseKSUID(tt.id)
+			if err != nil {
+				t.Fatalf("ParseKSUID() error = %v", err)
+			}
+			if got := strings.ToUpper(hex.EncodeToString(k[:])); got != tt.raw {
+				t.Errorf("ParseKSUID() bytes = %s, want %s", got, tt.raw)
+			}
+			if !k.Time().Equal(tt.created) {
+				t.Errorf("Time() = %v, want %v", k.Time().UTC(), tt.created)
+			}
+			if got := strings.ToUpper(hex.EncodeToString(k.Payload())); got != tt.raw[8:] {
+				t.Errorf("Payload() = %s, want %s", got, tt.raw[8:])
+			}
+			if k.String() != tt.id {
+				t.Errorf("String() = %s, want %s", k.String(), tt.id)
+			}
+		})
+	}
+}
+
+func TestGenerateKSUID(t *testing.T) {
+	before := time.Now().Truncate(time.Second)
+	id := GenerateKSUID()
+	k, err := ParseKSUID(id)
+	if err != nil {
+		t.Fatalf("ParseKSUID(GenerateKSUID()) error = %v", err)
+	}
+	if k.Time().Before(before) || k.Time().After(time.Now()) {
+		t.Errorf("GenerateKSUID() time = %v, want about %v", k.Time(), before)
+	}
+
+	earlier := generateKSUIDAt(before.Add(-time.Hour))
+	if earlier >= id {
+		t.Errorf("a KSUID from an hour earlier %s sorts after %s", earlier, id)
+	}
+
+	prefixed := GenerateKSUIDWithPrefix("evt")
+	if _, err := ParseKSUID(strings.TrimPrefix(prefixed, "evt-")); !strings.HasPrefix(prefixed, "evt-") || err != nil {
+		t.Errorf("GenerateKSUIDWithPrefix() = %q, want evt- followed by a KSUID", prefixed)
+	}
+}
+
+func TestParseKSUIDErrors(t *testing.T) {
+	for _, id := range []string{"", "0ujtsYcgvSTl8PAuAdqWYSMnLO", "0ujtsYcgvSTl8PAuAdqWYSMnLO!", "aWgEPTl1tmebfsQzFP4bxwgy80W"} {
+		if _, err := ParseKSUID(id); !errors.Is(err, ErrInvalidKSUID) {
+			t.Errorf("ParseKSUID(%q) error = %v, want ErrInvalidKSUID", id, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MachineIDEnvVar is the environment variable read by EnvMachineID when no variable name is given
+const MachineIDEnvVar = "EASYGO_MACHINE_ID"
+
+// maxMachineID is the largest machine ID the default Snowflake layout can hold
+const maxMachineID = 1<<snowflakeMachineBits - 1
+
+var ErrMachineIDUnavailable = errors.New("machine ID unavailable")
+
+// MachineIDProvider resolves the machine ID a Snowflake generator embeds in its IDs
+type MachineIDProvider interface {
+	MachineID() (int64, error)
+}
+
+// MachineIDFunc adapts a plain function to MachineIDProvider
+type MachineIDFunc func() (int64, error)
+
+func (f MachineIDFunc) MachineID() (int64, error) {
+	return f()
+}
+
+// EnvMachineID reads the machine ID from an environment variable, MachineIDEnvVar if Var is empty.
+// The value must be an integer between 0 and 1023.
+type EnvMachineID struct {
+	Var string
+}
+
+func (p EnvMachineID) MachineID() (int64, error) {
+	name := p.Var
+	if name == "" {
+		name = MachineIDEnvVar
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s is not set", ErrMachineIDUnavailable, name)
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || id < 0 || id > maxMachineID {
+		return 0, fmt.Errorf("%w: %s=%q is not an integer between 0 and %d", ErrMachineIDUnavailable, name, value, maxMachineID)
+	}
+	return id, nil
+}
+
+// HostnameHashMachineID derives the machine ID from an FNV-1a hash of the hostname. It needs no
+// configuration, but distinct hosts collide with probability 1/1024 per pair, so prefer an assigned ID
+// for large fleets.
+type HostnameHashMachineID struct{}
+
+func (HostnameHashMachineID) MachineID() (int64, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrMachineIDUnavailable, err)
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(hostname))
+	return int64(hash.Sum32() % (maxMachineID + 1)), nil
+}
+
+// StatefulSetOrdinalMachineID uses the ordinal suffix of a Kubernetes StatefulSet pod name ("web-3"
+// gives 3), which is unique within the StatefulSet. The pod name is read from the environment variable
+// PodNameVar (typically populated through the downward API) or, if that is empty or unset, the hostname.
+type StatefulSetOrdinalMachineID struct {
+	PodNameVar string
+}
+
+func (p StatefulSetOrdinalMachineID) MachineID() (int64, error) {
+	name := ""
+	if p.PodNameVar != "" {
+		name = os.Getenv(p.PodNameVar)
+	}
+	if name == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrMachineIDUnavailable, err)
+		}
+		name = hostname
+	}
+	dash := strings.LastIndexByte(name, '-')
+	ordinal, err := strconv.ParseInt(name[dash+1:], 10, 64)
+	if dash < 0 || err != nil || ordinal < 0 {
+		return 0, fmt.Errorf("%w: pod name %q has no StatefulSet ordinal", ErrMachineIDUnavailable, name)
+	}
+	if ordinal > maxMachineID {
+		return 0, fmt.Errorf("%w: ordinal %d exceeds %d", ErrMachineIDUnavailable, ordinal, maxMachineID)
+	}
+	return ordinal, nil
+}
+
+// FirstMachineID tries each provider in order and returns the first machine ID resolved
+func FirstMachineID(providers ...MachineIDProvider) MachineIDProvider {
+	return MachineIDFunc(func() (int64, error) {
+		var errs []error
+		for _, provider := range providers {
+			id, err := provider.MachineID()
+			if err == nil {
+				return id, nil
+			}
+			errs = append(errs, err)
+		}
+		if len(errs) == 0 {
+			return 0, fmt.Errorf("%w: no providers", ErrMachineIDUnavailable)
+		}
+		return 0, errors.Join(errs...)
+	})
+}
+
+// NewSnowflakeGeneratorFromProvider creates a SnowflakeGenerator using the machine ID resolved by provider
+func NewSnowflakeGeneratorFromProvider(provider MachineIDProvider, opts ...SnowflakeOption) (*SnowflakeGenerator, error) {
+	id, err := provider.MachineID()
+	if err != nil {
+		return nil, err
+	}
+	return NewSnowflakeGenerator(id, opts...), nil
+}
+
+var (
+	machineIDProviderMutex sync.Mutex
+	machineIDProvider      MachineIDProvider
+)
+
+// SetMachineIDProvider makes the package-level Snowflake generator take its machine ID from provider
+// instead of the last IP octet. It must be called before the first GenerateSnowflakeID to have any
+// effect. If provider fails, the generator falls back to the IP and process ID heuristics.
+func SetMachineIDProvider(provider MachineIDProvider) {
+	machineIDProviderMutex.Lock()
+	defer machineIDProviderMutex.Unlock()
+	machineIDProvider = provider
+}
+
+// resolveMachineID returns the machine ID for the package-level generator
+func resolveMachineID() int64 {
+	machineIDProviderMutex.Lock()
+	provider := machineIDProvider
+	machineIDProviderMutex.Unlock()
+	if provider != nil {
+		if id, err := provider.MachineID(); err == nil {
+			return id
+		}
+	}
+	return getMachineID()
+}
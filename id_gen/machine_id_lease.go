@@ -0,0 +1,132 @@
+package id_gen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultMachineIDLeaseTTL is used when NewLeaseMachineID is given a non-positive TTL
+const defaultMachineIDLeaseTTL = 30 * time.Second
+
+// LeaseStore is the coordination backend behind LeaseMachineID, typically a thin adapter over Redis
+// (SET key owner NX PX ttl, plus compare-and-set scripts for renew and release) or an etcd lease.
+// Every method must be atomic across the cluster.
+type LeaseStore interface {
+	// Acquire claims key for owner for ttl if nobody holds it, reporting whether it succeeded
+	Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Renew extends owner's claim on key by ttl, reporting false if owner no longer holds it
+	Renew(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Release gives up owner's claim on key; releasing a key owned by someone else must be a no-op
+	Release(ctx context.Context, key, owner string) error
+}
+
+// LeaseMachineID allocates a cluster-wide unique machine ID by leasing the first free key
+// "<prefix><id>" for id 0 to 1023 from a LeaseStore. The lease is renewed in the background every third
+// of its TTL until Close. Each failed renewal is reported to OnLost; once the lease has really been lost,
+// IDs minted afterwards may collide, so the usual reaction is to stop serving and restart.
+type LeaseMachineID struct {
+	store  LeaseStore
+	prefix string
+	owner  string
+	ttl    time.Duration
+
+	// OnLost, if non-nil, is called from the renewal goroutine when a renewal fails. Set it before the
+	// first MachineID call.
+	OnLost func(machineID int64, err error)
+
+	mutex    sync.Mutex
+	acquired bool
+	id       int64
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewLeaseMachineID creates a lease-based allocator; keys are named keyPrefix followed by the machine ID.
+// A non-positive ttl selects 30 seconds.
+func NewLeaseMachineID(store LeaseStore, keyPrefix string, ttl time.Duration) *LeaseMachineID {
+	if ttl <= 0 {
+		ttl = defaultMachineIDLeaseTTL
+	}
+	hostname, _ := os.Hostname()
+	return &LeaseMachineID{
+		store:  store,
+		prefix: keyPrefix,
+		owner:  fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), GenerateRandomHexString(8)),
+		ttl:    ttl,
+	}
+}
+
+// MachineID leases a free machine ID on the first call and returns the same ID afterwards
+func (l *LeaseMachineID) MachineID() (int64, error) {
+	return l.MachineIDContext(context.Background())
+}
+
+// MachineIDContext is MachineID with a context bounding the calls to the store
+func (l *LeaseMachineID) MachineIDContext(ctx context.Context) (int64, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.acquired {
+		return l.id, nil
+	}
+
+	for id := int64(0); id <= maxMachineID; id++ {
+		ok, err := l.store.Acquire(ctx, l.key(id), l.owner, l.ttl)
+		if err != nil {
+			return 0, fmt.Errorf("%w: leasing machine ID %d: %v", ErrMachineIDUnavailable, id, err)
+		}
+		if ok {
+			l.acquired, l.id = true, id
+			l.stop, l.done = make(chan struct{}), make(chan struct{})
+			go l.renew(id, l.stop, l.done)
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: all %d machine IDs are leased", ErrMachineIDUnavailable, maxMachineID+1)
+}
+
+// Close stops renewing and releases the lease, if one was acquired
+func (l *LeaseMachineID) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if !l.acquired {
+		return nil
+	}
+	close(l.stop)
+	<-l.done
+	l.acquired = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.ttl)
+	defer cancel()
+	return l.store.Release(ctx, l.key(l.id), l.owner)
+}
+
+func (l *LeaseMachineID) renew(id int64, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+			ok, err := l.store.Renew(ctx, l.key(id), l.owner, l.ttl)
+			cancel()
+			if err == nil && !ok {
+				err = fmt.Errorf("lease for machine ID %d is held by another owner", id)
+			}
+			// keep trying: a transient store error may clear up before the lease expires
+			if hook := l.OnLost; err != nil && hook != nil {
+				hook(id, err)
+			}
+		}
+	}
+}
+
+func (l *LeaseMachineID) key(id int64) string {
+	return l.prefix + strconv.FormatInt(id, 10)
+}
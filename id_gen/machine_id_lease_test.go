@@ -0,0 +1,152 @@
+package id_gen
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryLeaseStore is an in-process LeaseStore with real expiry
+type memoryLeaseStore struct {
+	mutex     sync.Mutex
+	leases    map[string]memoryLease
+	renewErr  error
+	renewals  int
+	acquireFn func(key string) error
+}
+
+type memoryLease struct {
+	owner   string
+	expires time.Time
+}
+
+func newMemoryLeaseStore() *memoryLeaseStore {
+	return &memoryLeaseStore{leases: map[string]memoryLease{}}
+}
+
+func (s *memoryLeaseStore) Acquire(_ context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.acquireFn != nil {
+		if err := s.acquireFn(key); err != nil {
+			return false, err
+		}
+	}
+	if lease, ok := s.leases[key]; ok && time.Now().Before(lease.expires) {
+		return false, nil
+	}
+	s.leases[key] = memoryLease{owner: owner, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (s *memoryLeaseStore) Renew(_ context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.renewals++
+	if s.renewErr != nil {
+		return false, s.renewErr
+	}
+	lease, ok := s.leases[key]
+	if !ok || lease.owner != owner {
+		return false, nil
+	}
+	s.leases[key] = memoryLease{owner: owner, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (s *memoryLeaseStore) Release(_ context.Context, key, owner string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if lease, ok := s.leases[key]; ok && lease.owner == owner {
+		delete(s.leases, key)
+	}
+	return nil
+}
+
+func TestLeaseMachineIDUnique(t *testing.T) {
+	store := newMemoryLeaseStore()
+	var allocators []*LeaseMachineID
+	for want := int64(0); want < 3; want++ {
+		allocator := NewLeaseMachineID(store, "snowflake/", time.Minute)
+		allocators = append(allocators, allocator)
+		got, err := allocator.MachineID()
+		if err != nil || got != want {
+			t.Fatalf("MachineID() = %d, %v, want %d", got, err, want)
+		}
+		if again, _ := allocator.MachineID(); again != got {
+			t.Errorf("MachineID() again = %d, want the leased %d", again, got)
+		}
+	}
+	store.mutex.Lock()
+	_, prefixed := store.leases["snowflake/1"]
+	store.mutex.Unlock()
+	if !prefixed {
+		t.Error("store has no lease for snowflake/1, want keys named after the prefix")
+	}
+
+	// releasing an ID makes it available to the next allocator
+	if err := allocators[1].Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	next := NewLeaseMachineID(store, "snowflake/", time.Minute)
+	defer next.Close()
+	if got, err := next.MachineID(); err != nil || got != 1 {
+		t.Errorf("MachineID() after a release = %d, %v, want 1", got, err)
+	}
+	for _, allocator := range allocators {
+		allocator.Close()
+	}
+}
+
+func TestLeaseMachineIDRenewal(t *testing.T) {
+	store := newMemoryLeaseStore()
+	allocator := NewLeaseMachineID(store, "m/", 60*time.Millisecond)
+	lost := make(chan error, 10)
+	allocator.OnLost = func(machineID int64, err error) { lost <- err }
+
+	if _, err := allocator.MachineID(); err != nil {
+		t.Fatalf("MachineID() error = %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	store.mutex.Lock()
+	renewals := store.renewals
+	_, held := store.leases["m/0"]
+	store.renewErr = errors.New("store unreachable")
+	store.mutex.Unlock()
+	if renewals == 0 || !held {
+		t.Fatalf("after 150ms of a 60ms lease: %d renewals, held = %v, want the lease kept alive", renewals, held)
+	}
+
+	select {
+	case err := <-lost:
+		if err == nil {
+			t.Error("OnLost received a nil error")
+		}
+	case <-time.After(time.Second):
+		t.Error("OnLost was not called after renewals started failing")
+	}
+	if err := allocator.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestLeaseMachineIDErrors(t *testing.T) {
+	store := newMemoryLeaseStore()
+	store.acquireFn = func(string) error { return errors.New("connection refused") }
+	if _, err := NewLeaseMachineID(store, "m/", time.Minute).MachineID(); !errors.Is(err, ErrMachineIDUnavailable) {
+		t.Errorf("MachineID() with a failing store error = %v, want ErrMachineIDUnavailable", err)
+	}
+
+	full := newMemoryLeaseStore()
+	for id := int64(0); id <= maxMachineID; id++ {
+		full.leases[(&LeaseMachineID{prefix: "m/"}).key(id)] = memoryLease{owner: "other", expires: time.Now().Add(time.Hour)}
+	}
+	if _, err := NewLeaseMachineID(full, "m/", time.Minute).MachineID(); !errors.Is(err, ErrMachineIDUnavailable) {
+		t.Errorf("MachineID() with every ID leased error = %v, want ErrMachineIDUnavailable", err)
+	}
+	if err := NewLeaseMachineID(full, "m/", time.Minute).Close(); err != nil {
+		t.Errorf("Close() without a lease error = %v", err)
+	}
+}
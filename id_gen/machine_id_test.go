@@ -0,0 +1,103 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEnvMachineID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"valid", "42", 42, false},
+		{"padded", " 7\n", 7, false},
+		{"largest", "1023", 1023, false},
+		{"too large", "1024", 0, true},
+		{"negative", "-1", 0, true},
+		{"not a number", "node-3", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(MachineIDEnvVar, tt.value)
+			got, err := EnvMachineID{}.MachineID()
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("EnvMachineID.MachineID() = %d, %v, want %d, error %v", got, err, tt.want, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrMachineIDUnavailable) {
+				t.Errorf("EnvMachineID.MachineID() error = %v, want ErrMachineIDUnavailable", err)
+			}
+		})
+	}
+
+	t.Setenv("CUSTOM_MACHINE_ID", "9")
+	if got, err := (EnvMachineID{Var: "CUSTOM_MACHINE_ID"}).MachineID(); err != nil || got != 9 {
+		t.Errorf("EnvMachineID{Var}.MachineID() = %d, %v, want 9", got, err)
+	}
+	if _, err := (EnvMachineID{Var: "EASYGO_TEST_UNSET_MACHINE_ID"}).MachineID(); !errors.Is(err, ErrMachineIDUnavailable) {
+		t.Errorf("EnvMachineID.MachineID() with an unset variable error = %v, want ErrMachineIDUnavailable", err)
+	}
+}
+
+func TestStatefulSetOrdinalMachineID(t *testing.T) {
+	tests := []struct {
+		name    string
+		podName string
+		want    int64
+		wantErr bool
+	}{
+		{"ordinal", "web-3", 3, false},
+		{"dashed set name", "orders-api-12", 12, false},
+		{"no ordinal", "web", 0, true},
+		{"non-numeric suffix", "web-abc", 0, true},
+		{"ordinal too large", "web-2048", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("POD_NAME", tt.podName)
+			got, err := StatefulSetOrdinalMachineID{PodNameVar: "POD_NAME"}.MachineID()
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("StatefulSetOrdinalMachineID.MachineID() = %d, %v, want %d, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHostnameHashMachineID(t *testing.T) {
+	first, err := HostnameHashMachineID{}.MachineID()
+	if err != nil {
+		t.Fatalf("HostnameHashMachineID.MachineID() error = %v", err)
+	}
+	second, _ := HostnameHashMachineID{}.MachineID()
+	if first < 0 || first > maxMachineID || first != second {
+		t.Errorf("HostnameHashMachineID.MachineID() = %d then %d, want a stable ID in [0, %d]", first, second, maxMachineID)
+	}
+}
+
+func TestFirstMachineID(t *testing.T) {
+	failing := MachineIDFunc(func() (int64, error) { return 0, ErrMachineIDUnavailable })
+	fixed := MachineIDFunc(func() (int64, error) { return 5, nil })
+
+	if got, err := FirstMachineID(failing, fixed, MachineIDFunc(func() (int64, error) { return 6, nil })).MachineID(); err != nil || got != 5 {
+		t.Errorf("FirstMachineID().MachineID() = %d, %v, want the first success 5", got, err)
+	}
+	if _, err := FirstMachineID(failing, failing).MachineID(); !errors.Is(err, ErrMachineIDUnavailable) {
+		t.Errorf("FirstMachineID() of failing providers error = %v, want ErrMachineIDUnavailable", err)
+	}
+	if _, err := FirstMachineID().MachineID(); !errors.Is(err, ErrMachineIDUnavailable) {
+		t.Errorf("FirstMachineID() without providers error = %v, want ErrMachineIDUnavailable", err)
+	}
+
+	generator, err := NewSnowflakeGeneratorFromProvider(fixed)
+	if err != nil {
+		t.Fatalf("NewSnowflakeGeneratorFromProvider() error = %v", err)
+	}
+	if id := generator.GenerateSnowflakeID(); !IsSnowflakeFromMachine(id, 5) {
+		t.Errorf("NewSnowflakeGeneratorFromProvider() minted %d, want machine ID 5", id)
+	}
+	if _, err := NewSnowflakeGeneratorFromProvider(failing); err == nil {
+		t.Error("NewSnowflakeGeneratorFromProvider() with a failing provider returned no error")
+	}
+}
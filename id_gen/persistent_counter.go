@@ -0,0 +1,91 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PersistentCounter is a monotonic counter whose value is stored in a file, so IDs are never reissued
+// across restarts or crashes. Each Next call is durably written before it returns. It is safe for
+// concurrent use within one process but offers no protection across processes or nodes: give each
+// process its own file.
+type PersistentCounter struct {
+	mutex sync.Mutex
+	path  string
+	value int64
+}
+
+// NewPersistentCounter opens the counter stored at path, resuming from its last value.
+// A missing file starts the counter at 0.
+func NewPersistentCounter(path string) (*PersistentCounter, error) {
+	value, err := readCounterFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentCounter{path: path, value: value}, nil
+}
+
+// Next increments the counter, syncs the new value to disk and returns it. If persisting fails the
+// counter is left unchanged and the error is returned.
+func (c *PersistentCounter) Next() (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	next := c.value + 1
+	if err := writeCounterFile(c.path, next); err != nil {
+		return 0, err
+	}
+	c.value = next
+	return next, nil
+}
+
+func readCounterFile(path string) (int64, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("corrupt counter file %s: %w", path, err)
+	}
+	return value, nil
+}
+
+// writeCounterFile replaces the counter file atomically: the value goes to a synced temporary file
+// that is renamed over the old one, and the directory is synced so the rename survives a crash
+func writeCounterFile(path string, value int64) error {
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(strconv.FormatInt(value, 10)); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
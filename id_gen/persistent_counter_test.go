@@ -0,0 +1,100 @@
+package id_gen
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestPersistentCounterResumesAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter")
+
+	counter, err := NewPersistentCounter(path)
+	if err != nil {
+		t.Fatalf("NewPersistentCounter() error = %v", err)
+	}
+	for want := int64(1); want <= 3; want++ {
+		if got, err := counter.Next(); err != nil || got != want {
+			t.Fatalf("Next() = %d, %v, want %d", got, err, want)
+		}
+	}
+
+	// a restart reopens the same file
+	restarted, err := NewPersistentCounter(path)
+	if err != nil {
+		t.Fatalf("NewPersistentCounter() after restart error = %v", err)
+	}
+	if got, err := restarted.Next(); err != nil || got != 4 {
+		t.Errorf("Next() after restart = %d, %v, want 4", got, err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "4" {
+		t.Errorf("counter file holds %q, want 4", content)
+	}
+}
+
+func TestPersistentCounterConcurrent(t *testing.T) {
+	counter, err := NewPersistentCounter(filepath.Join(t.TempDir(), "counter"))
+	if err != nil {
+		t.Fatalf("NewPersistentCounter() error = %v", err)
+	}
+
+	const goroutines, perGoroutine = 4, 25
+	var mu sync.Mutex
+	seen := map[int64]bool{}
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				value, err := counter.Next()
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				mu.Lock()
+				seen[value] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for value := int64(1); value <= goroutines*perGoroutine; value++ {
+		if !seen[value] {
+			t.Fatalf("value %d was never issued, want 1 through %d exactly once each", value, goroutines*perGoroutine)
+		}
+	}
+}
+
+func TestPersistentCounterErrors(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt")
+	if err := os.WriteFile(corrupt, []byte("not a number"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPersistentCounter(corrupt); err == nil {
+		t.Error("NewPersistentCounter() with a corrupt file returned no error")
+	}
+
+	// a counter whose directory disappears can't persist, and must not advance
+	gone := filepath.Join(dir, "gone")
+	if err := os.Mkdir(gone, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	counter, err := NewPersistentCounter(filepath.Join(gone, "counter"))
+	if err != nil {
+		t.Fatalf("NewPersistentCounter() error = %v", err)
+	}
+	os.RemoveAll(gone)
+	if _, err := counter.Next(); err == nil {
+		t.Fatal("Next() without a writable directory returned no error")
+	}
+	if err := os.Mkdir(gone, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := counter.Next(); err != nil || got != 1 {
+		t.Errorf("Next() after a failed write = %d, %v, want 1", got, err)
+	}
+}
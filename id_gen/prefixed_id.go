@@ -0,0 +1,166 @@
+package id_gen
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	prefixedIDSeparator = "_"
+	maxIDPrefixLength   = 20
+)
+
+var ErrInvalidPrefixedID = errors.New("invalid prefixed ID")
+
+// PrefixedID is a Stripe-style ID such as "user_01H9ZQ3M8R5X2K7V4N6B0C1D2E": a lowercase type prefix,
+// an underscore and a ULID or UUID payload. It marshals to and from JSON and text as that string and
+// implements sql.Scanner and driver.Valuer, so it can be used directly as a struct field type.
+// The zero value is an unset ID, stored as SQL NULL and marshaled as "".
+type PrefixedID struct {
+	prefix  string
+	payload string
+}
+
+// NewPrefixedID generates a PrefixedID carrying a ULID, so IDs with the same prefix sort by creation time
+func NewPrefixedID(prefix string) (PrefixedID, error) {
+	if err := validateIDPrefix(prefix); err != nil {
+		return PrefixedID{}, err
+	}
+	id, err := ulid.New(ulid.Timestamp(time.Now()), defaultEntropy)
+	if err != nil {
+		return PrefixedID{}, err
+	}
+	return PrefixedID{prefix: prefix, payload: id.String()}, nil
+}
+
+// NewPrefixedUUIDv7 generates a PrefixedID carrying a version 7 UUID in its canonical form
+func NewPrefixedUUIDv7(prefix string) (PrefixedID, error) {
+	if err := validateIDPrefix(prefix); err != nil {
+		return PrefixedID{}, err
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return PrefixedID{}, err
+	}
+	return PrefixedID{prefix: prefix, payload: id.String()}, nil
+}
+
+// ParsePrefixedID parses s and checks that its prefix is prefix; an empty prefix accepts any valid prefix
+func ParsePrefixedID(s, prefix string) (PrefixedID, error) {
+	id, err := parsePrefixedID(s)
+	if err != nil {
+		return PrefixedID{}, err
+	}
+	if prefix != "" && id.prefix != prefix {
+		return PrefixedID{}, fmt.Errorf("%w: expected prefix %q, got %q", ErrInvalidPrefixedID, prefix, id.prefix)
+	}
+	return id, nil
+}
+
+// IsValidPrefixedID reports whether s is a well-formed PrefixedID with the given prefix (any prefix if empty)
+func IsValidPrefixedID(s, prefix string) bool {
+	_, err := ParsePrefixedID(s, prefix)
+	return err == nil
+}
+
+// Prefix returns the type prefix, e.g. "user"
+func (id PrefixedID) Prefix() string {
+	return id.prefix
+}
+
+// Payload returns the ULID or UUID after the separator
+func (id PrefixedID) Payload() string {
+	return id.payload
+}
+
+// IsZero reports whether id is unset
+func (id PrefixedID) IsZero() bool {
+	return id.prefix == "" && id.payload == ""
+}
+
+// String returns "<prefix>_<payload>", or "" for the zero value
+func (id PrefixedID) String() string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.prefix + prefixedIDSeparator + id.payload
+}
+
+// MarshalText implements encoding.TextMarshaler, which encoding/json uses for both values and map keys
+func (id PrefixedID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Any valid prefix is accepted; use ParsePrefixedID
+// or check Prefix afterwards to enforce a specific one. Empty text yields the zero value.
+func (id *PrefixedID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = PrefixedID{}
+		return nil
+	}
+	parsed, err := parsePrefixedID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the ID as text and the zero value as NULL
+func (id PrefixedID) Value() (driver.Value, error) {
+	if id.IsZero() {
+		return nil, nil
+	}
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner for text columns; NULL scans to the zero value
+func (id *PrefixedID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = PrefixedID{}
+		return nil
+	case string:
+		return id.UnmarshalText([]byte(v))
+	case []byte:
+		return id.UnmarshalText(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidPrefixedID, src)
+	}
+}
+
+func parsePrefixedID(s string) (PrefixedID, error) {
+	prefix, payload, ok := strings.Cut(s, prefixedIDSeparator)
+	if !ok {
+		return PrefixedID{}, fmt.Errorf("%w: %q has no %q separator", ErrInvalidPrefixedID, s, prefixedIDSeparator)
+	}
+	if err := validateIDPrefix(prefix); err != nil {
+		return PrefixedID{}, err
+	}
+	if _, err := ulid.ParseStrict(payload); err != nil {
+		if _, err := uuid.Parse(payload); err != nil {
+			return PrefixedID{}, fmt.Errorf("%w: payload %q is neither a ULID nor a UUID", ErrInvalidPrefixedID, payload)
+		}
+	}
+	return PrefixedID{prefix: prefix, payload: payload}, nil
+}
+
+// validateIDPrefix accepts 1-20 lowercase letters and digits, starting with a letter
+func validateIDPrefix(prefix string) error {
+	if prefix == "" || len(prefix) > maxIDPrefixLength {
+		return fmt.Errorf("%w: prefix must have 1 to %d characters, got %q", ErrInvalidPrefixedID, maxIDPrefixLength, prefix)
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if !(c >= 'a' && c <= 'z' || i > 0 && c >= '0' && c <= '9') {
+			return fmt.Errorf("%w: prefix %q must be lowercase letters and digits, starting with a letter", ErrInvalidPrefixedID, prefix)
+		}
+	}
+	return nil
+}
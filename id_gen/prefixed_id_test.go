@@ -0,0 +1,134 @@
+package id_gen
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewPrefixedID(t *testing.T) {
+	tests := []struct {
+		name       string
+		generate   func(string) (PrefixedID, error)
+		payloadLen int
+	}{
+		{"ulid", NewPrefixedID, 26},
+		{"uuidv7", NewPrefixedUUIDv7, 36},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tt.generate("user")
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			if id.Prefix() != "user" || len(id.Payload()) != tt.payloadLen || id.String() != "user_"+id.Payload() {
+				t.Errorf("generate() = %q, want user_ and a %d character payload", id, tt.payloadLen)
+			}
+			parsed, err := ParsePrefixedID(id.String(), "user")
+			if err != nil || parsed != id {
+				t.Errorf("ParsePrefixedID(%q) = %v, %v, want the same ID", id, parsed, err)
+			}
+			if _, err := tt.generate("User"); !errors.Is(err, ErrInvalidPrefixedID) {
+				t.Errorf("generate(User) error = %v, want ErrInvalidPrefixedID", err)
+			}
+		})
+	}
+}
+
+func TestParsePrefixedID(t *testing.T) {
+	valid := "user_" + GenerateSortableId()
+	tests := []struct {
+		name    string
+		id      string
+		prefix  string
+		wantErr bool
+	}{
+		{"matching prefix", valid, "user", false},
+		{"any prefix", valid, "", false},
+		{"uuid payload", "ord2_" + GenerateUUID(), "ord2", false},
+		{"wrong prefix", valid, "team", true},
+		{"no separator", GenerateSortableId(), "", true},
+		{"uppercase prefix", "User_" + GenerateSortableId(), "", true},
+		{"leading digit", "1user_" + GenerateSortableId(), "", true},
+		{"prefix too long", strings.Repeat("a", 21) + "_" + GenerateSortableId(), "", true},
+		{"bad payload", "user_notanid", "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePrefixedID(tt.id, tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePrefixedID(%q, %q) error = %v, want error %v", tt.id, tt.prefix, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPrefixedID) {
+				t.Errorf("ParsePrefixedID() error = %v, want ErrInvalidPrefixedID", err)
+			}
+			if got := IsValidPrefixedID(tt.id, tt.prefix); got == tt.wantErr {
+				t.Errorf("IsValidPrefixedID(%q, %q) = %v, want %v", tt.id, tt.prefix, got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrefixedIDJSON(t *testing.T) {
+	type account struct {
+		ID      PrefixedID            `json:"id"`
+		Parent  PrefixedID            `json:"parent"`
+		Members map[PrefixedID]string `json:"members"`
+	}
+	id, _ := NewPrefixedID("acct")
+	member, _ := NewPrefixedID("user")
+	encoded, err := json.Marshal(account{ID: id, Members: map[PrefixedID]string{member: "owner"}})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"id":"` + id.String() + `","parent":"","members":{"` + member.String() + `":"owner"}}`
+	if string(encoded) != want {
+		t.Errorf("json.Marshal() = %s, want %s", encoded, want)
+	}
+
+	var decoded account
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.ID != id || !decoded.Parent.IsZero() || decoded.Members[member] != "owner" {
+		t.Errorf("json.Unmarshal() = %+v, want the original account", decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"acct_bad"}`), &decoded); !errors.Is(err, ErrInvalidPrefixedID) {
+		t.Errorf("json.Unmarshal() of a malformed ID error = %v, want ErrInvalidPrefixedID", err)
+	}
+}
+
+func TestPrefixedIDSQL(t *testing.T) {
+	id, _ := NewPrefixedID("user")
+	value, err := id.Value()
+	if err != nil || value != id.String() {
+		t.Errorf("Value() = %v, %v, want %q", value, err, id.String())
+	}
+	if value, _ := (PrefixedID{}).Value(); value != nil {
+		t.Errorf("zero Value() = %v, want NULL", value)
+	}
+
+	tests := []struct {
+		name    string
+		src     any
+		want    PrefixedID
+		wantErr bool
+	}{
+		{"string", id.String(), id, false},
+		{"bytes", []byte(id.String()), id, false},
+		{"null", nil, PrefixedID{}, false},
+		{"integer", int64(5), PrefixedID{}, true},
+		{"malformed", "user_x", PrefixedID{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var scanned PrefixedID
+			err := scanned.Scan(tt.src)
+			if (err != nil) != tt.wantErr || scanned != tt.want {
+				t.Errorf("Scan(%v) = %v, %v, want %v, error %v", tt.src, scanned, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
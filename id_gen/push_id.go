@@ -0,0 +1,62 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// pushIDAlphabet is Firebase's modified base64 alphabet, in ascending ASCII order so IDs sort lexically
+const pushIDAlphabet = "-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+const (
+	pushIDTimeChars   = 8
+	pushIDRandomChars = 12
+)
+
+var (
+	pushIDMutex       sync.Mutex
+	lastPushTime      int64
+	lastPushRandChars [pushIDRandomChars]byte
+)
+
+// GeneratePushID generates a 20 character Firebase Realtime Database push ID: 8 characters of
+// millisecond timestamp followed by 12 random characters. IDs sort chronologically, and IDs generated
+// within the same millisecond reuse the previous random characters incremented by one, so they still
+// sort in generation order. Returns "" if the system random source fails.
+func GeneratePushID() string {
+	pushIDMutex.Lock()
+	defer pushIDMutex.Unlock()
+
+	now := time.Now().UnixMilli()
+	if now == lastPushTime {
+		// increment the random characters as one base64 number, carrying from the end
+		i := pushIDRandomChars - 1
+		for ; i >= 0 && lastPushRandChars[i] == 63; i-- {
+			lastPushRandChars[i] = 0
+		}
+		if i >= 0 {
+			lastPushRandChars[i]++
+		}
+	} else {
+		if _, err := rand.Read(lastPushRandChars[:]); err != nil {
+			return ""
+		}
+		for i := range lastPushRandChars {
+			lastPushRandChars[i] &= 63
+		}
+	}
+	lastPushTime = now
+
+	var id [pushIDTimeChars + pushIDRandomChars]byte
+	timestamp := now
+	for i := pushIDTimeChars - 1; i >= 0; i-- {
+		id[i] = pushIDAlphabet[timestamp%64]
+		timestamp /= 64
+	}
+	for i, c := range lastPushRandChars {
+		id[pushIDTimeChars+i] = pushIDAlphabet[c]
+	}
+	notifyGenerate("push_id")
+	return string(id[:])
+}
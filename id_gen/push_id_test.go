@@ -0,0 +1,50 @@
+package id_gen
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// pushIDMillis decodes the timestamp prefix of a push ID
+func pushIDMillis(id string) int64 {
+	var millis int64
+	for _, c := range id[:pushIDTimeChars] {
+		millis = millis*64 + int64(strings.IndexRune(pushIDAlphabet, c))
+	}
+	return millis
+}
+
+func TestGeneratePushID(t *testing.T) {
+	before := time.Now().UnixMilli()
+	ids := make([]string, 2000)
+	for i := range ids {
+		ids[i] = GeneratePushID()
+	}
+	after := time.Now().UnixMilli()
+
+	sameMillisecond := 0
+	for i, id := range ids {
+		if len(id) != 20 {
+			t.Fatalf("GeneratePushID() = %q, want 20 characters", id)
+		}
+		if strings.Trim(id, pushIDAlphabet) != "" {
+			t.Fatalf("GeneratePushID() = %q, want only characters from the push ID alphabet", id)
+		}
+		if millis := pushIDMillis(id); millis < before || millis > after {
+			t.Errorf("GeneratePushID() = %q encodes %d, want within [%d, %d]", id, millis, before, after)
+		}
+		if i == 0 {
+			continue
+		}
+		if id <= ids[i-1] {
+			t.Fatalf("GeneratePushID() = %q after %q, want ascending", id, ids[i-1])
+		}
+		if id[:pushIDTimeChars] == ids[i-1][:pushIDTimeChars] {
+			sameMillisecond++
+		}
+	}
+	if sameMillisecond == 0 {
+		t.Error("no two push IDs shared a millisecond, the increment path was not exercised")
+	}
+}
@@ -0,0 +1,36 @@
+package id_gen
+
+import "fmt"
+
+// Charset is the set of characters GenerateRandomString draws from. Any string of 2 to 128 distinct
+// ASCII characters can be used as a custom Charset.
+type Charset string
+
+const (
+	// CharsetHex is lowercase hexadecimal, as produced by GenerateRandomHexString
+	CharsetHex Charset = "0123456789abcdef"
+	// CharsetAlphanumeric is digits and upper and lowercase letters
+	CharsetAlphanumeric Charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// CharsetURLSafe is the URL-safe base64 alphabet from RFC 4648
+	CharsetURLSafe Charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	// CharsetDigits is 0-9, for numeric one-time passcodes
+	CharsetDigits Charset = "0123456789"
+)
+
+// GenerateRandomString generates length characters drawn uniformly from charset using crypto/rand,
+// with rejection sampling so no character is more likely than another. Unlike GenerateRandomHexString,
+// a failure to read randomness is returned as an error rather than an empty string.
+func GenerateRandomString(length int, charset Charset) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("string length must be positive, got %d", length)
+	}
+	if err := validateAlphabet(string(charset)); err != nil {
+		return "", err
+	}
+	s, err := randomStringFromAlphabet(length, string(charset))
+	if err != nil {
+		return "", err
+	}
+	notifyGenerate("random_string")
+	return s, nil
+}
@@ -0,0 +1,75 @@
+package id_gen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateRandomString(t *testing.T) {
+	tests := []struct {
+		name    string
+		length  int
+		charset Charset
+	}{
+		{"hex", 32, CharsetHex},
+		{"alphanumeric", 24, CharsetAlphanumeric},
+		{"url safe", 43, CharsetURLSafe},
+		{"otp", 6, CharsetDigits},
+		{"single character", 1, CharsetDigits},
+		{"custom", 50, Charset("ACGT")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := GenerateRandomString(tt.length, tt.charset)
+			if err != nil {
+				t.Fatalf("GenerateRandomString() error = %v", err)
+			}
+			if len(s) != tt.length || strings.Trim(s, string(tt.charset)) != "" {
+				t.Errorf("GenerateRandomString(%d, %q) = %q, want %d characters from the charset", tt.length, tt.charset, s, tt.length)
+			}
+		})
+	}
+}
+
+func TestGenerateRandomStringUnbiased(t *testing.T) {
+	// ten digits need a four bit mask, so taking bytes modulo 10 or keeping masked values above 9
+	// would make some digits noticeably more likely than others
+	const samples = 50000
+	s, err := GenerateRandomString(samples, CharsetDigits)
+	if err != nil {
+		t.Fatalf("GenerateRandomString() error = %v", err)
+	}
+	for _, c := range CharsetDigits {
+		if n := strings.Count(s, string(c)); n < samples/10*9/10 || n > samples/10*11/10 {
+			t.Errorf("%q appeared %d times in %d, want about %d", c, n, samples, samples/10)
+		}
+	}
+}
+
+func TestGenerateRandomStringErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		length       int
+		charset      Charset
+		wantAlphabet bool
+	}{
+		{"zero length", 0, CharsetHex, false},
+		{"negative length", -3, CharsetHex, false},
+		{"empty charset", 8, "", true},
+		{"single character", 8, "x", true},
+		{"duplicate characters", 8, "0120", true},
+		{"non-ASCII", 8, "abcß", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := GenerateRandomString(tt.length, tt.charset)
+			if err == nil || s != "" {
+				t.Fatalf("GenerateRandomString() = %q, %v, want an error", s, err)
+			}
+			if got := errors.Is(err, ErrInvalidAlphabet); got != tt.wantAlphabet {
+				t.Errorf("GenerateRandomString() error = %v, errors.Is(ErrInvalidAlphabet) = %v, want %v", err, got, tt.wantAlphabet)
+			}
+		})
+	}
+}
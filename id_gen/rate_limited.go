@@ -0,0 +1,80 @@
+package id_gen
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitedGenerator throttles an IDGenerator with a token bucket, so IDs are never minted faster
+// than a downstream system can absorb: it allows bursts of up to burst IDs and refills at rate IDs per second.
+type RateLimitedGenerator struct {
+	generator IDGenerator
+	rate      float64
+	burst     float64
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimitedGenerator wraps generator with a limit of rate IDs per second and bursts of up to burst IDs.
+// The bucket starts full. A burst below 1 is treated as 1.
+func NewRateLimitedGenerator(generator IDGenerator, rate float64, burst int) *RateLimitedGenerator {
+	b := float64(max(burst, 1))
+	return &RateLimitedGenerator{
+		generator: generator,
+		rate:      rate,
+		burst:     b,
+		tokens:    b,
+		last:      time.Now(),
+	}
+}
+
+// Generate blocks until a token is available and then returns a new ID
+func (g *RateLimitedGenerator) Generate() string {
+	id, _ := g.GenerateContext(context.Background())
+	return id
+}
+
+// GenerateContext waits for a token or for ctx to be done, returning ctx.Err() in the latter case
+func (g *RateLimitedGenerator) GenerateContext(ctx context.Context) (string, error) {
+	wait := g.reserve()
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			g.release()
+			return "", ctx.Err()
+		}
+	}
+	return g.generator.Generate(), nil
+}
+
+// reserve takes a token, possibly going into debt, and returns how long the caller must wait for it
+func (g *RateLimitedGenerator) reserve() time.Duration {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := time.Now()
+	g.tokens = min(g.burst, g.tokens+now.Sub(g.last).Seconds()*g.rate)
+	g.last = now
+	g.tokens--
+	if g.tokens >= 0 {
+		return 0
+	}
+	if g.rate <= 0 {
+		// nothing ever refills; wait "forever" so only ctx can release the caller
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(-g.tokens / g.rate * float64(time.Second))
+}
+
+// release returns a token reserved by a caller that gave up waiting
+func (g *RateLimitedGenerator) release() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.tokens = min(g.burst, g.tokens+1)
+}
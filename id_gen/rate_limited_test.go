@@ -0,0 +1,51 @@
+package id_gen
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedGeneratorRate(t *testing.T) {
+	const rate, burst, count = 200.0, 5, 45
+	generator := NewRateLimitedGenerator(GeneratorFunc(GenerateUUID), rate, burst)
+
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		if id := generator.Generate(); len(id) != 36 {
+			t.Fatalf("Generate() = %q, want a UUID", id)
+		}
+	}
+	elapsed := time.Since(start)
+
+	// the burst is free, the remaining IDs arrive at the configured rate
+	want := time.Duration(float64(count-burst) / rate * float64(time.Second))
+	if elapsed < want*8/10 || elapsed > want*2 {
+		t.Errorf("%d IDs at %v/s with burst %d took %v, want about %v", count, rate, burst, elapsed, want)
+	}
+}
+
+func TestRateLimitedGeneratorBurst(t *testing.T) {
+	generator := NewRateLimitedGenerator(GeneratorFunc(GenerateUUID), 1, 10)
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		generator.Generate()
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("a burst within the bucket took %v, want no waiting", elapsed)
+	}
+}
+
+func TestRateLimitedGeneratorContext(t *testing.T) {
+	generator := NewRateLimitedGenerator(GeneratorFunc(GenerateUUID), 0, 1)
+	if _, err := generator.GenerateContext(context.Background()); err != nil {
+		t.Fatalf("GenerateContext() within the burst error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := generator.GenerateContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GenerateContext() with an empty bucket error = %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,59 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+var ErrUnknownGenerator = errors.New("unknown ID generator")
+
+var (
+	registryMutex sync.RWMutex
+	registry      = map[string]func() string{
+		"uuid":                  GenerateUUID,
+		"uuidv7":                GenerateUUIDv7,
+		"ulid":                  GenerateSortableId,
+		"ksuid":                 GenerateKSUID,
+		"snowflake":             func() string { return strconv.FormatInt(GenerateSnowflakeID(), 10) },
+		"hex":                   func() string { return GenerateRandomHexString(16) },
+		"hybrid":                GenerateHybridID,
+		"second_sortable":       GenerateSecondSortableID,
+		"hlc":                   GenerateHLCID,
+		"checked":               func() string { return GenerateCheckedID(12) },
+		"random_node_snowflake": func() string { return strconv.FormatInt(GenerateRandomNodeSnowflake(), 10) },
+		"push_id":               GeneratePushID,
+		"short_distributed":     GenerateShortDistributedID,
+		"reverse_sortable":      GenerateReverseSortableID,
+		"versioned":             GenerateVersionedID,
+		"short_id":              func() string { id, _ := GenerateShortID(21); return id },
+	}
+)
+
+// RegisterGenerator registers fn under name so it can be resolved at runtime with Generate,
+// replacing any generator previously registered under that name (including built-ins).
+func RegisterGenerator(name string, fn func() string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry[name] = fn
+}
+
+// Generate produces an ID with the generator registered under name.
+// The package's own schemes ("uuid", "ulid", "snowflake", ...) are pre-registered.
+func Generate(name string) (string, error) {
+	registryMutex.RLock()
+	fn, ok := registry[name]
+	registryMutex.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownGenerator, name)
+	}
+	return fn(), nil
+}
+
+func isRegisteredGenerator(name string) bool {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	_, ok := registry[name]
+	return ok
+}
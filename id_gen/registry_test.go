@@ -0,0 +1,57 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterGenerator(t *testing.T) {
+	RegisterGenerator("test_fixed", func() string { return "fixed-1" })
+	defer func() {
+		registryMutex.Lock()
+		delete(registry, "test_fixed")
+		registryMutex.Unlock()
+	}()
+
+	got, err := Generate("test_fixed")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got != "fixed-1" {
+		t.Errorf("Generate() = %q, want fixed-1", got)
+	}
+
+	RegisterGenerator("test_fixed", func() string { return "fixed-2" })
+	if got, _ := Generate("test_fixed"); got != "fixed-2" {
+		t.Errorf("Generate() after re-registering = %q, want fixed-2", got)
+	}
+}
+
+func TestGenerateBuiltins(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantLen int
+	}{
+		{"uuid", 36},
+		{"uuidv7", 36},
+		{"ulid", 26},
+		{"hex", 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Generate(tt.name)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Generate() = %q, want %d characters", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestGenerateUnknown(t *testing.T) {
+	if _, err := Generate("no_such_scheme"); !errors.Is(err, ErrUnknownGenerator) {
+		t.Errorf("Generate(unknown) error = %v, want ErrUnknownGenerator", err)
+	}
+}
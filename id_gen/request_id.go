@@ -0,0 +1,50 @@
+package id_gen
+
+import "context"
+
+// requestIDKey is the private context key request IDs are stored under
+type requestIDKey struct{}
+
+// RequestIDOption customizes WithRequestID
+type RequestIDOption func(*requestIDOptions)
+
+type requestIDOptions struct {
+	replace   bool
+	generator func() string
+}
+
+// ReplaceRequestID makes WithRequestID always store a fresh ID, even if ctx already carries one
+func ReplaceRequestID() RequestIDOption {
+	return func(o *requestIDOptions) {
+		o.replace = true
+	}
+}
+
+// WithRequestIDGenerator makes WithRequestID mint IDs with generator instead of GenerateSortableId
+func WithRequestIDGenerator(generator func() string) RequestIDOption {
+	return func(o *requestIDOptions) {
+		o.generator = generator
+	}
+}
+
+// WithRequestID returns a context carrying a request ID, along with that ID. If ctx already carries
+// one it is reused, so the ID propagates through a call chain; otherwise a new ULID is generated.
+func WithRequestID(ctx context.Context, opts ...RequestIDOption) (context.Context, string) {
+	options := requestIDOptions{generator: GenerateSortableId}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if !options.replace {
+		if id, ok := RequestIDFromContext(ctx); ok {
+			return ctx, id
+		}
+	}
+	id := options.generator()
+	return context.WithValue(ctx, requestIDKey{}, id), id
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
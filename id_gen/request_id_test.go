@@ -0,0 +1,65 @@
+package id_gen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func TestWithRequestID(t *testing.T) {
+	if _, ok := RequestIDFromContext(context.Background()); ok {
+		t.Error("RequestIDFromContext(Background) reported an ID")
+	}
+
+	ctx, id := WithRequestID(context.Background())
+	if _, err := ulid.ParseStrict(id); err != nil {
+		t.Fatalf("WithRequestID() = %q, want a ULID: %v", id, err)
+	}
+	if got, ok := RequestIDFromContext(ctx); !ok || got != id {
+		t.Errorf("RequestIDFromContext() = %q, %v, want %q, true", got, ok, id)
+	}
+
+	// a child context, as further down a call chain, still carries the ID
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if got, _ := RequestIDFromContext(child); got != id {
+		t.Errorf("RequestIDFromContext(child) = %q, want %q", got, id)
+	}
+}
+
+func TestWithRequestIDReuse(t *testing.T) {
+	ctx, id := WithRequestID(context.Background())
+	counter := 0
+	generator := WithRequestIDGenerator(func() string { counter++; return "custom" })
+
+	tests := []struct {
+		name      string
+		opts      []RequestIDOption
+		want      string
+		generated int
+	}{
+		{"reuses the existing ID", nil, id, 0},
+		{"reuses even with a custom generator", []RequestIDOption{generator}, id, 0},
+		{"replace mints a new ID", []RequestIDOption{ReplaceRequestID(), generator}, "custom", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter = 0
+			next, got := WithRequestID(ctx, tt.opts...)
+			if got != tt.want || counter != tt.generated {
+				t.Errorf("WithRequestID() = %q after %d generations, want %q after %d", got, counter, tt.want, tt.generated)
+			}
+			if stored, _ := RequestIDFromContext(next); stored != tt.want {
+				t.Errorf("RequestIDFromContext() = %q, want %q", stored, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRequestIDEmptyGenerator(t *testing.T) {
+	ctx, _ := WithRequestID(context.Background(), WithRequestIDGenerator(func() string { return "" }))
+	if _, ok := RequestIDFromContext(ctx); ok {
+		t.Error("RequestIDFromContext() reported an empty request ID")
+	}
+}
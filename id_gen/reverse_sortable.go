@@ -0,0 +1,48 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"errors"
+	"time"
+)
+
+// ReverseSortableMaxTimestamp is the Unix millisecond timestamp that GenerateReverseSortableID counts
+// down from: the largest 48-bit value, which falls in the year 10889
+const ReverseSortableMaxTimestamp = 1<<48 - 1
+
+var ErrInvalidReverseSortableID = errors.New("invalid reverse-sortable ID")
+
+// GenerateReverseSortableID generates a 26 character ID like GenerateHybridID, except that its base32
+// prefix encodes ReverseSortableMaxTimestamp minus the current Unix millisecond. Ascending lexical order
+// is therefore newest first, which suits key-value stores that only scan forwards. IDs created within the
+// same millisecond are not ordered relative to each other.
+func GenerateReverseSortableID() string {
+	random := make([]byte, hybridRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return ""
+	}
+
+	id := make([]byte, 0, hybridIDLength)
+	id = appendBase32Uint(id, uint64(ReverseSortableMaxTimestamp-time.Now().UnixMilli()), hybridTimeChars)
+	id = appendBase32Bytes(id, random)
+	notifyGenerate("reverse_sortable")
+	return string(id)
+}
+
+// ReverseSortableIDTime recovers the creation time embedded in an ID produced by GenerateReverseSortableID
+func ReverseSortableIDTime(id string) (time.Time, error) {
+	if len(id) != hybridIDLength {
+		return time.Time{}, ErrInvalidReverseSortableID
+	}
+	inverted, ok := parseBase32Uint(id[:hybridTimeChars])
+	if !ok || inverted > ReverseSortableMaxTimestamp {
+		return time.Time{}, ErrInvalidReverseSortableID
+	}
+	if _, ok := parseBase32Uint(id[hybridTimeChars : hybridTimeChars+8]); !ok {
+		return time.Time{}, ErrInvalidReverseSortableID
+	}
+	if _, ok := parseBase32Uint(id[hybridTimeChars+8:]); !ok {
+		return time.Time{}, ErrInvalidReverseSortableID
+	}
+	return time.UnixMilli(ReverseSortableMaxTimestamp - int64(inverted)), nil
+}
@@ -0,0 +1,52 @@
+package id_gen
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestGenerateReverseSortableIDOrder(t *testing.T) {
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, GenerateReverseSortableID())
+		time.Sleep(2 * time.Millisecond)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] >= ids[i-1] {
+			t.Errorf("later ID %q sorts after earlier ID %q, want newest first", ids[i], ids[i-1])
+		}
+	}
+
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	for i := range sorted {
+		if sorted[i] != ids[len(ids)-1-i] {
+			t.Fatalf("ascending order %v, want the reverse of creation order %v", sorted, ids)
+		}
+	}
+}
+
+func TestReverseSortableIDTime(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	id := GenerateReverseSortableID()
+	after := time.Now()
+
+	if len(id) != hybridIDLength {
+		t.Fatalf("GenerateReverseSortableID() = %q, want %d characters", id, hybridIDLength)
+	}
+	createdAt, err := ReverseSortableIDTime(id)
+	if err != nil {
+		t.Fatalf("ReverseSortableIDTime() error = %v", err)
+	}
+	if createdAt.Before(before) || createdAt.After(after) {
+		t.Errorf("ReverseSortableIDTime() = %v, want within [%v, %v]", createdAt, before, after)
+	}
+
+	for _, invalid := range []string{"", "short", id[:hybridIDLength-1] + "!", "ZZZZZZZZZZ" + id[10:]} {
+		if _, err := ReverseSortableIDTime(invalid); !errors.Is(err, ErrInvalidReverseSortableID) {
+			t.Errorf("ReverseSortableIDTime(%q) error = %v, want ErrInvalidReverseSortableID", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package id_gen
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// maxScatterBuckets bounds the bucket count accepted by GenerateScatteredSortableID
+const maxScatterBuckets = 1 << 16
+
+var ErrInvalidScatteredID = errors.New("invalid scattered sortable ID")
+
+// GenerateScatteredSortableID generates "<bucket>-<ULID>", where bucket is a zero-padded decimal in
+// [0, buckets) derived from the ULID's random component. Prefixing keys with the bucket spreads writes
+// across partitions (avoiding a hot "latest" partition in HBase/DynamoDB style stores) while IDs within
+// one bucket still sort by time. It returns an empty string if buckets is outside [1, 65536].
+func GenerateScatteredSortableID(buckets int) string {
+	if buckets < 1 || buckets > maxScatterBuckets {
+		return ""
+	}
+	id, err := ulid.New(ulid.Timestamp(time.Now()), defaultEntropy)
+	if err != nil {
+		return ""
+	}
+	entropy := id.Entropy()
+	bucket := binary.BigEndian.Uint64(entropy[2:]) % uint64(buckets)
+	notifyGenerate("scattered")
+	return fmt.Sprintf("%0*d-%s", scatterBucketWidth(buckets), bucket, id.String())
+}
+
+// ParseScatteredSortableID recovers the bucket and creation time from a GenerateScatteredSortableID ID
+func ParseScatteredSortableID(id string) (bucket int, createdAt time.Time, err error) {
+	prefix, payload, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, time.Time{}, ErrInvalidScatteredID
+	}
+	bucket, err = strconv.Atoi(prefix)
+	if err != nil || bucket < 0 {
+		return 0, time.Time{}, ErrInvalidScatteredID
+	}
+	parsed, err := ulid.ParseStrict(payload)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("%w: %v", ErrInvalidScatteredID, err)
+	}
+	return bucket, ulid.Time(parsed.Time()), nil
+}
+
+// scatterBucketWidth is the number of decimal digits needed for the largest bucket number
+func scatterBucketWidth(buckets int) int {
+	return len(strconv.Itoa(buckets - 1))
+}
@@ -0,0 +1,57 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerateScatteredSortableIDDistribution(t *testing.T) {
+	const buckets, count = 16, 16000
+	var counts [buckets]int
+	lastInBucket := map[int]string{}
+	for i := 0; i < count; i++ {
+		id := GenerateScatteredSortableID(buckets)
+		bucket, _, err := ParseScatteredSortableID(id)
+		if err != nil {
+			t.Fatalf("ParseScatteredSortableID(%q) error = %v", id, err)
+		}
+		counts[bucket]++
+		if previous, ok := lastInBucket[bucket]; ok && id <= previous {
+			t.Fatalf("bucket %d: %s after %s, want time ordering within a bucket", bucket, id, previous)
+		}
+		lastInBucket[bucket] = id
+	}
+	for bucket, n := range counts {
+		// 1000 expected per bucket; a standard deviation is about 31
+		if n < 750 || n > 1250 {
+			t.Errorf("bucket %d got %d of %d IDs, want about %d", bucket, n, count, count/buckets)
+		}
+	}
+}
+
+func TestParseScatteredSortableID(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	id := GenerateScatteredSortableID(100)
+	if len(id) != 2+1+26 {
+		t.Fatalf("GenerateScatteredSortableID(100) = %q, want a two digit bucket prefix", id)
+	}
+	bucket, createdAt, err := ParseScatteredSortableID(id)
+	if err != nil {
+		t.Fatalf("ParseScatteredSortableID() error = %v", err)
+	}
+	if bucket < 0 || bucket >= 100 || createdAt.Before(before) || createdAt.After(time.Now()) {
+		t.Errorf("ParseScatteredSortableID() = %d, %v", bucket, createdAt)
+	}
+
+	for _, buckets := range []int{0, maxScatterBuckets + 1} {
+		if got := GenerateScatteredSortableID(buckets); got != "" {
+			t.Errorf("GenerateScatteredSortableID(%d) = %q, want empty", buckets, got)
+		}
+	}
+	for _, invalid := range []string{"", "nodash", "x-01ARZ3NDEKTSV4RRFFQ69G5FAV", "-1-01ARZ3NDEKTSV4RRFFQ69G5FAV", "03-notaulid"} {
+		if _, _, err := ParseScatteredSortableID(invalid); !errors.Is(err, ErrInvalidScatteredID) {
+			t.Errorf("ParseScatteredSortableID(%q) error = %v, want ErrInvalidScatteredID", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+const (
+	secondSortableBytes    = 12 // 4 bytes of Unix seconds + 8 random bytes
+	secondSortableIDLength = 17 // base62 characters needed for 96 bits
+)
+
+var ErrInvalidSecondSortableID = errors.New("invalid second-sortable ID")
+
+// GenerateSecondSortableID generates a 17 character base62 ID made of a 32-bit big-endian Unix second
+// timestamp followed by 64 random bits. IDs sort by creation second and are shorter than a ULID;
+// IDs created within the same second are not ordered relative to each other.
+// The 32-bit timestamp runs out in 2106.
+func GenerateSecondSortableID() string {
+	var payload [secondSortableBytes]byte
+	binary.BigEndian.PutUint32(payload[:4], uint32(time.Now().Unix()))
+	if _, err := rand.Read(payload[4:]); err != nil {
+		return ""
+	}
+	notifyGenerate("second_sortable")
+	return encodeBase62Fixed(payload[:], secondSortableIDLength)
+}
+
+// SecondSortableIDTime extracts the creation time embedded in an ID produced by GenerateSecondSortableID
+func SecondSortableIDTime(id string) (time.Time, error) {
+	if len(id) != secondSortableIDLength {
+		return time.Time{}, ErrInvalidSecondSortableID
+	}
+	payload, ok := decodeBase62Fixed(id, secondSortableBytes)
+	if !ok {
+		return time.Time{}, ErrInvalidSecondSortableID
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(payload[:4])), 0), nil
+}
@@ -0,0 +1,56 @@
+package id_gen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSecondSortableIDCrossSecondOrdering(t *testing.T) {
+	// the largest ID of one second must still sort before the smallest ID of the next
+	payloadAt := func(second uint32, fill byte) string {
+		var payload [secondSortableBytes]byte
+		binary.BigEndian.PutUint32(payload[:4], second)
+		copy(payload[4:], bytes.Repeat([]byte{fill}, 8))
+		return encodeBase62Fixed(payload[:], secondSortableIDLength)
+	}
+	for _, second := range []uint32{0, 1_700_000_000, 1<<32 - 2} {
+		last, next := payloadAt(second, 0xFF), payloadAt(second+1, 0x00)
+		if last >= next {
+			t.Errorf("second %d: %s does not sort before %s", second, last, next)
+		}
+	}
+}
+
+func TestGenerateSecondSortableID(t *testing.T) {
+	before := time.Now().Truncate(time.Second)
+	seen := make(map[string]struct{})
+	for i := 0; i < 5000; i++ {
+		id := GenerateSecondSortableID()
+		if len(id) != 17 {
+			t.Fatalf("GenerateSecondSortableID() = %q, want 17 characters", id)
+		}
+		if _, dup := seen[id]; dup {
+			t.Fatalf("GenerateSecondSortableID() repeated %s", id)
+		}
+		seen[id] = struct{}{}
+
+		created, err := SecondSortableIDTime(id)
+		if err != nil {
+			t.Fatalf("SecondSortableIDTime() error = %v", err)
+		}
+		if created.Before(before) || created.After(time.Now()) {
+			t.Fatalf("SecondSortableIDTime() = %v, want between %v and now", created, before)
+		}
+	}
+}
+
+func TestSecondSortableIDTimeInvalid(t *testing.T) {
+	for _, id := range []string{"", "short", "0000000000000000!", "zzzzzzzzzzzzzzzzz"} {
+		if _, err := SecondSortableIDTime(id); !errors.Is(err, ErrInvalidSecondSortableID) {
+			t.Errorf("SecondSortableIDTime(%q) error = %v, want ErrInvalidSecondSortableID", id, err)
+		}
+	}
+}
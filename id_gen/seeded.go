@@ -0,0 +1,50 @@
+package id_gen
+
+import (
+	mrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// SeededGenerator produces reproducible UUID- and ULID-shaped IDs from a math/rand source.
+// Two generators created with the same seed yield identical sequences, which is handy for demos
+// and golden-file tests.
+//
+// It is NOT cryptographically secure and must never be used for real identifiers.
+type SeededGenerator struct {
+	mutex sync.Mutex
+	rng   *mrand.Rand
+}
+
+// NewSeededGenerator creates a SeededGenerator from seed
+func NewSeededGenerator(seed int64) *SeededGenerator {
+	return &SeededGenerator{rng: mrand.New(mrand.NewSource(seed))}
+}
+
+// UUID returns the next deterministic version 4 shaped UUID
+func (g *SeededGenerator) UUID() string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var u uuid.UUID
+	_, _ = g.rng.Read(u[:])
+	u[6] = (u[6] & 0x0F) | 0x40 // version 4
+	u[8] = (u[8] & 0x3F) | 0x80 // RFC 4122 variant
+	return u.String()
+}
+
+// ULIDAt returns the next deterministic ULID with t as its timestamp. It returns an empty string if t
+// is outside the range ULIDs can represent.
+func (g *SeededGenerator) ULIDAt(t time.Time) string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	id, err := ulid.New(ulid.Timestamp(t), g.rng)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
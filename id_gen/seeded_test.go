@@ -0,0 +1,50 @@
+package id_gen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+func TestSeededGeneratorReproducible(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	first, second := NewSeededGenerator(42), NewSeededGenerator(42)
+	for i := 0; i < 10; i++ {
+		if a, b := first.UUID(), second.UUID(); a != b {
+			t.Fatalf("UUID() #%d = %s and %s, want identical sequences", i, a, b)
+		}
+		if a, b := first.ULIDAt(at), second.ULIDAt(at); a != b {
+			t.Fatalf("ULIDAt() #%d = %s and %s, want identical sequences", i, a, b)
+		}
+	}
+
+	if NewSeededGenerator(1).UUID() == NewSeededGenerator(2).UUID() {
+		t.Error("generators with different seeds produced the same UUID")
+	}
+}
+
+func TestSeededGeneratorShapes(t *testing.T) {
+	g := NewSeededGenerator(7)
+	u, err := uuid.Parse(g.UUID())
+	if err != nil {
+		t.Fatalf("UUID() is not a valid UUID: %v", err)
+	}
+	if u.Version() != 4 || u.Variant() != uuid.RFC4122 {
+		t.Errorf("UUID() version %d variant %s, want 4 RFC4122", u.Version(), u.Variant())
+	}
+
+	at := time.UnixMilli(1_700_000_000_123)
+	id, err := ulid.ParseStrict(g.ULIDAt(at))
+	if err != nil {
+		t.Fatalf("ULIDAt() is not a valid ULID: %v", err)
+	}
+	if got := ulid.Time(id.Time()); !got.Equal(at) {
+		t.Errorf("ULIDAt() timestamp = %v, want %v", got, at)
+	}
+
+	if got := g.ULIDAt(time.Unix(-1, 0)); got != "" {
+		t.Errorf("ULIDAt(before epoch) = %q, want empty", got)
+	}
+}
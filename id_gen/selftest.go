@@ -0,0 +1,159 @@
+package id_gen
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// selfTestBurst is the number of Snowflake IDs checked for monotonicity
+	selfTestBurst = 1000
+	// selfTestClockTolerance is how far an embedded timestamp may drift from the local clock
+	selfTestClockTolerance = time.Minute
+)
+
+// selfTestEarliestClock is the earliest wall clock time considered sane
+var selfTestEarliestClock = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SelfTest checks that the ID subsystem works, for use in startup readiness probes: it generates
+// an ID with each scheme and validates its format and embedded time, checks Snowflake IDs are strictly
+// increasing over a short burst, and flags a machine ID of 0 (usually a failed machine ID lookup) or a
+// wall clock that's obviously wrong. All problems found are joined into the returned error.
+func SelfTest() error {
+	var errs []error
+	check := func(scheme string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", scheme, err))
+		}
+	}
+
+	now := time.Now()
+	if now.Before(selfTestEarliestClock) {
+		errs = append(errs, fmt.Errorf("clock: wall clock reads %s, which looks broken", now.Format(time.RFC3339)))
+	}
+	checkTime := func(t time.Time) error {
+		if d := t.Sub(now); d > selfTestClockTolerance || d < -selfTestClockTolerance {
+			return fmt.Errorf("embedded time %s is too far from the local clock", t.Format(time.RFC3339Nano))
+		}
+		return nil
+	}
+
+	check("uuid", func() error {
+		u, err := uuid.Parse(GenerateUUID())
+		if err != nil {
+			return err
+		}
+		if u.Version() != 4 {
+			return fmt.Errorf("expected version 4, got %d", u.Version())
+		}
+		return nil
+	}())
+
+	check("uuidv7", func() error {
+		t, err := UUIDv7Time(GenerateUUIDv7())
+		if err != nil {
+			return err
+		}
+		return checkTime(t)
+	}())
+
+	check("ulid", func() error {
+		id, err := ulid.ParseStrict(GenerateSortableId())
+		if err != nil {
+			return err
+		}
+		return checkTime(ulid.Time(id.Time()))
+	}())
+
+	check("ksuid", func() error {
+		k, err := ParseKSUID(GenerateKSUID())
+		if err != nil {
+			return err
+		}
+		return checkTime(k.Time())
+	}())
+
+	check("snowflake", func() error {
+		once.Do(initSnowflakeGenerator)
+		if snowflakeGenerator.machineID == 0 {
+			return errors.New("machine ID is 0, machine ID detection probably failed")
+		}
+		previous := GenerateSnowflakeID()
+		for i := 1; i < selfTestBurst; i++ {
+			id := GenerateSnowflakeID()
+			if id <= previous {
+				return fmt.Errorf("IDs are not increasing: %d followed by %d", previous, id)
+			}
+			previous = id
+		}
+		return checkTime(time.UnixMilli(previous >> snowflakeTimestampShift))
+	}())
+
+	check("hex", func() error {
+		id := GenerateRandomHexString(16)
+		if decoded, err := hex.DecodeString(id); err != nil || len(decoded) != 16 {
+			return fmt.Errorf("malformed ID %q", id)
+		}
+		return nil
+	}())
+
+	check("hybrid", func() error {
+		t, err := HybridIDTime(GenerateHybridID())
+		if err != nil {
+			return err
+		}
+		return checkTime(t)
+	}())
+
+	check("second_sortable", func() error {
+		t, err := SecondSortableIDTime(GenerateSecondSortableID())
+		if err != nil {
+			return err
+		}
+		return checkTime(t)
+	}())
+
+	check("hlc", func() error {
+		ts, err := ParseHLCID(GenerateHLCID())
+		if err != nil {
+			return err
+		}
+		physical, _ := DecodeHLC(ts)
+		return checkTime(physical)
+	}())
+
+	check("checked", func() error {
+		if id := GenerateCheckedID(12); !ValidateCheckedID(id) {
+			return fmt.Errorf("generated ID %q fails validation", id)
+		}
+		return nil
+	}())
+
+	check("reverse_sortable", func() error {
+		t, err := ReverseSortableIDTime(GenerateReverseSortableID())
+		if err != nil {
+			return err
+		}
+		return checkTime(t)
+	}())
+
+	check("ksortable", func() error {
+		for _, precision := range []TimePrecision{PrecisionSecond, PrecisionMillisecond, PrecisionMicrosecond} {
+			t, err := KSortableIDTime(GenerateKSortableID(precision), precision)
+			if err != nil {
+				return fmt.Errorf("%v precision: %w", precision, err)
+			}
+			if err := checkTime(t); err != nil {
+				return fmt.Errorf("%v precision: %w", precision, err)
+			}
+		}
+		return nil
+	}())
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,9 @@
+package id_gen
+
+import "testing"
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Errorf("SelfTest() error = %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// maxShortCodeAttempts bounds how many candidates GenerateUniqueShortCode tries
+const maxShortCodeAttempts = 16
+
+var ErrNoFreeShortCode = errors.New("no free short code found")
+
+// GenerateUniqueShortCode generates a random code of length uppercase Crockford base32 characters
+// (no I, L, O or U, so codes are easy to read out) and retries until exists reports it unused.
+// exists is typically a database lookup. It gives up with ErrNoFreeShortCode after a bounded number
+// of attempts, which usually means the code space for this length is close to full.
+func GenerateUniqueShortCode(length int, exists func(string) bool) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("code length must be positive, got %d", length)
+	}
+	random := make([]byte, length)
+	for attempt := 0; attempt < maxShortCodeAttempts; attempt++ {
+		if _, err := rand.Read(random); err != nil {
+			return "", err
+		}
+		code := make([]byte, length)
+		for i, b := range random {
+			code[i] = crockfordAlphabet[b&0x1F]
+		}
+		if !exists(string(code)) {
+			notifyGenerate("short_code")
+			return string(code), nil
+		}
+	}
+	return "", fmt.Errorf("%w after %d attempts", ErrNoFreeShortCode, maxShortCodeAttempts)
+}
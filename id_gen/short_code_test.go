@@ -0,0 +1,44 @@
+package id_gen
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+var shortCodeShape = regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{6}$`)
+
+func TestGenerateUniqueShortCodeRetries(t *testing.T) {
+	var candidates []string
+	rejectFirstThree := func(code string) bool {
+		candidates = append(candidates, code)
+		return len(candidates) <= 3
+	}
+	code, err := GenerateUniqueShortCode(6, rejectFirstThree)
+	if err != nil {
+		t.Fatalf("GenerateUniqueShortCode() error = %v", err)
+	}
+	if len(candidates) != 4 || code != candidates[3] {
+		t.Errorf("GenerateUniqueShortCode() = %q after candidates %v, want the fourth candidate", code, candidates)
+	}
+	for _, candidate := range candidates {
+		if !shortCodeShape.MatchString(candidate) {
+			t.Errorf("candidate %q is not 6 uppercase Crockford base32 characters", candidate)
+		}
+	}
+}
+
+func TestGenerateUniqueShortCodeGivesUp(t *testing.T) {
+	attempts := 0
+	_, err := GenerateUniqueShortCode(6, func(string) bool { attempts++; return true })
+	if !errors.Is(err, ErrNoFreeShortCode) {
+		t.Fatalf("GenerateUniqueShortCode() error = %v, want ErrNoFreeShortCode", err)
+	}
+	if attempts != maxShortCodeAttempts {
+		t.Errorf("GenerateUniqueShortCode() tried %d candidates, want %d", attempts, maxShortCodeAttempts)
+	}
+
+	if _, err := GenerateUniqueShortCode(0, func(string) bool { return false }); err == nil {
+		t.Error("GenerateUniqueShortCode(0) returned no error")
+	}
+}
@@ -0,0 +1,50 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	shortDistributedTimeBits    = 7
+	shortDistributedCounterBits = 30
+	shortDistributedBytes       = 6 // 47 bits of payload
+	shortDistributedIDLength    = 8 // base62 characters needed for 47 bits
+)
+
+var (
+	shortDistributedOnce    sync.Once
+	shortDistributedCounter atomic.Uint64
+)
+
+// GenerateShortDistributedID generates an 8 character base62 ID that is unique across machines without a
+// central authority. It packs a 7-bit time prefix (Unix hours modulo 128), the 10-bit machine ID used by
+// GenerateSnowflakeID and a 30-bit per-process atomic counter.
+//
+// The counter starts at a random value and wraps after 2^30 (about 1.07 billion) IDs; an ID can then repeat
+// one issued 2^30 IDs earlier on the same machine if both fall in the same hour of the 128 hour (about 5.3 day)
+// prefix cycle. Starting at a random value makes reuse after a process restart unlikely but not impossible,
+// and two processes sharing a machine ID can collide, so prefer Snowflake IDs where those risks matter.
+func GenerateShortDistributedID() string {
+	once.Do(initSnowflakeGenerator)
+	shortDistributedOnce.Do(func() {
+		var seed [8]byte
+		if _, err := rand.Read(seed[:]); err == nil {
+			shortDistributedCounter.Store(binary.BigEndian.Uint64(seed[:]))
+		}
+	})
+
+	counter := shortDistributedCounter.Add(1) & (1<<shortDistributedCounterBits - 1)
+	prefix := uint64(time.Now().Unix()/3600) & (1<<shortDistributedTimeBits - 1)
+	value := prefix<<(snowflakeMachineBits+shortDistributedCounterBits) |
+		uint64(snowflakeGenerator.machineID)<<shortDistributedCounterBits |
+		counter
+
+	var payload [8]byte
+	binary.BigEndian.PutUint64(payload[:], value)
+	notifyGenerate("short_distributed")
+	return encodeBase62Fixed(payload[8-shortDistributedBytes:], shortDistributedIDLength)
+}
@@ -0,0 +1,55 @@
+package id_gen
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+)
+
+func TestGenerateShortDistributedIDConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 16, 2000
+	results := make(chan []string, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]string, perGoroutine)
+			for i := range ids {
+				ids[i] = GenerateShortDistributedID()
+			}
+			results <- ids
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for ids := range results {
+		for _, id := range ids {
+			if len(id) != shortDistributedIDLength {
+				t.Fatalf("GenerateShortDistributedID() = %q, want %d characters", id, shortDistributedIDLength)
+			}
+			if seen[id] {
+				t.Fatalf("GenerateShortDistributedID() returned %q twice", id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestGenerateShortDistributedIDMachineBits(t *testing.T) {
+	id := GenerateShortDistributedID()
+	payload, ok := decodeBase62Fixed(id, shortDistributedBytes)
+	if !ok {
+		t.Fatalf("decodeBase62Fixed(%q) failed", id)
+	}
+	var padded [8]byte
+	copy(padded[8-shortDistributedBytes:], payload)
+	value := binary.BigEndian.Uint64(padded[:])
+
+	machineID := int64(value>>shortDistributedCounterBits) & (1<<snowflakeMachineBits - 1)
+	if machineID != snowflakeGenerator.machineID {
+		t.Errorf("GenerateShortDistributedID() = %q carries machine ID %d, want %d", id, machineID, snowflakeGenerator.machineID)
+	}
+}
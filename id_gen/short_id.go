@@ -0,0 +1,83 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+const (
+	// ShortIDAlphabet is NanoID's URL-safe alphabet
+	ShortIDAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+	// ShortIDUnambiguousAlphabet drops characters that are easily confused when read or typed
+	// (0/O/o, 1/l/I, plus - and _), which suits invite codes
+	ShortIDUnambiguousAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz"
+)
+
+var ErrInvalidAlphabet = errors.New("invalid alphabet")
+
+// GenerateShortID generates a NanoID-style random ID of length characters from ShortIDAlphabet,
+// suitable for URL slugs. 21 characters give about as much entropy as a UUID v4.
+func GenerateShortID(length int) (string, error) {
+	return GenerateShortIDWithAlphabet(length, ShortIDAlphabet)
+}
+
+// GenerateShortIDWithAlphabet generates a random ID of length characters drawn uniformly from alphabet,
+// which must hold between 2 and 128 distinct ASCII characters; pass ShortIDUnambiguousAlphabet
+// to avoid look-alike characters. Randomness comes from crypto/rand, and errors from it are returned.
+func GenerateShortIDWithAlphabet(length int, alphabet string) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("ID length must be positive, got %d", length)
+	}
+	if err := validateAlphabet(alphabet); err != nil {
+		return "", err
+	}
+
+	id, err := randomStringFromAlphabet(length, alphabet)
+	if err != nil {
+		return "", err
+	}
+	notifyGenerate("short_id")
+	return id, nil
+}
+
+// randomStringFromAlphabet draws length characters uniformly from a validated alphabet. Like NanoID it masks
+// random bytes to the smallest power of two covering the alphabet and rejects values beyond it, which
+// avoids the modulo bias of picking alphabet[b%len(alphabet)].
+func randomStringFromAlphabet(length int, alphabet string) (string, error) {
+	mask := 1<<bits.Len(uint(len(alphabet)-1)) - 1
+	step := max(1, (8*mask*length)/(5*len(alphabet)))
+	id := make([]byte, 0, length)
+	random := make([]byte, step)
+	for {
+		if _, err := rand.Read(random); err != nil {
+			return "", err
+		}
+		for _, b := range random {
+			if index := int(b) & mask; index < len(alphabet) {
+				id = append(id, alphabet[index])
+				if len(id) == length {
+					return string(id), nil
+				}
+			}
+		}
+	}
+}
+
+func validateAlphabet(alphabet string) error {
+	if len(alphabet) < 2 || len(alphabet) > 128 {
+		return fmt.Errorf("%w: must have between 2 and 128 characters, got %d", ErrInvalidAlphabet, len(alphabet))
+	}
+	var seen [128]bool
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i] >= 0x80 {
+			return fmt.Errorf("%w: only ASCII characters are supported", ErrInvalidAlphabet)
+		}
+		if seen[alphabet[i]] {
+			return fmt.Errorf("%w: duplicate character %q", ErrInvalidAlphabet, alphabet[i])
+		}
+		seen[alphabet[i]] = true
+	}
+	return nil
+}
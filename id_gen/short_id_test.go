@@ -0,0 +1,71 @@
+package id_gen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateShortID(t *testing.T) {
+	for _, length := range []int{1, 8, 21, 100} {
+		id, err := GenerateShortID(length)
+		if err != nil {
+			t.Fatalf("GenerateShortID(%d) error = %v", length, err)
+		}
+		if len(id) != length || strings.Trim(id, ShortIDAlphabet) != "" {
+			t.Errorf("GenerateShortID(%d) = %q, want %d characters from ShortIDAlphabet", length, id, length)
+		}
+	}
+}
+
+func TestGenerateShortIDWithAlphabet(t *testing.T) {
+	id, err := GenerateShortIDWithAlphabet(2000, ShortIDUnambiguousAlphabet)
+	if err != nil {
+		t.Fatalf("GenerateShortIDWithAlphabet() error = %v", err)
+	}
+	if strings.ContainsAny(id, "0O1lI-_o") {
+		t.Errorf("GenerateShortIDWithAlphabet(unambiguous) = %q, want no look-alike characters", id)
+	}
+	if strings.Trim(id, ShortIDUnambiguousAlphabet) != "" {
+		t.Errorf("GenerateShortIDWithAlphabet(unambiguous) contains characters outside the alphabet")
+	}
+
+	// "abc" needs a two bit mask, so a biased implementation would favour some characters
+	const samples = 30000
+	id, err = GenerateShortIDWithAlphabet(samples, "abc")
+	if err != nil {
+		t.Fatalf("GenerateShortIDWithAlphabet() error = %v", err)
+	}
+	for _, c := range "abc" {
+		if n := strings.Count(id, string(c)); n < samples/3*9/10 || n > samples/3*11/10 {
+			t.Errorf("%q appeared %d times in %d, want about %d", c, n, samples, samples/3)
+		}
+	}
+}
+
+func TestGenerateShortIDErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		length       int
+		alphabet     string
+		wantAlphabet bool
+	}{
+		{"zero length", 0, ShortIDAlphabet, false},
+		{"negative length", -1, ShortIDAlphabet, false},
+		{"single character", 5, "a", true},
+		{"duplicate characters", 5, "abca", true},
+		{"non-ASCII", 5, "abcé", true},
+		{"too long", 5, strings.Repeat("a", 129), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := GenerateShortIDWithAlphabet(tt.length, tt.alphabet)
+			if err == nil || id != "" {
+				t.Fatalf("GenerateShortIDWithAlphabet() = %q, %v, want an error", id, err)
+			}
+			if got := errors.Is(err, ErrInvalidAlphabet); got != tt.wantAlphabet {
+				t.Errorf("GenerateShortIDWithAlphabet() error = %v, errors.Is(ErrInvalidAlphabet) = %v, want %v", err, got, tt.wantAlphabet)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package id_gen
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// GenerateSnowflakeIDBatch generates n Snowflake IDs under a single lock acquisition, which is much
+// cheaper than n GenerateSnowflakeID calls when a caller needs many IDs at once. The IDs are increasing
+// and follow the same rules as GenerateSnowflakeID, spilling into later milliseconds once a
+// millisecond's sequence is used up.
+func (sg *SnowflakeGenerator) GenerateSnowflakeIDBatch(n int) []int64 {
+	if n <= 0 {
+		return nil
+	}
+	ids := make([]int64, n)
+	sg.mutex.Lock()
+	defer sg.mutex.Unlock()
+	for i := range ids {
+		ids[i], _ = sg.generateLocked(false, false)
+	}
+	return ids
+}
+
+// AtomicSnowflakeGenerator generates default-layout Snowflake IDs without a mutex: the last timestamp
+// and sequence share one 64-bit word that is advanced with compare-and-swap, so concurrent callers never
+// block each other, which helps above a few hundred thousand IDs per second. It never reuses a timestamp
+// when the clock moves backwards but keeps issuing from the last one, and it spins until the next
+// millisecond when the sequence is exhausted. IDs don't overlap with a SnowflakeGenerator's only if the
+// two use different machine IDs.
+type AtomicSnowflakeGenerator struct {
+	state     atomic.Uint64 // timestamp<<snowflakeSequenceBits | sequence of the last issued ID
+	machineID int64
+}
+
+// NewAtomicSnowflakeGenerator creates a lock-free generator for machineID, masked to 10 bits
+func NewAtomicSnowflakeGenerator(machineID int64) *AtomicSnowflakeGenerator {
+	return &AtomicSnowflakeGenerator{machineID: machineID & defaultSnowflakeLayout.machineMask()}
+}
+
+// GenerateSnowflakeID generates a new Snowflake ID
+func (g *AtomicSnowflakeGenerator) GenerateSnowflakeID() int64 {
+	return g.claim(1)
+}
+
+// GenerateSnowflakeIDBatch generates n increasing IDs, claiming up to a millisecond's worth of
+// sequence numbers per compare-and-swap
+func (g *AtomicSnowflakeGenerator) GenerateSnowflakeIDBatch(n int) []int64 {
+	if n <= 0 {
+		return nil
+	}
+	ids := make([]int64, 0, n)
+	for len(ids) < n {
+		count := min(n-len(ids), maxSnowflakeSequence+1)
+		first := g.claim(count)
+		for i := 0; i < count; i++ {
+			ids = append(ids, first+int64(i))
+		}
+	}
+	return ids
+}
+
+// maxSnowflakeSequence is the largest sequence number of the default layout
+const maxSnowflakeSequence = 1<<snowflakeSequenceBits - 1
+
+// claim reserves count (at most maxSnowflakeSequence+1) consecutive sequence numbers within one
+// millisecond and returns the first ID
+func (g *AtomicSnowflakeGenerator) claim(count int) int64 {
+	for {
+		old := g.state.Load()
+		last := int64(old >> snowflakeSequenceBits)
+		sequence := int64(old & maxSnowflakeSequence)
+
+		timestamp := max(time.Now().UnixMilli(), last)
+		first := int64(0)
+		if timestamp == last && old != 0 {
+			first = sequence + 1
+			if first+int64(count)-1 > maxSnowflakeSequence {
+				// this millisecond is used up; spin until the clock moves on
+				continue
+			}
+		}
+
+		next := uint64(timestamp)<<snowflakeSequenceBits | uint64(first+int64(count)-1)
+		if g.state.CompareAndSwap(old, next) {
+			return defaultSnowflakeLayout.compose(timestamp, g.machineID, first)
+		}
+	}
+}
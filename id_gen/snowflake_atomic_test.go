@@ -0,0 +1,122 @@
+package id_gen
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// snowflakeSource is the generation API shared by the mutex and lock-free generators
+type snowflakeSource interface {
+	GenerateSnowflakeID() int64
+	GenerateSnowflakeIDBatch(n int) []int64
+}
+
+func snowflakeSources() []struct {
+	name   string
+	source snowflakeSource
+} {
+	return []struct {
+		name   string
+		source snowflakeSource
+	}{
+		{"mutex", NewSnowflakeGenerator(7)},
+		{"atomic", NewAtomicSnowflakeGenerator(7)},
+	}
+}
+
+func TestSnowflakeGeneratorsConcurrentUnique(t *testing.T) {
+	const goroutines, perGoroutine = 8, 5000
+	for _, tt := range snowflakeSources() {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now().Truncate(time.Millisecond)
+			results := make([][]int64, goroutines)
+			var wg sync.WaitGroup
+			for g := range results {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					ids := make([]int64, 0, perGoroutine)
+					for i := 0; i < perGoroutine/2; i++ {
+						ids = append(ids, tt.source.GenerateSnowflakeID())
+					}
+					ids = append(ids, tt.source.GenerateSnowflakeIDBatch(perGoroutine/2)...)
+					results[g] = ids
+				}(g)
+			}
+			wg.Wait()
+
+			var all []int64
+			for _, ids := range results {
+				for i := 1; i < len(ids); i++ {
+					if ids[i] <= ids[i-1] {
+						t.Fatalf("one caller received %d after %d, want increasing IDs", ids[i], ids[i-1])
+					}
+				}
+				all = append(all, ids...)
+			}
+			sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+			for i := 1; i < len(all); i++ {
+				if all[i] == all[i-1] {
+					t.Fatalf("ID %d was issued twice", all[i])
+				}
+			}
+			for _, id := range []int64{all[0], all[len(all)-1]} {
+				components := DecodeSnowflakeID(id)
+				if components.MachineID != 7 || components.Timestamp.Before(before) {
+					t.Errorf("DecodeSnowflakeID(%d) = %v, want machine 7 from after %v", id, components, before)
+				}
+			}
+		})
+	}
+}
+
+func TestSnowflakeIDBatch(t *testing.T) {
+	for _, tt := range snowflakeSources() {
+		t.Run(tt.name, func(t *testing.T) {
+			if ids := tt.source.GenerateSnowflakeIDBatch(0); ids != nil {
+				t.Errorf("GenerateSnowflakeIDBatch(0) = %v, want nil", ids)
+			}
+			// more than one millisecond's sequence space
+			ids := tt.source.GenerateSnowflakeIDBatch(10000)
+			if len(ids) != 10000 {
+				t.Fatalf("GenerateSnowflakeIDBatch(10000) returned %d IDs", len(ids))
+			}
+			for i := 1; i < len(ids); i++ {
+				if ids[i] <= ids[i-1] {
+					t.Fatalf("batch has %d after %d, want increasing IDs", ids[i], ids[i-1])
+				}
+			}
+			if next := tt.source.GenerateSnowflakeID(); next <= ids[len(ids)-1] {
+				t.Errorf("GenerateSnowflakeID() = %d after a batch ending at %d", next, ids[len(ids)-1])
+			}
+		})
+	}
+}
+
+func TestNewAtomicSnowflakeGeneratorMasksMachineID(t *testing.T) {
+	if id := NewAtomicSnowflakeGenerator(1024 + 5).GenerateSnowflakeID(); DecodeSnowflakeID(id).MachineID != 5 {
+		t.Errorf("NewAtomicSnowflakeGenerator(1029) minted machine ID %d, want 5", DecodeSnowflakeID(id).MachineID)
+	}
+}
+
+func BenchmarkSnowflakeGenerators(b *testing.B) {
+	for _, tt := range snowflakeSources() {
+		b.Run(tt.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					tt.source.GenerateSnowflakeID()
+				}
+			})
+		})
+		b.Run(tt.name+"_batch64", func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					tt.source.GenerateSnowflakeIDBatch(64)
+				}
+			})
+		})
+	}
+}
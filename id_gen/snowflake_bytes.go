@@ -0,0 +1,16 @@
+package id_gen
+
+import "encoding/binary"
+
+// SnowflakeToBytes returns the 8-byte big-endian form of a Snowflake ID. Since Snowflake IDs are
+// non-negative, the byte strings sort in the same order as the IDs, making them usable as fixed-width keys.
+func SnowflakeToBytes(id int64) [8]byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(id))
+	return b
+}
+
+// SnowflakeFromBytes is the inverse of SnowflakeToBytes
+func SnowflakeFromBytes(b [8]byte) int64 {
+	return int64(binary.BigEndian.Uint64(b[:]))
+}
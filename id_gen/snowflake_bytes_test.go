@@ -0,0 +1,34 @@
+package id_gen
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestSnowflakeBytesOrdering(t *testing.T) {
+	ids := []int64{0, 1, 255, 256, 1 << 22, math.MaxInt64, 1<<40 + 7}
+	for i := 0; i < 100; i++ {
+		ids = append(ids, GenerateSnowflakeID())
+	}
+
+	for _, id := range ids {
+		if got := SnowflakeFromBytes(SnowflakeToBytes(id)); got != id {
+			t.Errorf("SnowflakeFromBytes(SnowflakeToBytes(%d)) = %d", id, got)
+		}
+	}
+
+	byBytes := append([]int64(nil), ids...)
+	sort.Slice(byBytes, func(i, j int) bool {
+		a, b := SnowflakeToBytes(byBytes[i]), SnowflakeToBytes(byBytes[j])
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+	if !sort.SliceIsSorted(byBytes, func(i, j int) bool { return byBytes[i] < byBytes[j] }) {
+		t.Errorf("IDs sorted by their bytes = %v, want ascending int64 order", byBytes)
+	}
+
+	if got, want := SnowflakeToBytes(0x0102030405060708), [8]byte{1, 2, 3, 4, 5, 6, 7, 8}; got != want {
+		t.Errorf("SnowflakeToBytes() = %v, want big-endian %v", got, want)
+	}
+}
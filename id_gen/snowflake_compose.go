@@ -0,0 +1,91 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Default Snowflake layout: 41 bits of Unix milliseconds, 10 bits of machine ID, 12 bits of sequence
+const (
+	snowflakeTimestampBits = 41
+	snowflakeMachineBits   = 10
+	snowflakeSequenceBits  = 12
+
+	snowflakeMachineShift   = snowflakeSequenceBits
+	snowflakeTimestampShift = snowflakeSequenceBits + snowflakeMachineBits
+)
+
+var ErrSnowflakeFieldOverflow = errors.New("snowflake field overflows its bit width")
+
+// ComposeSnowflakeID assembles a Snowflake ID from its components using the default layout,
+// returning ErrSnowflakeFieldOverflow if any component doesn't fit its field.
+func ComposeSnowflakeID(timestampMillis, machineID, sequence int64) (int64, error) {
+	if err := checkSnowflakeField("timestamp", timestampMillis, snowflakeTimestampBits); err != nil {
+		return 0, err
+	}
+	if err := checkSnowflakeField("machine ID", machineID, snowflakeMachineBits); err != nil {
+		return 0, err
+	}
+	if err := checkSnowflakeField("sequence", sequence, snowflakeSequenceBits); err != nil {
+		return 0, err
+	}
+	return timestampMillis<<snowflakeTimestampShift | machineID<<snowflakeMachineShift | sequence, nil
+}
+
+func checkSnowflakeField(name string, value int64, bits uint) error {
+	if value < 0 || value >= 1<<bits {
+		return fmt.Errorf("%w: %s %d must be within [0, %d)", ErrSnowflakeFieldOverflow, name, value, int64(1)<<bits)
+	}
+	return nil
+}
+
+// snowflakeLayout describes how a SnowflakeGenerator stamps IDs: the timestamp resolution, the epoch
+// it counts from and the widths of the machine ID and sequence fields
+type snowflakeLayout struct {
+	unit         time.Duration
+	epoch        int64 // Unix time, in units, that timestamp 0 stands for
+	machineBits  uint
+	sequenceBits uint
+}
+
+// defaultSnowflakeLayout counts Unix milliseconds with a 10-bit machine ID and a 12-bit sequence
+var defaultSnowflakeLayout = snowflakeLayout{
+	unit:         time.Millisecond,
+	machineBits:  snowflakeMachineBits,
+	sequenceBits: snowflakeSequenceBits,
+}
+
+// now returns the current timestamp in the layout's units since its epoch
+func (l snowflakeLayout) now() int64 {
+	return l.ticks(time.Now())
+}
+
+// ticks converts t to a timestamp in the layout's units since its epoch
+func (l snowflakeLayout) ticks(t time.Time) int64 {
+	return t.UnixNano()/int64(l.unit) - l.epoch
+}
+
+func (l snowflakeLayout) machineMask() int64 {
+	return 1<<l.machineBits - 1
+}
+
+func (l snowflakeLayout) sequenceMask() int64 {
+	return 1<<l.sequenceBits - 1
+}
+
+func (l snowflakeLayout) timestampShift() uint {
+	return l.machineBits + l.sequenceBits
+}
+
+func (l snowflakeLayout) compose(timestamp, machineID, sequence int64) int64 {
+	return timestamp<<l.timestampShift() | machineID<<l.sequenceBits | sequence
+}
+
+// decode splits an ID into its wall clock time, machine ID and sequence
+func (l snowflakeLayout) decode(id int64) (time.Time, int64, int64) {
+	ticks := id>>l.timestampShift() + l.epoch
+	perSecond := int64(time.Second / l.unit)
+	t := time.Unix(ticks/perSecond, ticks%perSecond*int64(l.unit))
+	return t, id >> l.sequenceBits & l.machineMask(), id & l.sequenceMask()
+}
@@ -0,0 +1,57 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestComposeSnowflakeID(t *testing.T) {
+	tests := []struct {
+		name                           string
+		timestamp, machineID, sequence int64
+		want                           int64
+	}{
+		{"zero", 0, 0, 0, 0},
+		{"sequence only", 0, 0, 7, 7},
+		{"machine only", 0, 1, 0, 1 << 12},
+		{"timestamp only", 1, 0, 0, 1 << 22},
+		{"all fields max", 1<<41 - 1, 1<<10 - 1, 1<<12 - 1, 1<<63 - 1},
+		{"realistic", 1_714_564_800_123, 42, 9, 1_714_564_800_123<<22 | 42<<12 | 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComposeSnowflakeID(tt.timestamp, tt.machineID, tt.sequence)
+			if err != nil {
+				t.Fatalf("ComposeSnowflakeID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ComposeSnowflakeID() = %d, want %d", got, tt.want)
+			}
+			decoded := DecodeSnowflakeID(got)
+			if !decoded.Timestamp.Equal(time.UnixMilli(tt.timestamp)) || decoded.MachineID != tt.machineID || decoded.Sequence != tt.sequence {
+				t.Errorf("DecodeSnowflakeID(%d) = %v, want the composed components back", got, decoded)
+			}
+		})
+	}
+}
+
+func TestComposeSnowflakeIDOverflow(t *testing.T) {
+	tests := []struct {
+		name                           string
+		timestamp, machineID, sequence int64
+	}{
+		{"timestamp too large", 1 << 41, 0, 0},
+		{"negative timestamp", -1, 0, 0},
+		{"machine too large", 0, 1 << 10, 0},
+		{"sequence too large", 0, 0, 1 << 12},
+		{"negative sequence", 0, 0, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ComposeSnowflakeID(tt.timestamp, tt.machineID, tt.sequence); !errors.Is(err, ErrSnowflakeFieldOverflow) {
+				t.Errorf("ComposeSnowflakeID() error = %v, want ErrSnowflakeFieldOverflow", err)
+			}
+		})
+	}
+}
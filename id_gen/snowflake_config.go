@@ -0,0 +1,79 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidSnowflakeConfig = errors.New("invalid snowflake config")
+
+// minSnowflakeTimestampBits keeps a configured layout from leaving the timestamp too few bits to be useful
+const minSnowflakeTimestampBits = 31
+
+// SnowflakeConfig describes a Snowflake layout and generator options, e.g. to keep minting IDs compatible
+// with a scheme migrated from another service. Zero values select the defaults of NewSnowflakeGenerator.
+type SnowflakeConfig struct {
+	// Epoch is the time timestamp 0 stands for; zero means the Unix epoch
+	Epoch time.Time
+	// TimeUnit is the timestamp resolution and must divide one second; zero means a millisecond
+	TimeUnit time.Duration
+	// MachineBits and SequenceBits are the field widths; zero means 10 and 12. The timestamp gets the
+	// remaining 63 bits and must keep at least 31 of them.
+	MachineBits  uint
+	SequenceBits uint
+	// MachineID must fit in MachineBits
+	MachineID int64
+	// BorrowAhead and MaxWait behave like WithBorrowAhead and WithMaxWait; zero MaxWait means 1ms or one
+	// TimeUnit, whichever is longer, so an exhausted sequence waits for the next tick
+	BorrowAhead time.Duration
+	MaxWait     time.Duration
+}
+
+// NewSnowflakeGeneratorWithConfig creates a SnowflakeGenerator with a custom epoch, resolution and bit
+// layout. IDs stay sortable by time as long as the layout isn't changed for an existing ID space.
+// Invalid configurations return ErrInvalidSnowflakeConfig.
+func NewSnowflakeGeneratorWithConfig(cfg SnowflakeConfig) (*SnowflakeGenerator, error) {
+	layout := snowflakeLayout{
+		unit:         cfg.TimeUnit,
+		machineBits:  cfg.MachineBits,
+		sequenceBits: cfg.SequenceBits,
+	}
+	if layout.unit == 0 {
+		layout.unit = time.Millisecond
+	}
+	if layout.machineBits == 0 {
+		layout.machineBits = snowflakeMachineBits
+	}
+	if layout.sequenceBits == 0 {
+		layout.sequenceBits = snowflakeSequenceBits
+	}
+
+	if layout.unit < 0 || layout.unit > time.Second || time.Second%layout.unit != 0 {
+		return nil, fmt.Errorf("%w: time unit %v must divide one second", ErrInvalidSnowflakeConfig, layout.unit)
+	}
+	if layout.timestampShift() > 63-minSnowflakeTimestampBits {
+		return nil, fmt.Errorf("%w: %d machine and %d sequence bits leave fewer than %d timestamp bits",
+			ErrInvalidSnowflakeConfig, layout.machineBits, layout.sequenceBits, minSnowflakeTimestampBits)
+	}
+	if cfg.MachineID < 0 || cfg.MachineID > layout.machineMask() {
+		return nil, fmt.Errorf("%w: machine ID %d doesn't fit in %d bits", ErrInvalidSnowflakeConfig, cfg.MachineID, layout.machineBits)
+	}
+	if !cfg.Epoch.IsZero() {
+		layout.epoch = cfg.Epoch.UnixNano() / int64(layout.unit)
+	}
+	if layout.now() < 0 {
+		return nil, fmt.Errorf("%w: epoch %s is in the future", ErrInvalidSnowflakeConfig, cfg.Epoch.Format(time.RFC3339))
+	}
+
+	var opts []SnowflakeOption
+	if cfg.BorrowAhead > 0 {
+		opts = append(opts, WithBorrowAhead(cfg.BorrowAhead))
+	}
+	maxWait := cfg.MaxWait
+	if maxWait <= 0 {
+		maxWait = max(defaultSnowflakeMaxWait, layout.unit)
+	}
+	opts = append(opts, WithMaxWait(maxWait))
+	return newSnowflakeGenerator(cfg.MachineID, layout, opts), nil
+}
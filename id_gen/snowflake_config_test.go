@@ -0,0 +1,84 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSnowflakeGeneratorWithConfig(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		cfg         SnowflakeConfig
+		unit        time.Duration
+		machineBits uint
+		seqBits     uint
+	}{
+		{"defaults", SnowflakeConfig{MachineID: 3}, time.Millisecond, 10, 12},
+		{"custom epoch and layout", SnowflakeConfig{Epoch: epoch, MachineBits: 5, SequenceBits: 8, MachineID: 31}, time.Millisecond, 5, 8},
+		{"centisecond unit", SnowflakeConfig{Epoch: epoch, TimeUnit: 10 * time.Millisecond, MachineBits: 16, SequenceBits: 6, MachineID: 65535}, 10 * time.Millisecond, 16, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewSnowflakeGeneratorWithConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewSnowflakeGeneratorWithConfig() error = %v", err)
+			}
+			before := time.Now().Truncate(tt.unit)
+			previous := int64(-1)
+			for i := 0; i < 500; i++ {
+				id := generator.GenerateSnowflakeID()
+				if id <= previous {
+					t.Fatalf("IDs %d then %d, want strictly increasing", previous, id)
+				}
+				previous = id
+			}
+			after := time.Now()
+
+			components := generator.Decode(previous)
+			if components.MachineID != tt.cfg.MachineID {
+				t.Errorf("Decode().MachineID = %d, want %d", components.MachineID, tt.cfg.MachineID)
+			}
+			if components.Timestamp.Before(before) || components.Timestamp.After(after) {
+				t.Errorf("Decode().Timestamp = %v, want within [%v, %v]", components.Timestamp, before, after)
+			}
+			// the fields sit where the configured widths put them
+			if got := previous >> tt.seqBits & (1<<tt.machineBits - 1); got != tt.cfg.MachineID {
+				t.Errorf("machine field = %d, want %d", got, tt.cfg.MachineID)
+			}
+			if got := previous & (1<<tt.seqBits - 1); got != components.Sequence {
+				t.Errorf("sequence field = %d, want %d", got, components.Sequence)
+			}
+			epochTicks := tt.cfg.Epoch.UnixNano() / int64(tt.unit)
+			if tt.cfg.Epoch.IsZero() {
+				epochTicks = 0
+			}
+			if got, want := previous>>(tt.machineBits+tt.seqBits), components.Timestamp.UnixNano()/int64(tt.unit)-epochTicks; got != want {
+				t.Errorf("timestamp field = %d, want %d ticks since the epoch", got, want)
+			}
+		})
+	}
+}
+
+func TestNewSnowflakeGeneratorWithConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SnowflakeConfig
+	}{
+		{"unit not dividing a second", SnowflakeConfig{TimeUnit: 7 * time.Millisecond}},
+		{"unit above a second", SnowflakeConfig{TimeUnit: time.Minute}},
+		{"negative unit", SnowflakeConfig{TimeUnit: -time.Millisecond}},
+		{"too few timestamp bits", SnowflakeConfig{MachineBits: 20, SequenceBits: 13}},
+		{"machine ID too wide", SnowflakeConfig{MachineBits: 4, MachineID: 16}},
+		{"negative machine ID", SnowflakeConfig{MachineID: -1}},
+		{"future epoch", SnowflakeConfig{Epoch: time.Now().Add(time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSnowflakeGeneratorWithConfig(tt.cfg); !errors.Is(err, ErrInvalidSnowflakeConfig) {
+				t.Errorf("NewSnowflakeGeneratorWithConfig() error = %v, want ErrInvalidSnowflakeConfig", err)
+			}
+		})
+	}
+}
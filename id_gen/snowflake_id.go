@@ -0,0 +1,92 @@
+package id_gen
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// SnowflakeID is a Snowflake ID that marshals to JSON as a string, e.g. "7516158901285310464", because
+// JavaScript numbers lose precision above 2^53. Unmarshaling accepts both strings and numbers. It also
+// implements sql.Scanner and driver.Valuer for integer columns, so it can be used directly in API DTOs
+// and database models. The zero value is an unset ID, stored as SQL NULL.
+type SnowflakeID int64
+
+// NewSnowflakeID generates a SnowflakeID with the package-level generator, like GenerateSnowflakeID
+func NewSnowflakeID() SnowflakeID {
+	return SnowflakeID(GenerateSnowflakeID())
+}
+
+// Int64 returns the ID as a plain int64
+func (id SnowflakeID) Int64() int64 {
+	return int64(id)
+}
+
+// String returns the ID in decimal
+func (id SnowflakeID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// Components decodes the ID with the default layout, like DecodeSnowflakeID
+func (id SnowflakeID) Components() SnowflakeComponents {
+	return DecodeSnowflakeID(int64(id))
+}
+
+// MarshalJSON encodes the ID as a JSON string
+func (id SnowflakeID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + id.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts a decimal string or a number; null leaves the ID unchanged
+func (id *SnowflakeID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	return id.UnmarshalText(data)
+}
+
+// MarshalText implements encoding.TextMarshaler, so the ID also works as a JSON map key
+func (id SnowflakeID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses a decimal ID
+func (id *SnowflakeID) UnmarshalText(text []byte) error {
+	parsed, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not a decimal int64", ErrInvalidSnowflake, text)
+	}
+	*id = SnowflakeID(parsed)
+	return nil
+}
+
+// Value implements driver.Valuer, storing the zero value as NULL
+func (id SnowflakeID) Value() (driver.Value, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return int64(id), nil
+}
+
+// Scan implements sql.Scanner for integer and text columns; NULL scans to the zero value
+func (id *SnowflakeID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = 0
+		return nil
+	case int64:
+		*id = SnowflakeID(v)
+		return nil
+	case string:
+		return id.UnmarshalText([]byte(v))
+	case []byte:
+		return id.UnmarshalText(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidSnowflake, src)
+	}
+}
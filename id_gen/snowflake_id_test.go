@@ -0,0 +1,123 @@
+package id_gen
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnowflakeIDJSON(t *testing.T) {
+	type order struct {
+		ID     SnowflakeID            `json:"id"`
+		Parent SnowflakeID            `json:"parent"`
+		Items  map[SnowflakeID]string `json:"items"`
+	}
+	// above 2^53, where a JavaScript number would round it
+	const big SnowflakeID = 7516158901285310465
+	encoded, err := json.Marshal(order{ID: big, Items: map[SnowflakeID]string{42: "widget"}})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"id":"7516158901285310465","parent":"0","items":{"42":"widget"}}`; string(encoded) != want {
+		t.Errorf("json.Marshal() = %s, want %s", encoded, want)
+	}
+
+	var decoded order
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.ID != big || decoded.Items[42] != "widget" {
+		t.Errorf("json.Unmarshal() = %+v, want the original order", decoded)
+	}
+}
+
+func TestSnowflakeIDUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"string", `"7516158901285310465"`, 7516158901285310465, false},
+		{"number", `7516158901285310465`, 7516158901285310465, false},
+		{"negative", `"-5"`, -5, false},
+		{"padded", ` "12" `, 12, false},
+		{"null keeps value", `null`, 99, false},
+		{"empty string", `""`, 0, true},
+		{"not a number", `"abc"`, 0, true},
+		{"float", `1.5`, 0, true},
+		{"overflow", `"9223372036854775808"`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := SnowflakeID(99)
+			err := id.UnmarshalJSON([]byte(tt.input))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSnowflake) {
+					t.Errorf("UnmarshalJSON(%s) error = %v, want ErrInvalidSnowflake", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalJSON(%s) error = %v", tt.input, err)
+			}
+			if id != tt.want {
+				t.Errorf("UnmarshalJSON(%s) = %d, want %d", tt.input, id, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnowflakeIDSQL(t *testing.T) {
+	value, err := SnowflakeID(1234).Value()
+	if err != nil || value != int64(1234) {
+		t.Errorf("Value() = %v, %v, want int64 1234", value, err)
+	}
+	if value, _ := SnowflakeID(0).Value(); value != nil {
+		t.Errorf("zero Value() = %v, want NULL", value)
+	}
+
+	tests := []struct {
+		name    string
+		src     any
+		want    SnowflakeID
+		wantErr bool
+	}{
+		{"int64", int64(7516158901285310465), 7516158901285310465, false},
+		{"string", "1234", 1234, false},
+		{"bytes", []byte("1234"), 1234, false},
+		{"null", nil, 0, false},
+		{"bad text", "12a4", 0, true},
+		{"float", 3.5, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := SnowflakeID(99)
+			err := id.Scan(tt.src)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSnowflake) {
+					t.Errorf("Scan(%v) error = %v, want ErrInvalidSnowflake", tt.src, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.src, err)
+			}
+			if id != tt.want {
+				t.Errorf("Scan(%v) = %d, want %d", tt.src, id, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSnowflakeID(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	id := NewSnowflakeID()
+	if id.Int64() <= 0 || id.String() == "" {
+		t.Fatalf("NewSnowflakeID() = %d, want a positive ID", id)
+	}
+	if created := id.Components().Timestamp; created.Before(before) || created.After(time.Now()) {
+		t.Errorf("NewSnowflakeID().Components().Timestamp = %v, want about %v", created, before)
+	}
+}
@@ -0,0 +1,46 @@
+package id_gen
+
+import (
+	"fmt"
+	"time"
+)
+
+// SnowflakeComponents are the fields packed into a Snowflake ID
+type SnowflakeComponents struct {
+	Timestamp time.Time
+	MachineID int64
+	Sequence  int64
+}
+
+// String formats the components for logs, e.g. "snowflake{time=2024-05-01T12:00:00.123Z machine=42 seq=7}"
+func (c SnowflakeComponents) String() string {
+	return fmt.Sprintf("snowflake{time=%s machine=%d seq=%d}", c.Timestamp.UTC().Format(time.RFC3339Nano), c.MachineID, c.Sequence)
+}
+
+// DecodeSnowflakeID splits an ID from GenerateSnowflakeID or NewSnowflakeGenerator (the default layout:
+// Unix milliseconds, 10-bit machine ID, 12-bit sequence) into its components
+func DecodeSnowflakeID(id int64) SnowflakeComponents {
+	return decodeSnowflakeComponents(defaultSnowflakeLayout, id)
+}
+
+// Decode splits an ID minted by this generator, or any generator with the same layout and epoch, into its components
+func (sg *SnowflakeGenerator) Decode(id int64) SnowflakeComponents {
+	return decodeSnowflakeComponents(sg.layout, id)
+}
+
+func decodeSnowflakeComponents(layout snowflakeLayout, id int64) SnowflakeComponents {
+	timestamp, machineID, sequence := layout.decode(id)
+	return SnowflakeComponents{Timestamp: timestamp, MachineID: machineID, Sequence: sequence}
+}
+
+// IsSnowflakeFromMachine reports whether id was minted by the node with machineID (default layout)
+func IsSnowflakeFromMachine(id, machineID int64) bool {
+	return id >= 0 && (id>>snowflakeMachineShift)&(1<<snowflakeMachineBits-1) == machineID
+}
+
+// SnowflakeAgeSince returns how long ago id was minted, reading its timestamp as milliseconds since epoch.
+// IDs from this package's default generator use the Unix epoch, time.UnixMilli(0).
+func SnowflakeAgeSince(id int64, epoch time.Time) time.Duration {
+	minted := epoch.Add(time.Duration(id>>snowflakeTimestampShift) * time.Millisecond)
+	return time.Since(minted)
+}
@@ -0,0 +1,69 @@
+package id_gen
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsSnowflakeFromMachine(t *testing.T) {
+	generator := NewSnowflakeGenerator(42)
+	id := generator.GenerateSnowflakeID()
+	tests := []struct {
+		machineID int64
+		want      bool
+	}{
+		{42, true},
+		{41, false},
+		{0, false},
+		{1023, false},
+	}
+	for _, tt := range tests {
+		if got := IsSnowflakeFromMachine(id, tt.machineID); got != tt.want {
+			t.Errorf("IsSnowflakeFromMachine(id from 42, %d) = %v, want %v", tt.machineID, got, tt.want)
+		}
+	}
+	if IsSnowflakeFromMachine(-1, 1023) {
+		t.Error("IsSnowflakeFromMachine(-1) = true, want false for a negative ID")
+	}
+}
+
+func TestSnowflakeAgeSince(t *testing.T) {
+	minted := time.Now().Add(-time.Hour)
+	id, err := ComposeSnowflakeID(minted.UnixMilli(), 7, 0)
+	if err != nil {
+		t.Fatalf("ComposeSnowflakeID() error = %v", err)
+	}
+	if age := SnowflakeAgeSince(id, time.UnixMilli(0)); age < time.Hour || age > time.Hour+time.Minute {
+		t.Errorf("SnowflakeAgeSince() = %v, want about 1h", age)
+	}
+
+	fresh := NewSnowflakeGenerator(7).GenerateSnowflakeID()
+	if age := SnowflakeAgeSince(fresh, time.UnixMilli(0)); age < 0 || age > time.Second {
+		t.Errorf("SnowflakeAgeSince(fresh ID) = %v, want under a second", age)
+	}
+}
+
+func TestDecodeSnowflakeID(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	generator := NewSnowflakeGenerator(99)
+	first, second := generator.GenerateSnowflakeID(), generator.GenerateSnowflakeID()
+
+	decoded := DecodeSnowflakeID(second)
+	if decoded.MachineID != 99 {
+		t.Errorf("DecodeSnowflakeID().MachineID = %d, want 99", decoded.MachineID)
+	}
+	if decoded.Timestamp.Before(before) || decoded.Timestamp.After(time.Now()) {
+		t.Errorf("DecodeSnowflakeID().Timestamp = %v, want about now", decoded.Timestamp)
+	}
+	if generator.Decode(second) != decoded {
+		t.Errorf("generator.Decode() = %v, want %v", generator.Decode(second), decoded)
+	}
+	if d := DecodeSnowflakeID(first); d.Timestamp.Equal(decoded.Timestamp) && d.Sequence+1 != decoded.Sequence {
+		t.Errorf("consecutive IDs in one millisecond have sequences %d and %d", d.Sequence, decoded.Sequence)
+	}
+
+	components := SnowflakeComponents{Timestamp: time.UnixMilli(1_714_564_800_123), MachineID: 42, Sequence: 7}
+	if got, want := components.String(), "snowflake{time=2024-05-01T12:00:00.123Z machine=42 seq=7}"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package id_gen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+)
+
+// burstOverMillisecond counts the IDs a generator mints while its clock steps through one millisecond
+// in microsecond increments, without ever waiting for or fabricating a timestamp
+func burstOverMillisecond(newGenerator func(opts ...SnowflakeOption) *SnowflakeGenerator) int {
+	clock := timeutil.NewFakeClock(time.Now().Truncate(time.Millisecond))
+	generator := newGenerator(WithClock(clock), WithMaxWait(0))
+	minted := 0
+	for step := 0; step < 1000; step++ {
+		for {
+			if _, err := generator.TryGenerateSnowflakeID(); err != nil {
+				break
+			}
+			minted++
+		}
+		clock.Advance(time.Microsecond)
+	}
+	return minted
+}
+
+func TestMicroSnowflakeBurstThroughput(t *testing.T) {
+	milli := burstOverMillisecond(func(opts ...SnowflakeOption) *SnowflakeGenerator { return NewSnowflakeGenerator(1, opts...) })
+	micro := burstOverMillisecond(func(opts ...SnowflakeOption) *SnowflakeGenerator { return NewMicroSnowflakeGenerator(1, opts...) })
+	if milli != 4096 {
+		t.Errorf("millisecond generator minted %d IDs in a millisecond, want 4096", milli)
+	}
+	if micro != 16*1000 {
+		t.Errorf("microsecond generator minted %d IDs in a millisecond, want %d", micro, 16*1000)
+	}
+}
+
+func TestDecodeMicroSnowflakeID(t *testing.T) {
+	createdAt := time.Date(2030, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	clock := timeutil.NewFakeClock(createdAt)
+	generator := NewMicroSnowflakeGenerator(77, WithClock(clock))
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		ids = append(ids, generator.GenerateSnowflakeID())
+	}
+	clock.Advance(time.Microsecond)
+	ids = append(ids, generator.GenerateSnowflakeID())
+
+	tests := []struct {
+		timestamp time.Time
+		sequence  int64
+	}{
+		{createdAt, 0},
+		{createdAt, 1},
+		{createdAt, 2},
+		{createdAt.Add(time.Microsecond), 0},
+	}
+	for i, tt := range tests {
+		timestamp, machineID, sequence := DecodeMicroSnowflakeID(ids[i])
+		if !timestamp.Equal(tt.timestamp) || machineID != 77 || sequence != tt.sequence {
+			t.Errorf("DecodeMicroSnowflakeID(%d) = %v, %d, %d, want %v, 77, %d", ids[i], timestamp, machineID, sequence, tt.timestamp, tt.sequence)
+		}
+		if i > 0 && ids[i] <= ids[i-1] {
+			t.Errorf("ID %d follows %d, want increasing IDs", ids[i], ids[i-1])
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package id_gen
+
+import "time"
+
+// Epochs of well-known public Snowflake formats. Both use the default layout of a millisecond timestamp,
+// 10 bits of machine ID (Discord splits them into worker and process IDs) and a 12-bit sequence.
+var (
+	// DiscordEpoch is the start of 2015 UTC, the epoch of Discord IDs
+	DiscordEpoch = time.UnixMilli(1420070400000).UTC()
+	// TwitterEpoch is 2010-11-04T01:42:54.657Z, the epoch of Twitter (X) IDs
+	TwitterEpoch = time.UnixMilli(1288834974657).UTC()
+)
+
+// NewPublicSnowflakeGenerator creates a SnowflakeGenerator whose timestamps count milliseconds from
+// epoch, such as DiscordEpoch or TwitterEpoch, so it mints IDs compatible with that platform's format
+func NewPublicSnowflakeGenerator(machineID int64, epoch time.Time, opts ...SnowflakeOption) *SnowflakeGenerator {
+	layout := defaultSnowflakeLayout
+	layout.epoch = epoch.UnixMilli()
+	return newSnowflakeGenerator(machineID, layout, opts)
+}
+
+// DecodePublicSnowflake returns the creation time of a Snowflake ID whose timestamp counts milliseconds
+// from epoch, e.g. a Discord ID with DiscordEpoch
+func DecodePublicSnowflake(id int64, epoch time.Time) time.Time {
+	return time.UnixMilli(id>>snowflakeTimestampShift + epoch.UnixMilli())
+}
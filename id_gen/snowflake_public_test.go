@@ -0,0 +1,44 @@
+package id_gen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+)
+
+func TestDecodePublicSnowflakeDiscord(t *testing.T) {
+	// the example from Discord's API reference
+	const id = 175928847299117063
+	want := time.Date(2016, 4, 30, 11, 18, 25, 796_000_000, time.UTC)
+	got := DecodePublicSnowflake(id, DiscordEpoch)
+	if d := got.Sub(want); d < -time.Millisecond || d > time.Millisecond {
+		t.Errorf("DecodePublicSnowflake(%d, DiscordEpoch) = %v, want %v", id, got.UTC(), want)
+	}
+}
+
+func TestNewPublicSnowflakeGenerator(t *testing.T) {
+	createdAt := time.Date(2024, 2, 29, 8, 30, 0, 250_000_000, time.UTC)
+	tests := []struct {
+		name  string
+		epoch time.Time
+	}{
+		{"discord", DiscordEpoch},
+		{"twitter", TwitterEpoch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewPublicSnowflakeGenerator(5, tt.epoch, WithClock(timeutil.NewFakeClock(createdAt)))
+			id := generator.GenerateSnowflakeID()
+			if got := DecodePublicSnowflake(id, tt.epoch); !got.Equal(createdAt) {
+				t.Errorf("DecodePublicSnowflake() = %v, want %v", got.UTC(), createdAt)
+			}
+			if want := createdAt.Sub(tt.epoch).Milliseconds(); id>>snowflakeTimestampShift != want {
+				t.Errorf("timestamp field = %d, want %d milliseconds since the epoch", id>>snowflakeTimestampShift, want)
+			}
+			if components := generator.Decode(id); components.MachineID != 5 || !components.Timestamp.Equal(createdAt) {
+				t.Errorf("Decode() = %v, want machine 5 at %v", components, createdAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package id_gen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+)
+
+// randomNodeBits is the number of random bits below the timestamp: the machine and sequence fields combined
+const randomNodeBits = snowflakeMachineBits + snowflakeSequenceBits
+
+// GenerateRandomNodeSnowflake generates a Snowflake-layout ID whose low 22 bits (normally the machine ID
+// and sequence) are filled from crypto/rand on every call, so no machine ID needs assigning. IDs still
+// sort by their millisecond timestamp but are unordered within a millisecond. Returns 0 on failure.
+//
+// Only IDs minted in the same millisecond can collide. For k such IDs across all processes the chance
+// of any collision is about k²/2^23: roughly 0.001% for k=10, 0.1% for k=100 and 11% for k=1000
+// (see EstimateRandomNodeSnowflakeCollisionProbability). Use a coordinated generator beyond low rates.
+func GenerateRandomNodeSnowflake() int64 {
+	var random [8]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return 0
+	}
+	node := int64(binary.BigEndian.Uint64(random[:]) & (1<<randomNodeBits - 1))
+	notifyGenerate("random_node_snowflake")
+	return time.Now().UnixMilli()<<snowflakeTimestampShift | node
+}
+
+// EstimateRandomNodeSnowflakeCollisionProbability estimates the collision probability for count IDs
+// from GenerateRandomNodeSnowflake minted in the same millisecond
+func EstimateRandomNodeSnowflakeCollisionProbability(countPerMillisecond uint64) float64 {
+	return EstimateCollisionProbability(randomNodeBits, countPerMillisecond)
+}
@@ -0,0 +1,57 @@
+package id_gen
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGenerateRandomNodeSnowflake(t *testing.T) {
+	const count = 50000
+	perMillisecond := map[int64]int{}
+	seen := make(map[int64]bool, count)
+	collisions := 0
+	previousMillis := int64(0)
+	for i := 0; i < count; i++ {
+		id := GenerateRandomNodeSnowflake()
+		millis := id >> snowflakeTimestampShift
+		if millis < previousMillis {
+			t.Fatalf("ID %d has timestamp %d after timestamp %d, want time ordering", id, millis, previousMillis)
+		}
+		previousMillis = millis
+		perMillisecond[millis]++
+		if seen[id] {
+			collisions++
+		}
+		seen[id] = true
+	}
+
+	// the expected number of colliding pairs given how many IDs landed in each millisecond
+	expected := 0.0
+	for _, k := range perMillisecond {
+		expected += float64(k) * float64(k-1) / 2 / math.Exp2(randomNodeBits)
+	}
+	if limit := 3*expected + 5; float64(collisions) > limit {
+		t.Errorf("%d collisions among %d IDs, want at most %.1f (%.2f expected)", collisions, count, limit, expected)
+	}
+
+	if now := time.Now().UnixMilli(); previousMillis > now || previousMillis < now-time.Minute.Milliseconds() {
+		t.Errorf("embedded timestamp %d, want close to %d", previousMillis, now)
+	}
+}
+
+func TestEstimateRandomNodeSnowflakeCollisionProbability(t *testing.T) {
+	tests := []struct {
+		count    uint64
+		min, max float64
+	}{
+		{10, 0.000005, 0.00002},
+		{100, 0.0008, 0.0015},
+		{1000, 0.09, 0.13},
+	}
+	for _, tt := range tests {
+		if got := EstimateRandomNodeSnowflakeCollisionProbability(tt.count); got < tt.min || got > tt.max {
+			t.Errorf("EstimateRandomNodeSnowflakeCollisionProbability(%d) = %g, want in [%g, %g]", tt.count, got, tt.min, tt.max)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package id_gen
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReserveSnowflakeRange reserves n consecutive Snowflake IDs, start through end inclusive, that no
+// other call on this generator will hand out. All IDs in the block share one timestamp, so n can be at
+// most the size of the sequence space: 4096 by default, 16 for microsecond generators. If the current
+// timestamp doesn't have n sequence slots left, the generator waits up to MaxWait for the next one (or
+// borrows ahead, when enabled) and returns ErrSequenceExhausted if none becomes available. If the clock
+// has stepped backwards the last issued timestamp keeps being used.
+func (sg *SnowflakeGenerator) ReserveSnowflakeRange(n int) (start, end int64, err error) {
+	maxRange := sg.layout.sequenceMask() + 1
+	if n <= 0 || int64(n) > maxRange {
+		return 0, 0, fmt.Errorf("range size must be between 1 and %d, got %d", maxRange, n)
+	}
+
+	sg.mutex.Lock()
+	defer sg.mutex.Unlock()
+	sg.resumeFromPeak()
+
+	now := sg.now()
+	if drift := sg.lastClock - now; drift > 0 {
+		if hook := OnClockRollback; hook != nil {
+			hook(time.Duration(drift) * sg.layout.unit)
+		}
+	}
+	sg.lastClock = now
+	timestamp := max(now, sg.lastTimestamp)
+
+	first := int64(0)
+	if timestamp == sg.lastTimestamp {
+		first = sg.sequence + 1
+		if first+int64(n) > maxRange {
+			if sg.borrowAhead > 0 && sg.lastTimestamp+1-now <= sg.borrowAhead {
+				timestamp = sg.lastTimestamp + 1
+			}
+			deadline := time.Now().Add(sg.maxWait)
+			for timestamp <= sg.lastTimestamp {
+				if time.Now().After(deadline) {
+					return 0, 0, ErrSequenceExhausted
+				}
+				timestamp = sg.now()
+				sg.lastClock = timestamp
+			}
+			first = 0
+		}
+	}
+
+	sg.lastTimestamp = timestamp
+	sg.sequence = first + int64(n) - 1
+
+	start = sg.layout.compose(timestamp, sg.machineID, first)
+	return start, start + int64(n) - 1, nil
+}
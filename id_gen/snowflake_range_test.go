@@ -0,0 +1,91 @@
+package id_gen
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+)
+
+func TestReserveSnowflakeRangeConcurrent(t *testing.T) {
+	const reservers, perReserver, size = 8, 50, 100
+	generator := NewSnowflakeGenerator(3, WithMaxWait(time.Second))
+
+	type span struct{ start, end int64 }
+	var mu sync.Mutex
+	var spans []span
+	var wg sync.WaitGroup
+	for r := 0; r < reservers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perReserver; i++ {
+				start, end, err := generator.ReserveSnowflakeRange(size)
+				if err != nil {
+					t.Errorf("ReserveSnowflakeRange() error = %v", err)
+					return
+				}
+				if end-start+1 != size {
+					t.Errorf("ReserveSnowflakeRange() = [%d, %d], want %d IDs", start, end, size)
+				}
+				mu.Lock()
+				spans = append(spans, span{start, end})
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	for i := 1; i < len(spans); i++ {
+		if spans[i].start <= spans[i-1].end {
+			t.Fatalf("ranges [%d, %d] and [%d, %d] overlap", spans[i-1].start, spans[i-1].end, spans[i].start, spans[i].end)
+		}
+	}
+
+	// single IDs handed out afterwards don't fall into any reserved range either
+	last := spans[len(spans)-1]
+	if id := generator.GenerateSnowflakeID(); id <= last.end {
+		t.Errorf("GenerateSnowflakeID() = %d after a range ending at %d", id, last.end)
+	}
+}
+
+func TestReserveSnowflakeRangeLimits(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Now())
+	generator := NewSnowflakeGenerator(1, WithClock(clock), WithMaxWait(5*time.Millisecond))
+
+	for _, n := range []int{0, -1, 4097} {
+		if _, _, err := generator.ReserveSnowflakeRange(n); err == nil {
+			t.Errorf("ReserveSnowflakeRange(%d) returned no error", n)
+		}
+	}
+	if _, _, err := generator.ReserveSnowflakeRange(4000); err != nil {
+		t.Fatalf("ReserveSnowflakeRange(4000) error = %v", err)
+	}
+	// the frozen clock leaves only 96 slots in this millisecond
+	if _, _, err := generator.ReserveSnowflakeRange(100); err != ErrSequenceExhausted {
+		t.Errorf("ReserveSnowflakeRange(100) error = %v, want ErrSequenceExhausted", err)
+	}
+	clock.Advance(time.Millisecond)
+	if start, end, err := generator.ReserveSnowflakeRange(4096); err != nil || end-start != 4095 {
+		t.Errorf("ReserveSnowflakeRange(4096) = [%d, %d], %v, want a full millisecond", start, end, err)
+	}
+}
+
+func TestReserveSnowflakeRangeTracksClock(t *testing.T) {
+	start := time.Now()
+	clock := timeutil.NewFakeClock(start)
+	generator := NewSnowflakeGenerator(1, WithClock(clock))
+	if _, _, err := generator.ReserveSnowflakeRange(10); err != nil {
+		t.Fatalf("ReserveSnowflakeRange(10) error = %v", err)
+	}
+
+	// the rollback is only seen if the reservation recorded the clock reading
+	clock.Set(start.Add(-5 * time.Millisecond))
+	if _, err := generator.GenerateSnowflakeIDSafe(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Errorf("GenerateSnowflakeIDSafe() after a rollback error = %v, want ErrClockMovedBackwards", err)
+	}
+}
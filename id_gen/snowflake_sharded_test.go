@@ -0,0 +1,114 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+)
+
+func TestShardedSnowflakeGenerator(t *testing.T) {
+	tests := []struct {
+		name      string
+		shardBits uint
+		machineID int64
+		shard     int64
+	}{
+		{"one bit", 1, 511, 1},
+		{"tenant", 6, 9, 42},
+		{"max shard", 6, 15, 63},
+		{"zero shard", 4, 63, 0},
+		{"nine bits", 9, 1, 511},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := timeutil.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+			generator, err := NewShardedSnowflakeGenerator(tt.shardBits, tt.machineID, WithClock(clock))
+			if err != nil {
+				t.Fatalf("NewShardedSnowflakeGenerator() error = %v", err)
+			}
+			id, err := generator.GenerateSnowflakeID(tt.shard)
+			if err != nil {
+				t.Fatalf("GenerateSnowflakeID(%d) error = %v", tt.shard, err)
+			}
+			if got := generator.ExtractShard(id); got != tt.shard {
+				t.Errorf("ExtractShard() = %d, want %d", got, tt.shard)
+			}
+			if got := ExtractShard(id, tt.shardBits); got != tt.shard {
+				t.Errorf("ExtractShard(id, %d) = %d, want %d", tt.shardBits, got, tt.shard)
+			}
+
+			components := DecodeSnowflakeID(id)
+			wantMachine := tt.shard<<(snowflakeMachineBits-tt.shardBits) | tt.machineID
+			if components.MachineID != wantMachine || !components.Timestamp.Equal(clock.Now()) || components.Sequence != 0 {
+				t.Errorf("DecodeSnowflakeID() = %+v, want machine field %d at %v", components, wantMachine, clock.Now())
+			}
+		})
+	}
+}
+
+func TestShardedSnowflakeGeneratorUniqueAcrossShards(t *testing.T) {
+	clock := timeutil.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	generator, err := NewShardedSnowflakeGenerator(4, 3, WithClock(clock))
+	if err != nil {
+		t.Fatalf("NewShardedSnowflakeGenerator() error = %v", err)
+	}
+	if generator.ShardBits() != 4 {
+		t.Errorf("ShardBits() = %d, want 4", generator.ShardBits())
+	}
+
+	seen := map[int64]bool{}
+	previous := int64(0)
+	for i := 0; i < 1000; i++ {
+		shard := int64(i % 16)
+		id, err := generator.GenerateSnowflakeID(shard)
+		if err != nil {
+			t.Fatalf("GenerateSnowflakeID(%d) error = %v", shard, err)
+		}
+		if seen[id] {
+			t.Fatalf("GenerateSnowflakeID() returned %d twice", id)
+		}
+		seen[id] = true
+		if generator.ExtractShard(id) != shard {
+			t.Fatalf("ExtractShard(%d) = %d, want %d", id, generator.ExtractShard(id), shard)
+		}
+		if i%100 == 99 {
+			clock.Advance(time.Millisecond)
+			if id <= previous {
+				t.Errorf("ID %d after %d, want IDs to increase across milliseconds", id, previous)
+			}
+			previous = id
+		}
+	}
+}
+
+func TestShardedSnowflakeGeneratorErrors(t *testing.T) {
+	configs := []struct {
+		name      string
+		shardBits uint
+		machineID int64
+	}{
+		{"no shard bits", 0, 1},
+		{"all machine bits", 10, 0},
+		{"machine ID too wide", 6, 16},
+		{"negative machine ID", 6, -1},
+	}
+	for _, tt := range configs {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewShardedSnowflakeGenerator(tt.shardBits, tt.machineID); !errors.Is(err, ErrInvalidSnowflakeConfig) {
+				t.Errorf("NewShardedSnowflakeGenerator(%d, %d) error = %v, want ErrInvalidSnowflakeConfig", tt.shardBits, tt.machineID, err)
+			}
+		})
+	}
+
+	generator, err := NewShardedSnowflakeGenerator(6, 1)
+	if err != nil {
+		t.Fatalf("NewShardedSnowflakeGenerator() error = %v", err)
+	}
+	for _, shard := range []int64{-1, 64, 1 << 20} {
+		if id, err := generator.GenerateSnowflakeID(shard); !errors.Is(err, ErrShardOverflow) || id != 0 {
+			t.Errorf("GenerateSnowflakeID(%d) = %d, %v, want ErrShardOverflow", shard, id, err)
+		}
+	}
+}
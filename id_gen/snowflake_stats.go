@@ -0,0 +1,23 @@
+package id_gen
+
+// SnowflakeStats is a consistent snapshot of a SnowflakeGenerator's progress
+type SnowflakeStats struct {
+	// LastTimestamp is the timestamp field of the last ID issued, in the generator's units since its
+	// epoch: Unix milliseconds by default, or microseconds for NewMicroSnowflakeGenerator
+	LastTimestamp int64
+	// CurrentSequence is the sequence number of the last ID issued within LastTimestamp
+	CurrentSequence int64
+	MachineID       int64
+}
+
+// Stats returns the generator's last timestamp, sequence and machine ID, read under its mutex.
+// Comparing CurrentSequence against the sequence space shows how close a node runs to exhaustion.
+func (sg *SnowflakeGenerator) Stats() SnowflakeStats {
+	sg.mutex.Lock()
+	defer sg.mutex.Unlock()
+	return SnowflakeStats{
+		LastTimestamp:   sg.lastTimestamp,
+		CurrentSequence: sg.sequence,
+		MachineID:       sg.machineID,
+	}
+}
@@ -0,0 +1,45 @@
+package id_gen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/timeutil"
+)
+
+func TestSnowflakeGeneratorStats(t *testing.T) {
+	start := time.Now().Truncate(time.Millisecond)
+	clock := timeutil.NewFakeClock(start)
+	generator := NewSnowflakeGenerator(9, WithClock(clock))
+
+	if got, want := generator.Stats(), (SnowflakeStats{MachineID: 9}); got != want {
+		t.Errorf("Stats() before generating = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name     string
+		generate int
+		advance  time.Duration
+		want     SnowflakeStats
+	}{
+		{"several IDs in one millisecond", 5, 0, SnowflakeStats{start.UnixMilli(), 4, 9}},
+		{"more IDs in the same millisecond", 3, 0, SnowflakeStats{start.UnixMilli(), 7, 9}},
+		{"new millisecond resets the sequence", 2, 3 * time.Millisecond, SnowflakeStats{start.Add(3 * time.Millisecond).UnixMilli(), 1, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Advance(tt.advance)
+			var last int64
+			for i := 0; i < tt.generate; i++ {
+				last = generator.GenerateSnowflakeID()
+			}
+			got := generator.Stats()
+			if got != tt.want {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.want)
+			}
+			if decoded := generator.Decode(last); decoded.Sequence != got.CurrentSequence {
+				t.Errorf("last ID has sequence %d, Stats() reports %d", decoded.Sequence, got.CurrentSequence)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+)
+
+var ErrInvalidEnvTag = errors.New("invalid environment tag")
+
+// allowedEnvTags is the set of environment tags accepted by GenerateTaggedID
+var allowedEnvTags = map[string]bool{
+	"prod": true,
+	"stag": true,
+	"dev":  true,
+	"test": true,
+}
+
+// GenerateTaggedID generates "<env>_<ULID>", carrying the environment the ID was minted in so that,
+// for example, a staging ID can't be used to delete production records. env must be one of
+// "prod", "stag", "dev" or "test"; any other value yields an empty string.
+func GenerateTaggedID(env string) string {
+	if !allowedEnvTags[env] {
+		return ""
+	}
+	id := GenerateSortableId()
+	if id == "" {
+		return ""
+	}
+	return env + "_" + id
+}
+
+// TagOf returns the environment tag of an ID produced by GenerateTaggedID
+func TagOf(id string) (string, error) {
+	env, payload, ok := strings.Cut(id, "_")
+	if !ok || !allowedEnvTags[env] {
+		return "", fmt.Errorf("%w in ID %q", ErrInvalidEnvTag, id)
+	}
+	if _, err := ulid.ParseStrict(payload); err != nil {
+		return "", fmt.Errorf("invalid tagged ID %q: %w", id, err)
+	}
+	return env, nil
+}
+
+// IsFromEnv reports whether id is a valid tagged ID minted in env
+func IsFromEnv(id, env string) bool {
+	tag, err := TagOf(id)
+	return err == nil && tag == env
+}
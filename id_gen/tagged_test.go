@@ -0,0 +1,60 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateTaggedID(t *testing.T) {
+	for _, env := range []string{"prod", "stag", "dev", "test"} {
+		t.Run(env, func(t *testing.T) {
+			id := GenerateTaggedID(env)
+			tag, err := TagOf(id)
+			if err != nil {
+				t.Fatalf("TagOf(%q) error = %v", id, err)
+			}
+			if tag != env {
+				t.Errorf("TagOf(%q) = %q, want %q", id, tag, env)
+			}
+			if !IsFromEnv(id, env) {
+				t.Errorf("IsFromEnv(%q, %q) = false, want true", id, env)
+			}
+		})
+	}
+
+	for _, env := range []string{"", "production", "PROD", "qa"} {
+		if id := GenerateTaggedID(env); id != "" {
+			t.Errorf("GenerateTaggedID(%q) = %q, want empty", env, id)
+		}
+	}
+}
+
+func TestTaggedIDMismatch(t *testing.T) {
+	staging := GenerateTaggedID("stag")
+	if IsFromEnv(staging, "prod") {
+		t.Errorf("IsFromEnv(%q, prod) = true, want false", staging)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"untagged ULID", GenerateSortableId()},
+		{"unknown tag", "qa_" + GenerateSortableId()},
+		{"bad payload", "prod_notaulid"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TagOf(tt.id); err == nil {
+				t.Errorf("TagOf(%q) returned no error", tt.id)
+			}
+			if IsFromEnv(tt.id, "prod") {
+				t.Errorf("IsFromEnv(%q, prod) = true, want false", tt.id)
+			}
+		})
+	}
+	if _, err := TagOf("qa_" + GenerateSortableId()); !errors.Is(err, ErrInvalidEnvTag) {
+		t.Errorf("TagOf() with an unknown tag error = %v, want ErrInvalidEnvTag", err)
+	}
+}
@@ -0,0 +1,52 @@
+package id_gen
+
+import (
+	"math"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// MinULIDForTime returns the smallest ULID minted in t's millisecond, so "id >= MinULIDForTime(from)"
+// selects records created at or after from with an index range scan. Times outside the ULID range are clamped.
+func MinULIDForTime(t time.Time) string {
+	var id ulid.ULID
+	_ = id.SetTime(ulidTimestampClamped(t))
+	return id.String()
+}
+
+// MaxULIDForTime returns the largest ULID minted in t's millisecond, so "id <= MaxULIDForTime(to)"
+// includes every record created up to and including that millisecond
+func MaxULIDForTime(t time.Time) string {
+	id := ulid.ULID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	_ = id.SetTime(ulidTimestampClamped(t))
+	return id.String()
+}
+
+func ulidTimestampClamped(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	if ms < 0 {
+		return 0
+	}
+	return min(uint64(ms), ulid.MaxTime())
+}
+
+// MinSnowflakeForTime returns the smallest default-layout Snowflake ID (as minted by GenerateSnowflakeID)
+// in t's millisecond, for range scans like MinULIDForTime. Times outside the layout's range are clamped.
+func MinSnowflakeForTime(t time.Time) int64 {
+	return snowflakeTimestampClamped(t) << snowflakeTimestampShift
+}
+
+// MaxSnowflakeForTime returns the largest default-layout Snowflake ID in t's millisecond,
+// i.e. with every machine and sequence bit set
+func MaxSnowflakeForTime(t time.Time) int64 {
+	ms := snowflakeTimestampClamped(t)
+	if ms == 1<<snowflakeTimestampBits-1 {
+		return math.MaxInt64
+	}
+	return (ms+1)<<snowflakeTimestampShift - 1
+}
+
+func snowflakeTimestampClamped(t time.Time) int64 {
+	return min(max(t.UnixMilli(), 0), 1<<snowflakeTimestampBits-1)
+}
@@ -0,0 +1,96 @@
+package id_gen
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func TestULIDForTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       time.Time
+		min, max string
+	}{
+		{"spec example", time.UnixMilli(1469922850259), "01ARZ3NDEK0000000000000000", "01ARZ3NDEKZZZZZZZZZZZZZZZZ"},
+		{"sub-millisecond truncated", time.UnixMilli(1469922850259).Add(999 * time.Microsecond), "01ARZ3NDEK0000000000000000", "01ARZ3NDEKZZZZZZZZZZZZZZZZ"},
+		{"unix epoch", time.UnixMilli(0), "00000000000000000000000000", "0000000000ZZZZZZZZZZZZZZZZ"},
+		{"before epoch clamped", time.UnixMilli(-1), "00000000000000000000000000", "0000000000ZZZZZZZZZZZZZZZZ"},
+		{"past max clamped", time.UnixMilli(int64(ulid.MaxTime())).Add(time.Hour), "7ZZZZZZZZZ0000000000000000", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MinULIDForTime(tt.at); got != tt.min {
+				t.Errorf("MinULIDForTime(%v) = %s, want %s", tt.at, got, tt.min)
+			}
+			if got := MaxULIDForTime(tt.at); got != tt.max {
+				t.Errorf("MaxULIDForTime(%v) = %s, want %s", tt.at, got, tt.max)
+			}
+		})
+	}
+}
+
+func TestULIDForTimeBoundsGeneratedIDs(t *testing.T) {
+	at := time.Date(2024, 3, 1, 9, 30, 0, 123_000_000, time.UTC)
+	from, to := MinULIDForTime(at), MaxULIDForTime(at)
+	for i := 0; i < 100; i++ {
+		if id := GenerateSortableIdAt(at); id < from || id > to {
+			t.Fatalf("GenerateSortableIdAt(%v) = %s, want within [%s, %s]", at, id, from, to)
+		}
+	}
+	if before := GenerateSortableIdAt(at.Add(-time.Millisecond)); before >= from {
+		t.Errorf("ID from the previous millisecond %s is not below %s", before, from)
+	}
+	if after := GenerateSortableIdAt(at.Add(time.Millisecond)); after <= to {
+		t.Errorf("ID from the next millisecond %s is not above %s", after, to)
+	}
+}
+
+func TestSnowflakeForTime(t *testing.T) {
+	lastMillisecond := time.UnixMilli(1<<snowflakeTimestampBits - 1)
+	tests := []struct {
+		name     string
+		at       time.Time
+		min, max int64
+	}{
+		{"twitter epoch", TwitterEpoch, 1288834974657 << 22, 1288834974658<<22 - 1},
+		{"unix epoch", time.UnixMilli(0), 0, 1<<22 - 1},
+		{"before epoch clamped", time.UnixMilli(-5), 0, 1<<22 - 1},
+		{"last millisecond", lastMillisecond, (1<<snowflakeTimestampBits - 1) << 22, math.MaxInt64},
+		{"past range clamped", lastMillisecond.Add(time.Hour), (1<<snowflakeTimestampBits - 1) << 22, math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MinSnowflakeForTime(tt.at); got != tt.min {
+				t.Errorf("MinSnowflakeForTime(%v) = %d, want %d", tt.at, got, tt.min)
+			}
+			if got := MaxSnowflakeForTime(tt.at); got != tt.max {
+				t.Errorf("MaxSnowflakeForTime(%v) = %d, want %d", tt.at, got, tt.max)
+			}
+		})
+	}
+}
+
+func TestSnowflakeForTimeBoundsGeneratedIDs(t *testing.T) {
+	at := time.Date(2024, 3, 1, 9, 30, 0, 123_000_000, time.UTC)
+	from, to := MinSnowflakeForTime(at), MaxSnowflakeForTime(at)
+	if to+1 != MinSnowflakeForTime(at.Add(time.Millisecond)) {
+		t.Errorf("MaxSnowflakeForTime(t)+1 = %d, want MinSnowflakeForTime(t+1ms) = %d", to+1, MinSnowflakeForTime(at.Add(time.Millisecond)))
+	}
+	for _, machineID := range []int64{0, 1, 1023} {
+		for _, sequence := range []int64{0, 4095} {
+			id, err := ComposeSnowflakeID(at.UnixMilli(), machineID, sequence)
+			if err != nil {
+				t.Fatalf("ComposeSnowflakeID() error = %v", err)
+			}
+			if id < from || id > to {
+				t.Errorf("ComposeSnowflakeID(t, %d, %d) = %d, want within [%d, %d]", machineID, sequence, id, from, to)
+			}
+		}
+	}
+	if components := DecodeSnowflakeID(from); !components.Timestamp.Equal(at) {
+		t.Errorf("DecodeSnowflakeID(MinSnowflakeForTime(t)).Timestamp = %v, want %v", components.Timestamp, at)
+	}
+}
@@ -0,0 +1,92 @@
+package id_gen
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// gregorianOffset100ns is the number of 100ns intervals between 1582-10-15 and the Unix epoch
+const gregorianOffset100ns = 122192928000000000
+
+var ErrInvalidUUID = errors.New("invalid UUID")
+
+// InspectUUID parses s and reports its version (1-8) and variant ("RFC4122", "Microsoft", ...).
+// For time-based versions (1, 6, 7) the embedded timestamp is returned; other versions return a nil timestamp.
+func InspectUUID(s string) (version int, variant string, timestamp *time.Time, err error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return 0, "", nil, fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+
+	version = int(u.Version())
+	variant = u.Variant().String()
+
+	switch version {
+	case 1, 7:
+		sec, nsec := u.Time().UnixTime()
+		t := time.Unix(sec, nsec).UTC()
+		timestamp = &t
+	case 6:
+		// v6 stores the v1 timestamp with its fields reordered most-significant first
+		high := uint64(binary.BigEndian.Uint32(u[0:4]))
+		mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+		low := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0FFF)
+		ticks := int64(high<<28|mid<<12|low) - gregorianOffset100ns
+		t := time.Unix(0, ticks*100).UTC()
+		timestamp = &t
+	}
+	return version, variant, timestamp, nil
+}
+
+// NilUUID returns the all-zeros nil UUID, useful as an "unset" sentinel
+func NilUUID() string {
+	return uuid.Nil.String()
+}
+
+// MaxUUID returns the all-ones max UUID sentinel
+func MaxUUID() string {
+	return uuid.Max.String()
+}
+
+// IsNilUUID reports whether s is the nil UUID, in canonical or compact (32 hex digit) form
+func IsNilUUID(s string) bool {
+	u, err := uuid.Parse(s)
+	return err == nil && u == uuid.Nil
+}
+
+// IsMaxUUID reports whether s is the max UUID, in canonical or compact (32 hex digit) form
+func IsMaxUUID(s string) bool {
+	u, err := uuid.Parse(s)
+	return err == nil && u == uuid.Max
+}
+
+// Well-known UUID namespaces from RFC 4122, for GenerateUUIDv5
+const (
+	UUIDNamespaceDNS  = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	UUIDNamespaceURL  = "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+	UUIDNamespaceOID  = "6ba7b812-9dad-11d1-80b4-00c04fd430c8"
+	UUIDNamespaceX500 = "6ba7b814-9dad-11d1-80b4-00c04fd430c8"
+)
+
+// GenerateUUIDv5 derives a name-based (SHA-1) version 5 UUID from namespace and name. The same inputs
+// always give the same UUID, so repeated imports of an external record can be upserted idempotently.
+// namespace is a UUID such as UUIDNamespaceURL or one returned by NewUUIDNamespace.
+func GenerateUUIDv5(namespace, name string) (string, error) {
+	ns, err := uuid.Parse(namespace)
+	if err != nil {
+		return "", fmt.Errorf("%w: namespace: %v", ErrInvalidUUID, err)
+	}
+	notifyGenerate("uuidv5")
+	return uuid.NewSHA1(ns, []byte(name)).String(), nil
+}
+
+// NewUUIDNamespace defines an application namespace for GenerateUUIDv5 by hashing name, typically a URL
+// you control such as "https://example.com/ids/orders", under UUIDNamespaceURL. It is deterministic,
+// so the namespace can be recomputed anywhere instead of being stored.
+func NewUUIDNamespace(name string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
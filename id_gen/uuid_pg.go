@@ -0,0 +1,35 @@
+package id_gen
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UUIDToPGString normalizes any UUID form accepted by uuid.Parse (braced, urn:uuid:, unhyphenated,
+// uppercase) to the canonical lowercase hyphenated text PostgreSQL's uuid type expects
+func UUIDToPGString(s string) (string, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+	return u.String(), nil
+}
+
+// UUIDToPGBytes returns the 16 raw bytes of a UUID, the form to bind to a bytea column
+func UUIDToPGBytes(s string) ([]byte, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+	return u[:], nil
+}
+
+// UUIDFromDatabaseBytes converts 16 raw bytes read from a bytea column back to canonical UUID text
+func UUIDFromDatabaseBytes(b []byte) (string, error) {
+	u, err := uuid.FromBytes(b)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+	return u.String(), nil
+}
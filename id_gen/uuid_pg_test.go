@@ -0,0 +1,68 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUUIDToPGString(t *testing.T) {
+	const canonical = "123e4567-e89b-12d3-a456-426614174000"
+	tests := []struct {
+		name, input string
+	}{
+		{"canonical", canonical},
+		{"uppercase", "123E4567-E89B-12D3-A456-426614174000"},
+		{"unhyphenated", "123e4567e89b12d3a456426614174000"},
+		{"braced", "{123e4567-e89b-12d3-a456-426614174000}"},
+		{"urn", "urn:uuid:123e4567-e89b-12d3-a456-426614174000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UUIDToPGString(tt.input)
+			if err != nil {
+				t.Fatalf("UUIDToPGString() error = %v", err)
+			}
+			if got != canonical {
+				t.Errorf("UUIDToPGString(%q) = %q, want %q", tt.input, got, canonical)
+			}
+			if _, err := uuid.Parse(got); err != nil {
+				t.Errorf("UUIDToPGString() = %q does not parse: %v", got, err)
+			}
+		})
+	}
+}
+
+func TestUUIDToPGBytesRoundTrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		id := GenerateUUID()
+		b, err := UUIDToPGBytes(id)
+		if err != nil {
+			t.Fatalf("UUIDToPGBytes() error = %v", err)
+		}
+		if len(b) != 16 {
+			t.Fatalf("UUIDToPGBytes() = %x, want 16 raw bytes", b)
+		}
+		if got, err := UUIDFromDatabaseBytes(b); err != nil || got != id {
+			t.Errorf("UUIDFromDatabaseBytes(UUIDToPGBytes(%q)) = %q, %v", id, got, err)
+		}
+	}
+
+	want := []byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	if got, _ := UUIDToPGBytes("123e4567-e89b-12d3-a456-426614174000"); string(got) != string(want) {
+		t.Errorf("UUIDToPGBytes() = %x, want %x", got, want)
+	}
+}
+
+func TestUUIDPGErrors(t *testing.T) {
+	if _, err := UUIDToPGString("not-a-uuid"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("UUIDToPGString() error = %v, want ErrInvalidUUID", err)
+	}
+	if _, err := UUIDToPGBytes(""); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("UUIDToPGBytes() error = %v, want ErrInvalidUUID", err)
+	}
+	if _, err := UUIDFromDatabaseBytes(make([]byte, 15)); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("UUIDFromDatabaseBytes(15 bytes) error = %v, want ErrInvalidUUID", err)
+	}
+}
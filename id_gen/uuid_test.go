@@ -0,0 +1,143 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInspectUUID(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantVersion int
+		wantVariant string
+		wantTime    time.Time
+	}{
+		{"v1", "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", 1, "RFC4122", time.Date(1997, 2, 3, 17, 43, 12, 216875000, time.UTC)},
+		{"v4", "9b2c7e8a-4f1d-4c3e-8a6b-2d5f0e7c9a13", 4, "RFC4122", time.Time{}},
+		{"v7", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7, "RFC4122", time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+		{"v7 uppercase", "017F22E2-79B0-7CC3-98C4-DC0C0C07398F", 7, "RFC4122", time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+		{"microsoft variant", "9b2c7e8a-4f1d-4c3e-ca6b-2d5f0e7c9a13", 4, "Microsoft", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, variant, timestamp, err := InspectUUID(tt.input)
+			if err != nil {
+				t.Fatalf("InspectUUID() error = %v", err)
+			}
+			if version != tt.wantVersion || variant != tt.wantVariant {
+				t.Errorf("InspectUUID() = v%d %s, want v%d %s", version, variant, tt.wantVersion, tt.wantVariant)
+			}
+			switch {
+			case tt.wantTime.IsZero() && timestamp != nil:
+				t.Errorf("InspectUUID() timestamp = %v, want nil", *timestamp)
+			case !tt.wantTime.IsZero() && (timestamp == nil || !timestamp.Equal(tt.wantTime)):
+				t.Errorf("InspectUUID() timestamp = %v, want %v", timestamp, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestInspectUUIDGenerated(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	version, _, timestamp, err := InspectUUID(GenerateUUIDv7())
+	if err != nil {
+		t.Fatalf("InspectUUID() error = %v", err)
+	}
+	if version != 7 || timestamp == nil || timestamp.Before(before) || timestamp.After(time.Now()) {
+		t.Errorf("InspectUUID(GenerateUUIDv7()) = v%d at %v, want v7 at about %v", version, timestamp, before)
+	}
+	if version, _, _, _ := InspectUUID(GenerateUUID()); version != 4 {
+		t.Errorf("InspectUUID(GenerateUUID()) version = %d, want 4", version)
+	}
+}
+
+func TestInspectUUIDInvalid(t *testing.T) {
+	for _, input := range []string{"", "not-a-uuid", "f81d4fae-7dec-11d0-a765-00a0c91e6bf"} {
+		if _, _, _, err := InspectUUID(input); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("InspectUUID(%q) error = %v, want ErrInvalidUUID", input, err)
+		}
+	}
+}
+
+func TestNilAndMaxUUID(t *testing.T) {
+	if NilUUID() != "00000000-0000-0000-0000-000000000000" {
+		t.Errorf("NilUUID() = %s", NilUUID())
+	}
+	if MaxUUID() != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Errorf("MaxUUID() = %s", MaxUUID())
+	}
+	tests := []struct {
+		input        string
+		isNil, isMax bool
+	}{
+		{"00000000-0000-0000-0000-000000000000", true, false},
+		{"00000000000000000000000000000000", true, false},
+		{"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF", false, true},
+		{"ffffffffffffffffffffffffffffffff", false, true},
+		{GenerateUUID(), false, false},
+		{GenerateUUIDv7(), false, false},
+		{"not-a-uuid", false, false},
+	}
+	for _, tt := range tests {
+		if got := IsNilUUID(tt.input); got != tt.isNil {
+			t.Errorf("IsNilUUID(%q) = %v, want %v", tt.input, got, tt.isNil)
+		}
+		if got := IsMaxUUID(tt.input); got != tt.isMax {
+			t.Errorf("IsMaxUUID(%q) = %v, want %v", tt.input, got, tt.isMax)
+		}
+	}
+}
+
+func TestGenerateUUIDv5(t *testing.T) {
+	orders := NewUUIDNamespace("https://example.com/ids/orders")
+	tests := []struct {
+		name, namespace, input, want string
+	}{
+		{"dns", UUIDNamespaceDNS, "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{"dns empty name", UUIDNamespaceDNS, "", "4ebd0208-8328-5d69-8c44-ec50939c0967"},
+		{"oid", UUIDNamespaceOID, "1.3.6.1", "1447fa61-5277-5fef-a9b3-fbc6e44f4af3"},
+		{"x500", UUIDNamespaceX500, "cn=John", "1713550e-4d56-5817-bce4-d5dac105f99d"},
+		{"url", UUIDNamespaceURL, "https://example.com/ids/orders", "770dc1a0-70c3-5529-8ba9-36bdb1faf780"},
+		{"custom namespace", orders, "ext-42", "da2666cb-4375-595c-a741-6bb572376121"},
+		{"uppercase namespace", "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateUUIDv5(tt.namespace, tt.input)
+			if err != nil {
+				t.Fatalf("GenerateUUIDv5() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateUUIDv5(%s, %q) = %s, want %s", tt.namespace, tt.input, got, tt.want)
+			}
+			if again, _ := GenerateUUIDv5(tt.namespace, tt.input); again != got {
+				t.Errorf("GenerateUUIDv5() = %s then %s, want a stable UUID", got, again)
+			}
+			if version, _, _, _ := InspectUUID(got); version != 5 {
+				t.Errorf("InspectUUID(%s) version = %d, want 5", got, version)
+			}
+		})
+	}
+}
+
+func TestGenerateUUIDv5Errors(t *testing.T) {
+	if _, err := GenerateUUIDv5("not-a-namespace", "x"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("GenerateUUIDv5(not-a-namespace) error = %v, want ErrInvalidUUID", err)
+	}
+	a, _ := GenerateUUIDv5(UUIDNamespaceDNS, "example.com")
+	b, _ := GenerateUUIDv5(UUIDNamespaceURL, "example.com")
+	if a == b {
+		t.Errorf("GenerateUUIDv5() = %s in both the DNS and URL namespaces, want different UUIDs", a)
+	}
+}
+
+func TestNewUUIDNamespace(t *testing.T) {
+	if got, want := NewUUIDNamespace("https://example.com/ids/orders"), "770dc1a0-70c3-5529-8ba9-36bdb1faf780"; got != want {
+		t.Errorf("NewUUIDNamespace() = %s, want %s", got, want)
+	}
+	if NewUUIDNamespace("orders") == NewUUIDNamespace("users") {
+		t.Error("NewUUIDNamespace() returned the same namespace for different names")
+	}
+}
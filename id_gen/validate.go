@@ -0,0 +1,79 @@
+package id_gen
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	canonicalUUIDLength = 36
+	// snowflakeFutureTolerance allows for clock skew between the minting node and the validating one
+	snowflakeFutureTolerance = time.Minute
+)
+
+var (
+	ErrInvalidULID      = errors.New("invalid ULID")
+	ErrInvalidSnowflake = errors.New("invalid Snowflake ID")
+)
+
+// ValidateUUID checks that s is a UUID in canonical 8-4-4-4-12 form (either case), as accepted in path
+// parameters. Unlike uuid.Parse it rejects the braced, URN and compact forms.
+func ValidateUUID(s string) error {
+	if len(s) != canonicalUUIDLength {
+		return fmt.Errorf("%w: expected %d characters, got %d", ErrInvalidUUID, canonicalUUIDLength, len(s))
+	}
+	if _, err := uuid.Parse(s); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidUUID, err)
+	}
+	return nil
+}
+
+// IsValidUUID reports whether s is a UUID in canonical form
+func IsValidUUID(s string) bool {
+	return ValidateUUID(s) == nil
+}
+
+// ValidateULID checks that s is a 26 character ULID (either case) whose timestamp doesn't overflow
+func ValidateULID(s string) error {
+	if _, err := ulid.ParseStrict(s); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidULID, err)
+	}
+	return nil
+}
+
+// IsValidULID reports whether s is a valid ULID
+func IsValidULID(s string) bool {
+	return ValidateULID(s) == nil
+}
+
+// ValidateSnowflake sanity checks an ID in the default layout (Unix milliseconds, as minted by
+// GenerateSnowflakeID): it must be positive and its timestamp must lie between TwitterEpoch, which
+// no Snowflake scheme predates, and a minute from now, allowing for clock skew
+func ValidateSnowflake(id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("%w: %d is not positive", ErrInvalidSnowflake, id)
+	}
+	minted := DecodeSnowflakeID(id).Timestamp
+	if minted.Before(TwitterEpoch) {
+		return fmt.Errorf("%w: timestamp %s predates %s", ErrInvalidSnowflake, minted.UTC().Format(time.RFC3339), TwitterEpoch.Format(time.RFC3339))
+	}
+	if latest := time.Now().Add(snowflakeFutureTolerance); minted.After(latest) {
+		return fmt.Errorf("%w: timestamp %s is in the future", ErrInvalidSnowflake, minted.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// IsValidSnowflake reports whether id passes ValidateSnowflake
+func IsValidSnowflake(id int64) bool {
+	return ValidateSnowflake(id) == nil
+}
+
+// ValidatePrefixedID checks that id is a well-formed PrefixedID carrying prefix, like ParsePrefixedID
+func ValidatePrefixedID(prefix, id string) error {
+	_, err := ParsePrefixedID(id, prefix)
+	return err
+}
@@ -0,0 +1,132 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateUUID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"v4", "9b2c7e8a-4f1d-4c3e-8a6b-2d5f0e7c9a13", true},
+		{"uppercase", "9B2C7E8A-4F1D-4C3E-8A6B-2D5F0E7C9A13", true},
+		{"nil", "00000000-0000-0000-0000-000000000000", true},
+		{"generated", GenerateUUIDv7(), true},
+		{"empty", "", false},
+		{"compact", "9b2c7e8a4f1d4c3e8a6b2d5f0e7c9a13", false},
+		{"braced", "{9b2c7e8a-4f1d-4c3e-8a6b-2d5f0e7c9a13}", false},
+		{"urn", "urn:uuid:9b2c7e8a-4f1d-4c3e-8a6b-2d5f0e7c9a13", false},
+		{"bad hex", "9b2c7e8a-4f1d-4c3e-8a6b-2d5f0e7c9a1g", false},
+		{"misplaced hyphen", "9b2c7e8a4-f1d-4c3e-8a6b-2d5f0e7c9a13", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUUID(tt.input)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateUUID(%q) error = %v", tt.input, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("ValidateUUID(%q) error = %v, want ErrInvalidUUID", tt.input, err)
+			}
+			if got := IsValidUUID(tt.input); got != tt.valid {
+				t.Errorf("IsValidUUID(%q) = %v, want %v", tt.input, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateULID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"spec example", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"lowercase", "01arz3ndektsv4rrffq69g5fav", true},
+		{"max", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", true},
+		{"generated", GenerateSortableId(), true},
+		{"empty", "", false},
+		{"too short", "01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+		{"too long", "01ARZ3NDEKTSV4RRFFQ69G5FAVX", false},
+		{"excluded letter", "01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+		{"timestamp overflow", "80000000000000000000000000", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateULID(tt.input)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateULID(%q) error = %v", tt.input, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidULID) {
+				t.Errorf("ValidateULID(%q) error = %v, want ErrInvalidULID", tt.input, err)
+			}
+			if got := IsValidULID(tt.input); got != tt.valid {
+				t.Errorf("IsValidULID(%q) = %v, want %v", tt.input, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateSnowflake(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    int64
+		valid bool
+	}{
+		{"generated", GenerateSnowflakeID(), true},
+		{"twitter epoch", MinSnowflakeForTime(TwitterEpoch), true},
+		{"within skew tolerance", MinSnowflakeForTime(time.Now().Add(snowflakeFutureTolerance / 2)), true},
+		{"zero", 0, false},
+		{"negative", -42, false},
+		{"before twitter epoch", MaxSnowflakeForTime(TwitterEpoch.Add(-time.Millisecond)), false},
+		{"far future", MinSnowflakeForTime(time.Now().Add(time.Hour)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSnowflake(tt.id)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateSnowflake(%d) error = %v", tt.id, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSnowflake) {
+				t.Errorf("ValidateSnowflake(%d) error = %v, want ErrInvalidSnowflake", tt.id, err)
+			}
+			if got := IsValidSnowflake(tt.id); got != tt.valid {
+				t.Errorf("IsValidSnowflake(%d) = %v, want %v", tt.id, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidatePrefixedID(t *testing.T) {
+	user, err := NewPrefixedID("user")
+	if err != nil {
+		t.Fatalf("NewPrefixedID() error = %v", err)
+	}
+	tests := []struct {
+		name, prefix, id string
+		valid            bool
+	}{
+		{"matching prefix", "user", user.String(), true},
+		{"any prefix", "", user.String(), true},
+		{"uuid payload", "order", "order_9b2c7e8a-4f1d-4c3e-8a6b-2d5f0e7c9a13", true},
+		{"other prefix", "order", user.String(), false},
+		{"no separator", "user", "user01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{"bad payload", "user", "user_123", false},
+		{"uppercase prefix", "user", "USER_01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePrefixedID(tt.prefix, tt.id)
+			if tt.valid && err != nil {
+				t.Errorf("ValidatePrefixedID(%q, %q) error = %v", tt.prefix, tt.id, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidPrefixedID) {
+				t.Errorf("ValidatePrefixedID(%q, %q) error = %v, want ErrInvalidPrefixedID", tt.prefix, tt.id, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package id_gen
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// VersionedIDV1 is the current versioned ID format: the payload is a ULID
+const VersionedIDV1 byte = 1
+
+const versionedIDPrefixLength = 2 // hex digits of the version byte
+
+var (
+	ErrInvalidVersionedID = errors.New("invalid versioned ID")
+	ErrUnknownIDVersion   = errors.New("unknown versioned ID version")
+)
+
+// GenerateVersionedID generates an ID made of a version byte, written as two hex digits, followed by a
+// version specific payload, so the format can evolve while old IDs stay parseable. Version 1 (currently
+// the only one) wraps a ULID, e.g. "01" + "01ARZ3NDEKTSV4RRFFQ69G5FAV", giving 28 characters that still
+// sort by creation time among IDs of the same version. Returns "" on failure.
+func GenerateVersionedID() string {
+	id, err := ulid.New(ulid.Timestamp(time.Now()), defaultEntropy)
+	if err != nil {
+		return ""
+	}
+	notifyGenerate("versioned")
+	return fmt.Sprintf("%02X", VersionedIDV1) + id.String()
+}
+
+// ParseVersionedID splits an ID produced by GenerateVersionedID into its version and raw payload bytes
+// (the 16 ULID bytes for version 1). For a well-formed prefix naming a version this package doesn't know,
+// it returns that version together with ErrUnknownIDVersion, so callers can route it elsewhere.
+func ParseVersionedID(id string) (version byte, payload []byte, err error) {
+	if len(id) < versionedIDPrefixLength {
+		return 0, nil, ErrInvalidVersionedID
+	}
+	prefix, err := hex.DecodeString(id[:versionedIDPrefixLength])
+	if err != nil {
+		return 0, nil, ErrInvalidVersionedID
+	}
+	version = prefix[0]
+
+	switch version {
+	case VersionedIDV1:
+		parsed, err := ulid.ParseStrict(id[versionedIDPrefixLength:])
+		if err != nil {
+			return version, nil, fmt.Errorf("%w: %v", ErrInvalidVersionedID, err)
+		}
+		return version, parsed[:], nil
+	default:
+		return version, nil, fmt.Errorf("%w: %d", ErrUnknownIDVersion, version)
+	}
+}
@@ -0,0 +1,59 @@
+package id_gen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func TestVersionedIDRoundTrip(t *testing.T) {
+	id := GenerateVersionedID()
+	if len(id) != 28 || id[:2] != "01" {
+		t.Fatalf("GenerateVersionedID() = %q, want \"01\" followed by a ULID", id)
+	}
+	version, payload, err := ParseVersionedID(id)
+	if err != nil {
+		t.Fatalf("ParseVersionedID() error = %v", err)
+	}
+	if version != VersionedIDV1 {
+		t.Errorf("ParseVersionedID() version = %d, want %d", version, VersionedIDV1)
+	}
+	var parsed ulid.ULID
+	copy(parsed[:], payload)
+	if len(payload) != 16 || parsed.String() != id[2:] {
+		t.Errorf("ParseVersionedID() payload = %x, want the bytes of ULID %s", payload, id[2:])
+	}
+
+	if later := GenerateVersionedID(); later <= id {
+		t.Errorf("GenerateVersionedID() = %q after %q, want ascending", later, id)
+	}
+}
+
+func TestParseVersionedIDErrors(t *testing.T) {
+	ulidText := GenerateSortableId()
+	tests := []struct {
+		name        string
+		id          string
+		wantVersion byte
+		wantErr     error
+	}{
+		{"unknown version", "07" + ulidText, 7, ErrUnknownIDVersion},
+		{"unknown version with foreign payload", "FFsomething-else", 0xFF, ErrUnknownIDVersion},
+		{"empty", "", 0, ErrInvalidVersionedID},
+		{"too short", "0", 0, ErrInvalidVersionedID},
+		{"non-hex prefix", "zz" + ulidText, 0, ErrInvalidVersionedID},
+		{"bad v1 payload", "01notaulid", VersionedIDV1, ErrInvalidVersionedID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, payload, err := ParseVersionedID(tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseVersionedID(%q) error = %v, want %v", tt.id, err, tt.wantErr)
+			}
+			if version != tt.wantVersion || payload != nil {
+				t.Errorf("ParseVersionedID(%q) = %d, %x, want version %d and no payload", tt.id, version, payload, tt.wantVersion)
+			}
+		})
+	}
+}
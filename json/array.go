@@ -0,0 +1,235 @@
+package json
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrNotArray = errors.New("JSON value is not an array")
+
+// ChunkJSONArray splits a JSON array into JSON array strings of at most chunkSize elements each,
+// preserving element order. An empty array yields no chunks.
+func ChunkJSONArray(data string, chunkSize int) ([]string, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+	elements, err := decodeRawArray(data)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]string, 0, (len(elements)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(elements); start += chunkSize {
+		chunk, err := joinRawArray(elements[start:min(start+chunkSize, len(elements))])
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// SortJSONArray stably sorts a JSON array of objects by the value at the dotted field path.
+// Numbers compare numerically, strings lexically and booleans false before true; when types are
+// mixed, booleans sort before numbers and numbers before strings. Elements where the field is
+// missing, null or not a scalar always sort to the end, in their original order, regardless of direction.
+func SortJSONArray(data, field string, descending bool) (string, error) {
+	segments, err := parsePath(field)
+	if err != nil {
+		return "", err
+	}
+	elements, err := decodeRawArray(data)
+	if err != nil {
+		return "", err
+	}
+
+	type sortItem struct {
+		raw   json.RawMessage
+		key   any
+		valid bool
+	}
+	items := make([]sortItem, len(elements))
+	for i, element := range elements {
+		value, err := decodeJSONValue(string(element))
+		if err != nil {
+			return "", err
+		}
+		key, ok := lookupPath(value, segments)
+		items[i] = sortItem{raw: element, key: key, valid: ok && sortRank(key) >= 0}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.valid || !b.valid {
+			return a.valid && !b.valid
+		}
+		c := compareScalars(a.key, b.key)
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	sorted := make([]json.RawMessage, len(items))
+	for i, item := range items {
+		sorted[i] = item.raw
+	}
+	return joinRawArray(sorted)
+}
+
+// sortRank orders scalar JSON types for comparison; -1 marks values that can't be sorted
+func sortRank(value any) int {
+	switch value.(type) {
+	case bool:
+		return 0
+	case json.Number:
+		return 1
+	case string:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// compareScalars compares two sortable scalars, returning -1, 0 or 1
+func compareScalars(a, b any) int {
+	if ra, rb := sortRank(a), sortRank(b); ra != rb {
+		return cmp.Compare(ra, rb)
+	}
+	switch av := a.(type) {
+	case bool:
+		bv := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case !av:
+			return -1
+		default:
+			return 1
+		}
+	case json.Number:
+		ar, aok := jsonNumberRat(av)
+		br, bok := jsonNumberRat(b)
+		if !aok || !bok {
+			return cmp.Compare(av.String(), b.(json.Number).String())
+		}
+		return ar.Cmp(br)
+	default:
+		return cmp.Compare(a.(string), b.(string))
+	}
+}
+
+// joinRawArray renders raw elements as a compact JSON array
+func joinRawArray(elements []json.RawMessage) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, element := range elements {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := json.Compact(&buf, element); err != nil {
+			return "", err
+		}
+	}
+	buf.WriteByte(']')
+	return buf.String(), nil
+}
+
+// decodeRawArray decodes a JSON array into its raw, undecoded elements
+func decodeRawArray(data string) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace([]byte(data))
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, ErrNotArray
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal(trimmed, &elements); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+// AppendToJSONArray appends elements to the JSON array data by splicing their encodings in before the
+// closing bracket, so the existing elements are validated but never decoded or re-encoded. Formatting
+// of the existing content, including whitespace around the array, is preserved.
+func AppendToJSONArray(data string, elements ...any) (string, error) {
+	trimmed := bytes.TrimSpace([]byte(data))
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return "", ErrNotArray
+	}
+	if !json.Valid(trimmed) {
+		return "", fmt.Errorf("invalid JSON array")
+	}
+	if len(elements) == 0 {
+		return data, nil
+	}
+
+	closing := bytes.LastIndexByte([]byte(data), ']')
+	empty := len(bytes.TrimSpace([]byte(data[:closing]))) == len("[")
+
+	var buf bytes.Buffer
+	buf.WriteString(data[:closing])
+	for i, element := range elements {
+		encoded, err := json.Marshal(element)
+		if err != nil {
+			return "", fmt.Errorf("element %d: %w", i, err)
+		}
+		if i > 0 || !empty {
+			buf.WriteByte(',')
+		}
+		buf.Write(encoded)
+	}
+	buf.WriteString(data[closing:])
+	return buf.String(), nil
+}
+
+// JSONArrayStats summarizes the numbers in a JSON array. With an empty field the elements themselves
+// are used; otherwise field is a dotted path read from each element, as in SortJSONArray. Elements whose
+// value is missing or not a JSON number (including numeric strings) are skipped and not counted. When no
+// numbers are found every result is zero.
+func JSONArrayStats(data, field string) (min, max, sum, mean float64, count int, err error) {
+	var segments []pathSegment
+	if field != "" {
+		if segments, err = parsePath(field); err != nil {
+			return 0, 0, 0, 0, 0, err
+		}
+	}
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+	elements, ok := root.([]any)
+	if !ok {
+		return 0, 0, 0, 0, 0, ErrNotArray
+	}
+
+	for _, element := range elements {
+		value, ok := lookupPath(element, segments)
+		if !ok {
+			continue
+		}
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		f, convErr := number.Float64()
+		if convErr != nil {
+			continue
+		}
+		if count == 0 || f < min {
+			min = f
+		}
+		if count == 0 || f > max {
+			max = f
+		}
+		sum += f
+		count++
+	}
+	if count > 0 {
+		mean = sum / float64(count)
+	}
+	return min, max, sum, mean, count, nil
+}
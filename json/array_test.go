@@ -0,0 +1,176 @@
+package json
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestChunkJSONArray(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		chunkSize int
+		want      []string
+	}{
+		{"exact multiple", `[1,2,3,4]`, 2, []string{`[1,2]`, `[3,4]`}},
+		{"remainder", `[1, 2, 3, 4, 5]`, 2, []string{`[1,2]`, `[3,4]`, `[5]`}},
+		{"chunk larger than array", `[{"a":1},"b"]`, 10, []string{`[{"a":1},"b"]`}},
+		{"empty array", `[]`, 3, []string{}},
+		{"whitespace around", " [ 1 ] ", 1, []string{`[1]`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChunkJSONArray(tt.data, tt.chunkSize)
+			if err != nil {
+				t.Fatalf("ChunkJSONArray() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChunkJSONArray() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkJSONArrayErrors(t *testing.T) {
+	if _, err := ChunkJSONArray(`{"a":1}`, 2); !errors.Is(err, ErrNotArray) {
+		t.Errorf("ChunkJSONArray(object) error = %v, want ErrNotArray", err)
+	}
+	if _, err := ChunkJSONArray(`[1,`, 2); err == nil {
+		t.Error("ChunkJSONArray(truncated) returned no error")
+	}
+	if _, err := ChunkJSONArray(`[1]`, 0); err == nil {
+		t.Error("ChunkJSONArray(size 0) returned no error")
+	}
+}
+
+func TestSortJSONArray(t *testing.T) {
+	const people = `[{"n":"bo","age":30},{"n":"al","age":9},{"n":"cy","age":100},{"n":"di"}]`
+	tests := []struct {
+		name       string
+		data       string
+		field      string
+		descending bool
+		want       string
+	}{
+		{"numeric ascending", people, "age", false, `[{"n":"al","age":9},{"n":"bo","age":30},{"n":"cy","age":100},{"n":"di"}]`},
+		{"numeric descending", people, "age", true, `[{"n":"cy","age":100},{"n":"bo","age":30},{"n":"al","age":9},{"n":"di"}]`},
+		{"string ascending", people, "n", false, `[{"n":"al","age":9},{"n":"bo","age":30},{"n":"cy","age":100},{"n":"di"}]`},
+		{"string descending", people, "n", true, `[{"n":"di"},{"n":"cy","age":100},{"n":"bo","age":30},{"n":"al","age":9}]`},
+		{"nested field", `[{"a":{"v":2}},{"a":{"v":1}}]`, "a.v", false, `[{"a":{"v":1}},{"a":{"v":2}}]`},
+		{"stable for ties", `[{"k":1,"i":0},{"k":0},{"k":1,"i":1}]`, "k", false, `[{"k":0},{"k":1,"i":0},{"k":1,"i":1}]`},
+		{"mixed types", `[{"k":"a"},{"k":2},{"k":true},{"k":null}]`, "k", false, `[{"k":true},{"k":2},{"k":"a"},{"k":null}]`},
+		{"large numbers compare exactly", `[{"k":12345678901234567891},{"k":12345678901234567890}]`, "k", false, `[{"k":12345678901234567890},{"k":12345678901234567891}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SortJSONArray(tt.data, tt.field, tt.descending)
+			if err != nil {
+				t.Fatalf("SortJSONArray() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SortJSONArray() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SortJSONArray(`{"a":1}`, "a", false); !errors.Is(err, ErrNotArray) {
+		t.Errorf("SortJSONArray(object) error = %v, want ErrNotArray", err)
+	}
+}
+
+func TestAppendToJSONArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		elements []any
+		want     string
+	}{
+		{"empty array", `[]`, []any{1}, `[1]`},
+		{"empty array with inner whitespace", "[ \n ]", []any{"a", "b"}, "[ \n \"a\",\"b\"]"},
+		{"single element", `[{"id":1}]`, []any{map[string]int{"id": 2}}, `[{"id":1},{"id":2}]`},
+		{"multiple elements", `[1,2,3]`, []any{4, nil, true}, `[1,2,3,4,null,true]`},
+		{"trailing whitespace kept", "[1]\n", []any{2}, "[1,2]\n"},
+		{"nothing to append", ` [1] `, nil, ` [1] `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AppendToJSONArray(tt.data, tt.elements...)
+			if err != nil {
+				t.Fatalf("AppendToJSONArray() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AppendToJSONArray() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendToJSONArrayErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		elements []any
+		notArray bool
+	}{
+		{"malformed array", `[1,2`, []any{3}, false},
+		{"trailing garbage", `[1]]`, []any{3}, false},
+		{"object", `{"a":1}`, []any{3}, true},
+		{"empty input", ``, []any{3}, true},
+		{"unmarshalable element", `[1]`, []any{make(chan int)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AppendToJSONArray(tt.data, tt.elements...)
+			if err == nil {
+				t.Fatal("AppendToJSONArray() returned no error")
+			}
+			if got := errors.Is(err, ErrNotArray); got != tt.notArray {
+				t.Errorf("AppendToJSONArray() error = %v, errors.Is(ErrNotArray) = %v, want %v", err, got, tt.notArray)
+			}
+		})
+	}
+}
+
+func TestJSONArrayStats(t *testing.T) {
+	type stats struct {
+		min, max, sum, mean float64
+		count               int
+	}
+	tests := []struct {
+		name  string
+		data  string
+		field string
+		want  stats
+	}{
+		{"numbers", `[3, 1.5, -2, 7.5]`, "", stats{-2, 7.5, 10, 2.5, 4}},
+		{"numbers with non-numeric entries", `[4, "5", null, true, 2, {"a":1}]`, "", stats{2, 4, 6, 3, 2}},
+		{"objects with a numeric field", `[{"price":10},{"price":2.5},{"price":7.5}]`, "price", stats{2.5, 10, 20, 20.0 / 3, 3}},
+		{"nested field with gaps", `[{"m":{"ms":100}},{"m":{}},{"m":{"ms":"fast"}},{"m":{"ms":300}}]`, "m.ms", stats{100, 300, 400, 200, 2}},
+		{"no numbers", `[{"a":"x"}]`, "a", stats{}},
+		{"empty array", `[]`, "", stats{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, sum, mean, count, err := JSONArrayStats(tt.data, tt.field)
+			if err != nil {
+				t.Fatalf("JSONArrayStats() error = %v", err)
+			}
+			if got := (stats{min, max, sum, mean, count}); got != tt.want {
+				t.Errorf("JSONArrayStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONArrayStatsErrors(t *testing.T) {
+	if _, _, _, _, _, err := JSONArrayStats(`{"a":1}`, "a"); !errors.Is(err, ErrNotArray) {
+		t.Errorf("JSONArrayStats() on an object error = %v, want ErrNotArray", err)
+	}
+	if _, _, _, _, _, err := JSONArrayStats(`[1,`, ""); err == nil {
+		t.Error("JSONArrayStats() with invalid JSON returned no error")
+	}
+	if _, _, _, _, _, err := JSONArrayStats(`[1]`, "a["); err == nil {
+		t.Error("JSONArrayStats() with an invalid field path returned no error")
+	}
+}
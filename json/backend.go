@@ -0,0 +1,56 @@
+package json
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// Backend is a JSON engine. The helpers built on plain marshaling and unmarshaling (SafeMarshalJson,
+// MarshalJson, MustMarshalJson, MarshalJsonPooled, MarshalSize, UnmarshalTo, SafeUnmarshal and
+// UnmarshalOrDefault) go through the registered backend, so a faster engine can be swapped in without touching call sites. Helpers that
+// rely on encoding/json specifics such as json.Number or token streaming always use the standard library.
+//
+// Both github.com/bytedance/sonic (sonic.ConfigStd) and github.com/json-iterator/go
+// (jsoniter.ConfigCompatibleWithStandardLibrary) already satisfy this interface. Building with
+// -tags jsoniter registers the latter by default.
+type Backend interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// StdBackend is the default Backend, backed by encoding/json
+type StdBackend struct{}
+
+func (StdBackend) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (StdBackend) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+var backend atomic.Pointer[Backend]
+
+// SetBackend makes the package use b, e.g. SetBackend(sonic.ConfigStd) during initialization.
+// A nil b restores StdBackend. It is safe to call concurrently with encoding, though usually done once.
+func SetBackend(b Backend) {
+	if b == nil {
+		b = StdBackend{}
+	}
+	backend.Store(&b)
+}
+
+// CurrentBackend returns the Backend in use
+func CurrentBackend() Backend {
+	if b := backend.Load(); b != nil {
+		return *b
+	}
+	return StdBackend{}
+}
+
+// isStd reports whether b is the standard library backend, letting helpers keep their encoding/json
+// specific fast paths
+func isStd(b Backend) bool {
+	_, ok := b.(StdBackend)
+	return ok
+}
@@ -0,0 +1,13 @@
+//go:build jsoniter
+
+package json
+
+import jsoniter "github.com/json-iterator/go"
+
+// JsoniterBackend is github.com/json-iterator/go configured to match encoding/json's output, including
+// sorted map keys and HTML escaping
+var JsoniterBackend Backend = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func init() {
+	SetBackend(JsoniterBackend)
+}
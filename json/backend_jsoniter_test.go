@@ -0,0 +1,31 @@
+//go:build jsoniter
+
+package json
+
+import "testing"
+
+func init() {
+	benchmarkedBackends = append(benchmarkedBackends, struct {
+		name    string
+		backend Backend
+	}{"jsoniter", JsoniterBackend})
+}
+
+func TestJsoniterBackendMatchesStd(t *testing.T) {
+	values := []any{
+		samplePayload,
+		map[string]any{"b": 1, "a": []any{"<x>", nil, 1.5}},
+		"",
+		nil,
+	}
+	for _, v := range values {
+		want, err := StdBackend{}.Marshal(v)
+		if err != nil {
+			t.Fatalf("StdBackend.Marshal(%v) error = %v", v, err)
+		}
+		got, err := JsoniterBackend.Marshal(v)
+		if err != nil || string(got) != string(want) {
+			t.Errorf("JsoniterBackend.Marshal(%v) = %s, %v, want %s", v, got, err, want)
+		}
+	}
+}
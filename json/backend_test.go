@@ -0,0 +1,135 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// countingBackend wraps StdBackend and counts the calls routed through it
+type countingBackend struct {
+	marshals, unmarshals int
+}
+
+func (b *countingBackend) Marshal(v any) ([]byte, error) {
+	b.marshals++
+	return StdBackend{}.Marshal(v)
+}
+
+func (b *countingBackend) Unmarshal(data []byte, v any) error {
+	b.unmarshals++
+	return StdBackend{}.Unmarshal(data, v)
+}
+
+// failingBackend fails every call, to show errors from the backend reach the callers
+type failingBackend struct{}
+
+var errBackend = errors.New("backend failure")
+
+func (failingBackend) Marshal(any) ([]byte, error) { return nil, errBackend }
+func (failingBackend) Unmarshal([]byte, any) error { return errBackend }
+
+func TestSetBackendRoutesHelpers(t *testing.T) {
+	counting := &countingBackend{}
+	SetBackend(counting)
+	defer SetBackend(nil)
+
+	tests := []struct {
+		name                         string
+		call                         func()
+		wantMarshals, wantUnmarshals int
+	}{
+		{"SafeMarshalJson", func() { SafeMarshalJson(samplePayload) }, 1, 0},
+		{"MarshalJson", func() { _, _ = MarshalJson(samplePayload) }, 1, 0},
+		{"MustMarshalJson", func() { MustMarshalJson(samplePayload) }, 1, 0},
+		{"MarshalJsonPooled", func() { MarshalJsonPooled(samplePayload) }, 1, 0},
+		{"MarshalSize", func() { _, _ = MarshalSize(samplePayload) }, 1, 0},
+		{"UnmarshalTo", func() { _, _ = UnmarshalTo[benchmarkPayload]([]byte(`{"id":1}`)) }, 0, 1},
+		{"SafeUnmarshal", func() { SafeUnmarshal[benchmarkPayload](`{"id":1}`) }, 0, 1},
+		{"UnmarshalOrDefault", func() { UnmarshalOrDefault(`{"id":1}`, benchmarkPayload{}) }, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*counting = countingBackend{}
+			tt.call()
+			if counting.marshals != tt.wantMarshals || counting.unmarshals != tt.wantUnmarshals {
+				t.Errorf("%s made %d Marshal and %d Unmarshal calls, want %d and %d",
+					tt.name, counting.marshals, counting.unmarshals, tt.wantMarshals, tt.wantUnmarshals)
+			}
+		})
+	}
+}
+
+func TestSetBackendErrors(t *testing.T) {
+	SetBackend(failingBackend{})
+	defer SetBackend(nil)
+
+	if _, err := MarshalJson(1); !errors.Is(err, errBackend) {
+		t.Errorf("MarshalJson() error = %v, want the backend error", err)
+	}
+	if got := SafeMarshalJson(1); got != "" {
+		t.Errorf("SafeMarshalJson() = %q, want empty", got)
+	}
+	if _, err := UnmarshalTo[int]([]byte(`1`)); !errors.Is(err, errBackend) {
+		t.Errorf("UnmarshalTo() error = %v, want the backend error", err)
+	}
+	if got := UnmarshalOrDefault(`1`, 7); got != 7 {
+		t.Errorf("UnmarshalOrDefault() = %d, want the default 7", got)
+	}
+}
+
+func TestSetBackendNilRestoresStd(t *testing.T) {
+	SetBackend(failingBackend{})
+	SetBackend(nil)
+	if _, ok := CurrentBackend().(StdBackend); !ok {
+		t.Errorf("CurrentBackend() after SetBackend(nil) = %T, want StdBackend", CurrentBackend())
+	}
+	if got := SafeMarshalJson(map[string]int{"a": 1}); got != `{"a":1}` {
+		t.Errorf("SafeMarshalJson() = %s, want {\"a\":1}", got)
+	}
+}
+
+// benchmarkedBackends lists the engines compared by the backend benchmarks. Run them with -tags jsoniter
+// to add json-iterator, or add an entry such as {"sonic", sonic.ConfigStd} in a local checkout.
+var benchmarkedBackends = []struct {
+	name    string
+	backend Backend
+}{
+	{"std", StdBackend{}},
+}
+
+var samplePayloadJSON = []byte(`{"id":42,"name":"order <created>","tags":["a","b","c"],"attrs":{"region":"eu","tier":"gold"}}`)
+
+func BenchmarkBackendMarshal(b *testing.B) {
+	for _, bb := range benchmarkedBackends {
+		b.Run(bb.name, func(b *testing.B) {
+			SetBackend(bb.backend)
+			defer SetBackend(nil)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				SafeMarshalJson(samplePayload)
+			}
+		})
+	}
+}
+
+func BenchmarkBackendUnmarshal(b *testing.B) {
+	for _, bb := range benchmarkedBackends {
+		b.Run(bb.name, func(b *testing.B) {
+			SetBackend(bb.backend)
+			defer SetBackend(nil)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = UnmarshalTo[benchmarkPayload](samplePayloadJSON)
+			}
+		})
+	}
+}
+
+// BenchmarkEncodingJSONMarshal calls encoding/json directly, the baseline for the cost of backend dispatch
+func BenchmarkEncodingJSONMarshal(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = json.Marshal(samplePayload)
+	}
+}
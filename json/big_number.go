@@ -0,0 +1,127 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// BigNumber is an arbitrary-precision number that marshals to and from a plain JSON number
+// without passing through float64, so a 30 digit integer or a long decimal round-trips exactly.
+// Unmarshaling also accepts the number quoted as a string. The zero value is 0.
+type BigNumber struct {
+	rat big.Rat
+}
+
+// NewBigNumber parses a decimal string such as "123456789012345678901234567890" or "-0.000001"
+func NewBigNumber(s string) (BigNumber, error) {
+	var n BigNumber
+	if _, ok := n.rat.SetString(s); !ok || strings.Contains(s, "/") {
+		return BigNumber{}, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+// BigNumberFromInt wraps a big.Int
+func BigNumberFromInt(i *big.Int) BigNumber {
+	var n BigNumber
+	n.rat.SetInt(i)
+	return n
+}
+
+// BigNumberFromRat wraps a big.Rat; marshaling fails later if it has no finite decimal form (e.g. 1/3)
+func BigNumberFromRat(r *big.Rat) BigNumber {
+	var n BigNumber
+	n.rat.Set(r)
+	return n
+}
+
+// Rat returns a copy of the value as a big.Rat
+func (n BigNumber) Rat() *big.Rat {
+	return new(big.Rat).Set(&n.rat)
+}
+
+// Int returns the value as a big.Int and whether it is an integer
+func (n BigNumber) Int() (*big.Int, bool) {
+	if !n.rat.IsInt() {
+		return nil, false
+	}
+	return new(big.Int).Set(n.rat.Num()), true
+}
+
+// String returns the exact decimal form, or the fraction form for values without one
+func (n BigNumber) String() string {
+	if s, ok := n.decimalString(); ok {
+		return s
+	}
+	return n.rat.String()
+}
+
+func (n BigNumber) MarshalJSON() ([]byte, error) {
+	s, ok := n.decimalString()
+	if !ok {
+		return nil, fmt.Errorf("number %s has no finite decimal representation", n.rat.String())
+	}
+	return []byte(s), nil
+}
+
+func (n *BigNumber) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	literal := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &literal); err != nil {
+			return err
+		}
+	}
+	if !isJSONNumberLiteral(literal) {
+		return fmt.Errorf("invalid number %q", literal)
+	}
+	parsed, err := NewBigNumber(literal)
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
+// decimalString renders the value as a minimal exact decimal, if one exists
+func (n BigNumber) decimalString() (string, bool) {
+	if n.rat.IsInt() {
+		return n.rat.Num().String(), true
+	}
+	// a fraction has a finite decimal form iff its reduced denominator only has factors 2 and 5
+	denominator := new(big.Int).Set(n.rat.Denom())
+	two, five := big.NewInt(2), big.NewInt(5)
+	twos, fives := 0, 0
+	for new(big.Int).Rem(denominator, two).Sign() == 0 {
+		denominator.Quo(denominator, two)
+		twos++
+	}
+	for new(big.Int).Rem(denominator, five).Sign() == 0 {
+		denominator.Quo(denominator, five)
+		fives++
+	}
+	if denominator.Cmp(big.NewInt(1)) != 0 {
+		return "", false
+	}
+	return n.rat.FloatString(max(twos, fives)), true
+}
+
+// UnmarshalPreserveNumbers unmarshals data into v like json.Unmarshal, except that numbers decoded into
+// interface values become json.Number instead of float64 so no precision is lost
+func UnmarshalPreserveNumbers(data string, v any) error {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// isJSONNumberLiteral reports whether s is a valid JSON number
+func isJSONNumberLiteral(s string) bool {
+	var number json.Number
+	return s != "" && s[0] != '"' && json.Unmarshal([]byte(s), &number) == nil
+}
@@ -0,0 +1,82 @@
+package json
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestBigNumberRoundTrip(t *testing.T) {
+	type ledger struct {
+		Balance BigNumber `json:"balance"`
+	}
+	tests := []struct {
+		name, data, want string
+	}{
+		{"30 digit integer", `{"balance":123456789012345678901234567890}`, `{"balance":123456789012345678901234567890}`},
+		{"negative integer", `{"balance":-98765432109876543210987654321}`, `{"balance":-98765432109876543210987654321}`},
+		{"long decimal", `{"balance":12345678901234567890.123456789012345678}`, `{"balance":12345678901234567890.123456789012345678}`},
+		{"tiny decimal", `{"balance":-0.000000000000000000000001}`, `{"balance":-0.000000000000000000000001}`},
+		{"exponent", `{"balance":1.5e3}`, `{"balance":1500}`},
+		{"quoted", `{"balance":"0.1"}`, `{"balance":0.1}`},
+		{"null keeps zero", `{"balance":null}`, `{"balance":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l ledger
+			if err := json.Unmarshal([]byte(tt.data), &l); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			got, err := json.Marshal(l)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("round trip = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBigNumberErrors(t *testing.T) {
+	for _, data := range []string{`"abc"`, `"1/3"`, `true`, `"0x10"`} {
+		var n BigNumber
+		if err := json.Unmarshal([]byte(data), &n); err == nil {
+			t.Errorf("Unmarshal(%s) = %s, want error", data, n)
+		}
+	}
+	third := BigNumberFromRat(big.NewRat(1, 3))
+	if _, err := json.Marshal(third); err == nil {
+		t.Error("Marshal(1/3) returned no error")
+	}
+	if got := third.String(); got != "1/3" {
+		t.Errorf("String() = %q, want 1/3", got)
+	}
+}
+
+func TestBigNumberAccessors(t *testing.T) {
+	n, err := NewBigNumber("123456789012345678901234567890")
+	if err != nil {
+		t.Fatalf("NewBigNumber() error = %v", err)
+	}
+	i, ok := n.Int()
+	if !ok || i.String() != "123456789012345678901234567890" {
+		t.Errorf("Int() = %v, %v", i, ok)
+	}
+	if _, ok := BigNumberFromRat(big.NewRat(1, 4)).Int(); ok {
+		t.Error("Int() on 0.25 reported an integer")
+	}
+	if got := BigNumberFromInt(big.NewInt(-7)).String(); got != "-7" {
+		t.Errorf("BigNumberFromInt(-7).String() = %q", got)
+	}
+}
+
+func TestUnmarshalPreserveNumbers(t *testing.T) {
+	var got map[string]any
+	if err := UnmarshalPreserveNumbers(`{"id":123456789012345678901234567890,"rate":0.1000000000000000055511}`, &got); err != nil {
+		t.Fatalf("UnmarshalPreserveNumbers() error = %v", err)
+	}
+	if got["id"] != json.Number("123456789012345678901234567890") || got["rate"] != json.Number("0.1000000000000000055511") {
+		t.Errorf("UnmarshalPreserveNumbers() = %v", got)
+	}
+}
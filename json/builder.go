@@ -0,0 +1,53 @@
+package json
+
+// JSONBuilder assembles a JSON object field by field, keeping keys in insertion order, which suits
+// hand-built structured log lines. Setting a key again replaces its value in place.
+type JSONBuilder struct {
+	fields *OrderedMap
+}
+
+// NewJSONBuilder creates a builder for an empty object
+func NewJSONBuilder() *JSONBuilder {
+	return &JSONBuilder{fields: NewOrderedMap()}
+}
+
+// Str sets key to a string value
+func (b *JSONBuilder) Str(key, val string) *JSONBuilder {
+	b.fields.Set(key, val)
+	return b
+}
+
+// Int sets key to an integer value
+func (b *JSONBuilder) Int(key string, val int) *JSONBuilder {
+	b.fields.Set(key, val)
+	return b
+}
+
+// Float sets key to a floating-point value; NaN and infinities make Build fail
+func (b *JSONBuilder) Float(key string, val float64) *JSONBuilder {
+	b.fields.Set(key, val)
+	return b
+}
+
+// Bool sets key to a boolean value
+func (b *JSONBuilder) Bool(key string, val bool) *JSONBuilder {
+	b.fields.Set(key, val)
+	return b
+}
+
+// Obj sets key to a nested object whose fields are added by fn
+func (b *JSONBuilder) Obj(key string, fn func(*JSONBuilder)) *JSONBuilder {
+	child := NewJSONBuilder()
+	fn(child)
+	b.fields.Set(key, child.fields)
+	return b
+}
+
+// Build renders the object as compact JSON
+func (b *JSONBuilder) Build() (string, error) {
+	out, err := b.fields.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,61 @@
+package json
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJSONBuilder(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(*JSONBuilder)
+		want  string
+	}{
+		{"empty", func(*JSONBuilder) {}, `{}`},
+		{
+			"types and insertion order",
+			func(b *JSONBuilder) {
+				b.Str("msg", "started").Int("port", 8080).Float("load", 0.5).Bool("tls", true)
+			},
+			`{"msg":"started","port":8080,"load":0.5,"tls":true}`,
+		},
+		{
+			"nested objects",
+			func(b *JSONBuilder) {
+				b.Str("level", "info").Obj("http", func(h *JSONBuilder) {
+					h.Str("method", "GET").Obj("client", func(c *JSONBuilder) {
+						c.Str("ip", "10.0.0.1").Int("port", 5000)
+					}).Int("status", 200)
+				}).Bool("ok", true)
+			},
+			`{"level":"info","http":{"method":"GET","client":{"ip":"10.0.0.1","port":5000},"status":200},"ok":true}`,
+		},
+		{
+			"replacing a key keeps its position",
+			func(b *JSONBuilder) {
+				b.Str("a", "1").Str("b", "2").Int("a", 3)
+			},
+			`{"a":3,"b":"2"}`,
+		},
+		{"strings escaped", func(b *JSONBuilder) { b.Str("q", `say "hi"`) }, `{"q":"say \"hi\""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewJSONBuilder()
+			tt.build(builder)
+			got, err := builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Build() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONBuilderInvalidFloat(t *testing.T) {
+	if _, err := NewJSONBuilder().Float("x", math.NaN()).Build(); err == nil {
+		t.Error("Build() with a NaN field returned no error")
+	}
+}
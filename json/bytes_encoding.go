@@ -0,0 +1,56 @@
+package json
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// BytesEncoding selects the base64 variant MarshalBytesAs uses for []byte values
+type BytesEncoding int
+
+const (
+	// Standard is padded standard base64, what encoding/json uses
+	Standard BytesEncoding = iota
+	// URLSafe is padded URL and filename safe base64
+	URLSafe
+	// RawStd is unpadded standard base64
+	RawStd
+	// RawURL is unpadded URL and filename safe base64
+	RawURL
+)
+
+func (e BytesEncoding) encoding() (*base64.Encoding, error) {
+	switch e {
+	case Standard:
+		return base64.StdEncoding, nil
+	case URLSafe:
+		return base64.URLEncoding, nil
+	case RawStd:
+		return base64.RawStdEncoding, nil
+	case RawURL:
+		return base64.RawURLEncoding, nil
+	default:
+		return nil, fmt.Errorf("unknown bytes encoding %d", e)
+	}
+}
+
+// MarshalBytesAs marshals v like json.Marshal but renders every []byte value, at any depth, with
+// the chosen base64 variant. Types with their own MarshalJSON/MarshalText are left to encode themselves.
+func MarshalBytesAs(v any, enc BytesEncoding) (string, error) {
+	encoding, err := enc.encoding()
+	if err != nil {
+		return "", err
+	}
+	encoder := treeEncoder{encodeBytes: encoding.EncodeToString}
+	tree, err := encoder.encode(reflect.ValueOf(v))
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,46 @@
+package json
+
+import "testing"
+
+type bytesPayload struct {
+	Key    []byte            `json:"key"`
+	Nested map[string][]byte `json:"nested"`
+	Name   string            `json:"name"`
+}
+
+func TestMarshalBytesAs(t *testing.T) {
+	payload := bytesPayload{
+		Key:    []byte{0xfb, 0xff},
+		Nested: map[string][]byte{"k": {0xfb, 0xff}},
+		Name:   "n",
+	}
+	tests := []struct {
+		name    string
+		enc     BytesEncoding
+		encoded string
+	}{
+		{"standard", Standard, `+/8=`},
+		{"url safe", URLSafe, `-_8=`},
+		{"raw standard", RawStd, `+/8`},
+		{"raw url", RawURL, `-_8`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalBytesAs(payload, tt.enc)
+			if err != nil {
+				t.Fatalf("MarshalBytesAs() error = %v", err)
+			}
+			want := `{"key":"` + tt.encoded + `","nested":{"k":"` + tt.encoded + `"},"name":"n"}`
+			if got != want {
+				t.Errorf("MarshalBytesAs() = %s, want %s", got, want)
+			}
+		})
+	}
+
+	if got, _ := MarshalBytesAs(payload, Standard); got != SafeMarshalJson(payload) {
+		t.Errorf("MarshalBytesAs(Standard) = %s, want the encoding/json output %s", got, SafeMarshalJson(payload))
+	}
+	if _, err := MarshalBytesAs(payload, BytesEncoding(99)); err == nil {
+		t.Error("MarshalBytesAs() with an unknown encoding returned no error")
+	}
+}
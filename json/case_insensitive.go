@@ -0,0 +1,124 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UnmarshalCaseInsensitive lowercases every object key in data (recursively) before unmarshaling into v,
+// so "UserID", "userid" and "userId" all bind to the same struct field or map key.
+//
+// When an object contains two keys that differ only by case they collapse into the same key and,
+// as with duplicate keys in encoding/json, the one appearing last in the document wins.
+func UnmarshalCaseInsensitive(data string, v any) error {
+	normalized, err := rewriteObjectKeys([]byte(data), strings.ToLower)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(normalized, v)
+}
+
+// rewriteObjectKeys re-emits a JSON document with keyFn applied to every object key,
+// preserving key order and the original number representations
+func rewriteObjectKeys(data []byte, keyFn func(string) string) ([]byte, error) {
+	return rewriteJSON(data, keyFn, nil)
+}
+
+// rewriteJSON re-emits a compact JSON document, preserving key order, with keyFn applied to every
+// object key and numberFn to every number. Either function may be nil to leave those tokens as they are.
+func rewriteJSON(data []byte, keyFn func(string) string, numberFn func(json.Number) (string, error)) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+	if err := rewriteValue(decoder, &buf, keyFn, numberFn); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	return buf.Bytes(), nil
+}
+
+func rewriteValue(decoder *json.Decoder, buf *bytes.Buffer, keyFn func(string) string, numberFn func(json.Number) (string, error)) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			buf.WriteByte('{')
+			for i := 0; decoder.More(); i++ {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				key := keyToken.(string)
+				if keyFn != nil {
+					key = keyFn(key)
+				}
+				if err := writeJSONString(buf, key); err != nil {
+					return err
+				}
+				buf.WriteByte(':')
+				if err := rewriteValue(decoder, buf, keyFn, numberFn); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte('}')
+		case '[':
+			buf.WriteByte('[')
+			for i := 0; decoder.More(); i++ {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := rewriteValue(decoder, buf, keyFn, numberFn); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(']')
+		}
+		// consume the closing delimiter
+		_, err := decoder.Token()
+		return err
+	case string:
+		return writeJSONString(buf, t)
+	case json.Number:
+		if numberFn == nil {
+			buf.WriteString(t.String())
+			return nil
+		}
+		rewritten, err := numberFn(t)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(rewritten)
+	case bool:
+		if t {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case nil:
+		buf.WriteString("null")
+	}
+	return nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) error {
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
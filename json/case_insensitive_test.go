@@ -0,0 +1,60 @@
+package json
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalCaseInsensitive(t *testing.T) {
+	type address struct {
+		PostCode string `json:"postCode"`
+	}
+	type user struct {
+		UserID  string         `json:"userId"`
+		Name    string         `json:"name"`
+		Address address        `json:"address"`
+		Labels  map[string]int `json:"labels"`
+	}
+	want := user{UserID: "u1", Name: "Ada", Address: address{PostCode: "N1"}}
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"declared casing", `{"userId":"u1","name":"Ada","address":{"postCode":"N1"}}`},
+		{"upper", `{"USERID":"u1","NAME":"Ada","ADDRESS":{"POSTCODE":"N1"}}`},
+		{"pascal", `{"UserID":"u1","Name":"Ada","Address":{"PostCode":"N1"}}`},
+		{"lower", `{"userid":"u1","name":"Ada","address":{"postcode":"N1"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got user
+			if err := UnmarshalCaseInsensitive(tt.data, &got); err != nil {
+				t.Fatalf("UnmarshalCaseInsensitive() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("UnmarshalCaseInsensitive() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalCaseInsensitiveMapKeys(t *testing.T) {
+	var got map[string]any
+	if err := UnmarshalCaseInsensitive(`{"UserId":1,"Nested":[{"KeY":true}],"userid":2}`, &got); err != nil {
+		t.Fatalf("UnmarshalCaseInsensitive() error = %v", err)
+	}
+	// the two spellings of userid collapse and the last one wins
+	want := map[string]any{"userid": float64(2), "nested": []any{map[string]any{"key": true}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalCaseInsensitive() = %v, want %v", got, want)
+	}
+}
+
+func TestUnmarshalCaseInsensitiveInvalid(t *testing.T) {
+	var got map[string]any
+	for _, data := range []string{`{"a":`, `{"a":1} {}`, ``} {
+		if err := UnmarshalCaseInsensitive(data, &got); err == nil {
+			t.Errorf("UnmarshalCaseInsensitive(%q) returned no error", data)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package json
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalCoerce unmarshals data into v, first coercing stringly-typed values to the type of the
+// field they bind to. Supported coercions:
+//   - a string holding a number (e.g. "30", "-1.5", "1e3") into int, uint and float fields
+//   - a string accepted by strconv.ParseBool ("true", "false", "1", "0", "t", "f", ...) into bool fields
+//
+// Coercion applies recursively through nested structs, pointers, slices, arrays and map values.
+// Anything else, including numbers into string fields or unparseable strings, is left as-is and
+// fails exactly like json.Unmarshal would.
+func UnmarshalCoerce(data string, v any) error {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return err
+	}
+	target := reflect.TypeOf(v)
+	if target != nil && target.Kind() == reflect.Pointer {
+		root = coerceValue(root, target.Elem())
+	}
+	coerced, err := json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(coerced, v)
+}
+
+func coerceValue(value any, t reflect.Type) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		// the type decodes itself, don't second-guess it
+		return value
+	}
+
+	switch v := value.(type) {
+	case string:
+		return coerceString(v, t)
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := structFields(t)
+			for key, item := range v {
+				// fields tagged ",string" already expect their value quoted
+				if field, ok := lookupStructField(fields, key); ok && !field.quoted {
+					v[key] = coerceValue(item, field.typ)
+				}
+			}
+		case reflect.Map:
+			for key, item := range v {
+				v[key] = coerceValue(item, t.Elem())
+			}
+		}
+		return v
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, item := range v {
+				v[i] = coerceValue(item, t.Elem())
+			}
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func coerceString(s string, t reflect.Type) any {
+	trimmed := strings.TrimSpace(s)
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if isJSONNumberLiteral(trimmed) {
+			return json.Number(trimmed)
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(trimmed); err == nil {
+			return b
+		}
+	}
+	return s
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
@@ -0,0 +1,57 @@
+package json
+
+import "testing"
+
+type coercedAccount struct {
+	Age    int             `json:"age"`
+	Active bool            `json:"active"`
+	Score  float64         `json:"score"`
+	Name   string          `json:"name"`
+	Limits []uint          `json:"limits"`
+	Owner  *coercedAccount `json:"owner"`
+}
+
+func TestUnmarshalCoerce(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want coercedAccount
+	}{
+		{"strings into int and bool", `{"age":"30","active":"true"}`, coercedAccount{Age: 30, Active: true}},
+		{"native values unchanged", `{"age":30,"active":false,"name":"ann"}`, coercedAccount{Age: 30, Name: "ann"}},
+		{"float and padded strings", `{"score":" 1.5e1 ","active":"1"}`, coercedAccount{Score: 15, Active: true}},
+		{"string field keeps its value", `{"name":"42"}`, coercedAccount{Name: "42"}},
+		{"slices and nested structs", `{"limits":["1","2"],"owner":{"age":"7"}}`, coercedAccount{Limits: []uint{1, 2}, Owner: &coercedAccount{Age: 7}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got coercedAccount
+			if err := UnmarshalCoerce(tt.data, &got); err != nil {
+				t.Fatalf("UnmarshalCoerce() error = %v", err)
+			}
+			if SafeMarshalJson(got) != SafeMarshalJson(tt.want) {
+				t.Errorf("UnmarshalCoerce() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalCoerceFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"unparseable number", `{"age":"thirty"}`},
+		{"unparseable bool", `{"active":"yes"}`},
+		{"number into string", `{"name":42}`},
+		{"invalid json", `{"age":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got coercedAccount
+			if err := UnmarshalCoerce(tt.data, &got); err == nil {
+				t.Errorf("UnmarshalCoerce(%s) returned no error, got %+v", tt.data, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package json
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// GetJSONStrings collects every string value matched by path, where [*] expands over all array
+// elements, e.g. "items[*].name". Matches that aren't strings are skipped, and a path that matches
+// nothing yields an empty slice; only invalid JSON or an invalid path return an error.
+func GetJSONStrings(data, path string) ([]string, error) {
+	matches, err := collectAtPath(data, path)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if s, ok := match.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// GetJSONFloats collects every number matched by path as float64, with the same wildcard and
+// skipping rules as GetJSONStrings
+func GetJSONFloats(data, path string) ([]float64, error) {
+	matches, err := collectAtPath(data, path)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, 0, len(matches))
+	for _, match := range matches {
+		if n, ok := match.(json.Number); ok {
+			if f, err := strconv.ParseFloat(n.String(), 64); err == nil {
+				out = append(out, f)
+			}
+		}
+	}
+	return out, nil
+}
+
+func collectAtPath(data, path string) ([]any, error) {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return collectPath(root, segments), nil
+}
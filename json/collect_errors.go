@@ -0,0 +1,85 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// UnmarshalCollectErrors decodes data into v field by field and returns every decoding error
+// instead of stopping at the first one. Nested struct fields are decoded recursively so their
+// errors are reported with their dotted path. Fields that decode successfully are still populated.
+// It returns nil when the whole document bound cleanly.
+func UnmarshalCollectErrors(data string, v any) []error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return []error{fmt.Errorf("target must be a non-nil pointer, got %T", v)}
+	}
+	return collectFieldErrors(json.RawMessage(data), rv.Elem(), "")
+}
+
+func collectFieldErrors(raw json.RawMessage, target reflect.Value, path string) []error {
+	structValue := target
+	for structValue.Kind() == reflect.Pointer && !isJSONNull(raw) {
+		if structValue.IsNil() {
+			structValue.Set(reflect.New(structValue.Type().Elem()))
+		}
+		structValue = structValue.Elem()
+	}
+	if structValue.Kind() != reflect.Struct || isJSONNull(raw) {
+		if err := json.Unmarshal(raw, target.Addr().Interface()); err != nil {
+			return []error{wrapFieldError(path, err)}
+		}
+		return nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return []error{wrapFieldError(path, err)}
+	}
+
+	var errs []error
+	fields := structFields(structValue.Type())
+	for _, key := range sortedKeys(object) {
+		field, ok := lookupStructField(fields, key)
+		if !ok {
+			continue
+		}
+		fieldValue, err := fieldByIndexAlloc(structValue, field.index)
+		if err != nil {
+			errs = append(errs, wrapFieldError(joinPath(path, field.name), err))
+			continue
+		}
+		errs = append(errs, collectFieldErrors(object[key], fieldValue, joinPath(path, field.name))...)
+	}
+	return errs
+}
+
+func wrapFieldError(path string, err error) error {
+	if path == "" {
+		return err
+	}
+	return fmt.Errorf("field %q: %w", path, err)
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
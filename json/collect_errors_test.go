@@ -0,0 +1,92 @@
+package json
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalCollectErrors(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+		Zip  int    `json:"zip"`
+	}
+	type form struct {
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Active  bool     `json:"active"`
+		Tags    []string `json:"tags"`
+		Address *address `json:"address"`
+	}
+	tests := []struct {
+		name     string
+		data     string
+		wantErrs []string
+		want     form
+	}{
+		{
+			name: "clean",
+			data: `{"name":"Ada","age":36,"active":true,"tags":["x"],"address":{"city":"London","zip":1}}`,
+			want: form{Name: "Ada", Age: 36, Active: true, Tags: []string{"x"}, Address: &address{City: "London", Zip: 1}},
+		},
+		{
+			name:     "every mismatch reported",
+			data:     `{"name":"Ada","age":"old","active":"yes","tags":"x","address":{"city":7,"zip":"N1"}}`,
+			wantErrs: []string{`"active"`, `"address.city"`, `"address.zip"`, `"age"`, `"tags"`},
+			want:     form{Name: "Ada", Address: &address{}},
+		},
+		{
+			name:     "good fields still bind",
+			data:     `{"name":"Ada","age":1.5,"address":null}`,
+			wantErrs: []string{`"age"`},
+			want:     form{Name: "Ada"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			errs := UnmarshalCollectErrors(tt.data, &got)
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("UnmarshalCollectErrors() = %v, want %d errors", errs, len(tt.wantErrs))
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), tt.wantErrs[i]) {
+					t.Errorf("error %d = %q, want it to name %s", i, err, tt.wantErrs[i])
+				}
+			}
+			if got.Name != tt.want.Name || got.Age != tt.want.Age || got.Active != tt.want.Active ||
+				len(got.Tags) != len(tt.want.Tags) || (got.Address == nil) != (tt.want.Address == nil) {
+				t.Errorf("UnmarshalCollectErrors() bound %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalCollectErrorsInvalidTarget(t *testing.T) {
+	var notPointer struct{}
+	if errs := UnmarshalCollectErrors(`{}`, notPointer); len(errs) != 1 {
+		t.Errorf("UnmarshalCollectErrors(non-pointer) = %v, want one error", errs)
+	}
+	var target struct{ A int }
+	if errs := UnmarshalCollectErrors(`{"A":`, &target); len(errs) != 1 {
+		t.Errorf("UnmarshalCollectErrors(truncated) = %v, want one error", errs)
+	}
+}
+
+type collectBase struct {
+	ID int `json:"id"`
+}
+
+func TestUnmarshalCollectErrorsUnexportedEmbeddedPointer(t *testing.T) {
+	// encoding/json can't allocate *collectBase here either; the field is reported instead of panicking
+	var target struct {
+		*collectBase
+		Name string `json:"name"`
+	}
+	errs := UnmarshalCollectErrors(`{"id":1,"name":"x"}`, &target)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `field "id": cannot set embedded pointer`) {
+		t.Errorf("UnmarshalCollectErrors() = %v, want one error for id", errs)
+	}
+	if target.Name != "x" {
+		t.Errorf("Name = %q, want the other fields still populated", target.Name)
+	}
+}
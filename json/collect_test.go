@@ -0,0 +1,74 @@
+package json
+
+import (
+	"reflect"
+	"testing"
+)
+
+const collectOrder = `{
+	"items": [
+		{"name": "apple", "price": 1.5, "tags": ["fruit", "red"]},
+		{"name": "bread", "price": 3, "tags": ["bakery"]},
+		{"name": 7, "price": "free"},
+		{"price": 0.25, "tags": []}
+	],
+	"total": 4.75
+}`
+
+func TestGetJSONStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"wildcard over objects", "items[*].name", []string{"apple", "bread"}},
+		{"nested wildcards", "items[*].tags[*]", []string{"fruit", "red", "bakery"}},
+		{"single index", "items[1].name", []string{"bread"}},
+		{"non-strings skipped", "items[*].price", []string{"free"}},
+		{"no match", "items[*].missing", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetJSONStrings(collectOrder, tt.path)
+			if err != nil {
+				t.Fatalf("GetJSONStrings() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetJSONStrings(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetJSONFloats(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []float64
+	}{
+		{"wildcard over objects", "items[*].price", []float64{1.5, 3, 0.25}},
+		{"non-numbers skipped", "items[*].name", []float64{7}},
+		{"plain path", "total", []float64{4.75}},
+		{"wildcard over a non-array", "total[*]", []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetJSONFloats(collectOrder, tt.path)
+			if err != nil {
+				t.Fatalf("GetJSONFloats() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetJSONFloats(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetJSONStringsInvalidInput(t *testing.T) {
+	if _, err := GetJSONStrings(`{"items":`, "items[*]"); err == nil {
+		t.Error("GetJSONStrings() with invalid JSON returned no error")
+	}
+	if _, err := GetJSONFloats(collectOrder, "items[*"); err == nil {
+		t.Error("GetJSONFloats() with an invalid path returned no error")
+	}
+}
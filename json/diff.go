@@ -0,0 +1,126 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DiffOp is the kind of a Change
+type DiffOp string
+
+const (
+	DiffAdd     DiffOp = "add"
+	DiffRemove  DiffOp = "remove"
+	DiffReplace DiffOp = "replace"
+)
+
+// Change is one difference found by Diff. Path uses the dotted form accepted by the path helpers
+// ("" for the root); Old is unset for DiffAdd and New for DiffRemove. Numbers are json.Number.
+type Change struct {
+	Path string
+	Op   DiffOp
+	Old  any
+	New  any
+}
+
+// String renders the change on one line, e.g. `~ user.name: "Ann" -> "Anna"`, `+ tags[2]: "new"` or `- age: 41`
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "(root)"
+	}
+	switch c.Op {
+	case DiffAdd:
+		return fmt.Sprintf("+ %s: %s", path, renderDiffValue(c.New))
+	case DiffRemove:
+		return fmt.Sprintf("- %s: %s", path, renderDiffValue(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", path, renderDiffValue(c.Old), renderDiffValue(c.New))
+	}
+}
+
+// Diff compares two JSON documents structurally and lists what changed from a to b: objects are compared
+// key by key (in sorted order) and arrays index by index, so an insertion in the middle of an array shows
+// up as replacements followed by an add. Numbers are compared by value, so 1 and 1.0 are equal.
+// FormatDiff renders the result for audit logs and test failures. Like the package's other document
+// helpers it takes the documents as strings; pass string(b) for raw bytes.
+func Diff(a, b string) ([]Change, error) {
+	aValue, err := decodeJSONValue(a)
+	if err != nil {
+		return nil, fmt.Errorf("invalid first document: %w", err)
+	}
+	bValue, err := decodeJSONValue(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid second document: %w", err)
+	}
+	return diffValues("", aValue, bValue, nil), nil
+}
+
+// FormatDiff renders changes one per line, as by Change.String; no changes give an empty string
+func FormatDiff(changes []Change) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func diffValues(path string, a, b any, changes []Change) []Change {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool, len(av)+len(bv))
+		for key := range av {
+			keys[key] = true
+		}
+		for key := range bv {
+			keys[key] = true
+		}
+		for _, key := range sortedKeys(keys) {
+			aItem, inA := av[key]
+			bItem, inB := bv[key]
+			switch {
+			case !inB:
+				changes = append(changes, Change{Path: joinPath(path, key), Op: DiffRemove, Old: aItem})
+			case !inA:
+				changes = append(changes, Change{Path: joinPath(path, key), Op: DiffAdd, New: bItem})
+			default:
+				changes = diffValues(joinPath(path, key), aItem, bItem, changes)
+			}
+		}
+		return changes
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < max(len(av), len(bv)); i++ {
+			switch {
+			case i >= len(bv):
+				changes = append(changes, Change{Path: indexPath(path, i), Op: DiffRemove, Old: av[i]})
+			case i >= len(av):
+				changes = append(changes, Change{Path: indexPath(path, i), Op: DiffAdd, New: bv[i]})
+			default:
+				changes = diffValues(indexPath(path, i), av[i], bv[i], changes)
+			}
+		}
+		return changes
+	}
+	if !jsonValuesEqual(a, b) {
+		changes = append(changes, Change{Path: path, Op: DiffReplace, Old: a, New: b})
+	}
+	return changes
+}
+
+// renderDiffValue renders a decoded value as compact JSON
+func renderDiffValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
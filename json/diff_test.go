@@ -0,0 +1,60 @@
+package json
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name, a, b, want string
+	}{
+		{"identical", `{"a":1,"b":[1,2]}`, `{"b":[1,2],"a":1}`, ``},
+		{"number spelling ignored", `{"n":1}`, `{"n":1.0}`, ``},
+		{"replace", `{"user":{"name":"Ann"}}`, `{"user":{"name":"Anna"}}`, `~ user.name: "Ann" -> "Anna"`},
+		{"add and remove in key order", `{"age":41,"b":1}`, `{"b":1,"zip":"1000"}`, "- age: 41\n+ zip: \"1000\""},
+		{"array append", `{"tags":["a"]}`, `{"tags":["a","new"]}`, `+ tags[1]: "new"`},
+		{"array shrink", `[1,2,3]`, `[1]`, "- [1]: 2\n- [2]: 3"},
+		{"array insertion", `["a","c"]`, `["a","b","c"]`, "~ [1]: \"c\" -> \"b\"\n+ [2]: \"c\""},
+		{"type change", `{"a":{"b":1}}`, `{"a":[1]}`, `~ a: {"b":1} -> [1]`},
+		{"null to value", `{"a":null}`, `{"a":false}`, `~ a: null -> false`},
+		{"root replaced", `1`, `"x"`, `~ (root): 1 -> "x"`},
+		{"nested array of objects", `{"items":[{"id":1,"q":2}]}`, `{"items":[{"id":1,"q":3}]}`, `~ items[0].q: 2 -> 3`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if got := FormatDiff(changes); got != tt.want {
+				t.Errorf("FormatDiff(Diff(%s, %s)) =\n%s\nwant\n%s", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffChanges(t *testing.T) {
+	changes, err := Diff(`{"a":1,"b":{"c":true},"d":[1]}`, `{"a":2,"b":{},"d":[1,"x"]}`)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	want := []Change{
+		{Path: "a", Op: DiffReplace, Old: json.Number("1"), New: json.Number("2")},
+		{Path: "b.c", Op: DiffRemove, Old: true},
+		{Path: "d[1]", Op: DiffAdd, New: "x"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() = %#v, want %#v", changes, want)
+	}
+}
+
+func TestDiffInvalidJSON(t *testing.T) {
+	if _, err := Diff(`{`, `{}`); err == nil {
+		t.Error("Diff() with an invalid first document returned no error")
+	}
+	if _, err := Diff(`{}`, `[`); err == nil {
+		t.Error("Diff() with an invalid second document returned no error")
+	}
+}
@@ -0,0 +1,41 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrNotObject = errors.New("JSON value is not an object")
+
+// EnsureFields injects each value in fields whose dotted path is absent from the data object,
+// creating intermediate objects as needed. Keys that are already present, even with a null value,
+// are left untouched.
+func EnsureFields(data string, fields map[string]any) (string, error) {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := root.(map[string]any); !ok {
+		return "", ErrNotObject
+	}
+
+	for _, path := range sortedKeys(fields) {
+		segments, err := parsePath(path)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := lookupPath(root, segments); ok {
+			continue
+		}
+		if err := setPath(root, segments, fields[path]); err != nil {
+			return "", fmt.Errorf("path %q: %w", path, err)
+		}
+	}
+
+	out, err := json.Marshal(root)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
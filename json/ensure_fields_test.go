@@ -0,0 +1,45 @@
+package json
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEnsureFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		fields map[string]any
+		want   string
+	}{
+		{"absent injected", `{"id":1}`, map[string]any{"status": "new", "version": 1}, `{"id":1,"status":"new","version":1}`},
+		{"present preserved", `{"status":"done"}`, map[string]any{"status": "new"}, `{"status":"done"}`},
+		{"explicit null preserved", `{"status":null}`, map[string]any{"status": "new"}, `{"status":null}`},
+		{"intermediate objects created", `{}`, map[string]any{"meta.owner.name": "ops"}, `{"meta":{"owner":{"name":"ops"}}}`},
+		{"nested sibling kept", `{"meta":{"a":1}}`, map[string]any{"meta.b": 2, "meta.a": 3}, `{"meta":{"a":1,"b":2}}`},
+		{"nothing to do", `{"a":1}`, nil, `{"a":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EnsureFields(tt.data, tt.fields)
+			if err != nil {
+				t.Fatalf("EnsureFields() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EnsureFields() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureFieldsErrors(t *testing.T) {
+	if _, err := EnsureFields(`[1]`, map[string]any{"a": 1}); !errors.Is(err, ErrNotObject) {
+		t.Errorf("EnsureFields(array) error = %v, want ErrNotObject", err)
+	}
+	if _, err := EnsureFields(`{"a":`, nil); err == nil {
+		t.Error("EnsureFields(truncated) returned no error")
+	}
+	if _, err := EnsureFields(`{"a":"text"}`, map[string]any{"a.b": 1}); err == nil {
+		t.Error("EnsureFields() through a string returned no error")
+	}
+}
@@ -0,0 +1,61 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ResponseError is the error member of a response envelope. UnwrapJSONResponse returns it as an error.
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("response error %d: %s", e.Code, e.Message)
+}
+
+// responseEnvelope fixes the member order of the envelope as data, error, meta
+type responseEnvelope struct {
+	Data  any            `json:"data"`
+	Error *ResponseError `json:"error"`
+	Meta  any            `json:"meta"`
+}
+
+// WrapJSONResponse wraps a successful payload as {"data":...,"error":null,"meta":...}.
+// A nil meta is written as an empty object so the envelope always has the same shape.
+func WrapJSONResponse(data any, meta any) (string, error) {
+	return marshalEnvelope(responseEnvelope{Data: data, Meta: meta})
+}
+
+// WrapJSONError builds a failure envelope: {"data":null,"error":{"code":...,"message":...},"meta":{}}
+func WrapJSONError(code int, message string) (string, error) {
+	return marshalEnvelope(responseEnvelope{Error: &ResponseError{Code: code, Message: message}})
+}
+
+func marshalEnvelope(envelope responseEnvelope) (string, error) {
+	if envelope.Meta == nil {
+		envelope.Meta = struct{}{}
+	}
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// UnwrapJSONResponse returns the raw data member of an envelope produced by WrapJSONResponse. If the
+// envelope carries a non-null error, it is returned as a *ResponseError.
+func UnwrapJSONResponse(envelope string) (dataRaw json.RawMessage, err error) {
+	var decoded struct {
+		Data  json.RawMessage `json:"data"`
+		Error *ResponseError  `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(envelope), &decoded); err != nil {
+		return nil, fmt.Errorf("invalid response envelope: %w", err)
+	}
+	if decoded.Error != nil {
+		return nil, decoded.Error
+	}
+	return decoded.Data, nil
+}
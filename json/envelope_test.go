@@ -0,0 +1,79 @@
+package json
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrapJSONResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		data, meta any
+		want       string
+	}{
+		{"payload and meta", map[string]int{"id": 7}, map[string]int{"page": 2}, `{"data":{"id":7},"error":null,"meta":{"page":2}}`},
+		{"nil meta", []string{"a"}, nil, `{"data":["a"],"error":null,"meta":{}}`},
+		{"nil data", nil, nil, `{"data":null,"error":null,"meta":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WrapJSONResponse(tt.data, tt.meta)
+			if err != nil {
+				t.Fatalf("WrapJSONResponse() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("WrapJSONResponse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := WrapJSONResponse(make(chan int), nil); err == nil {
+		t.Error("WrapJSONResponse() with unmarshalable data returned no error")
+	}
+}
+
+func TestWrapJSONError(t *testing.T) {
+	got, err := WrapJSONError(404, "order not found")
+	if err != nil {
+		t.Fatalf("WrapJSONError() error = %v", err)
+	}
+	if want := `{"data":null,"error":{"code":404,"message":"order not found"},"meta":{}}`; got != want {
+		t.Errorf("WrapJSONError() = %s, want %s", got, want)
+	}
+
+	_, err = UnwrapJSONResponse(got)
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) || responseErr.Code != 404 || responseErr.Message != "order not found" {
+		t.Errorf("UnwrapJSONResponse() error = %v, want the 404 ResponseError", err)
+	}
+}
+
+func TestUnwrapJSONResponse(t *testing.T) {
+	envelope, _ := WrapJSONResponse(map[string]any{"id": 7, "tags": []string{"x"}}, map[string]int{"page": 1})
+	data, err := UnwrapJSONResponse(envelope)
+	if err != nil {
+		t.Fatalf("UnwrapJSONResponse() error = %v", err)
+	}
+	if want := `{"id":7,"tags":["x"]}`; string(data) != want {
+		t.Errorf("UnwrapJSONResponse() = %s, want %s", data, want)
+	}
+
+	tests := []struct {
+		name, envelope, want string
+	}{
+		{"null data", `{"data":null,"error":null,"meta":{}}`, `null`},
+		{"missing data", `{"meta":{}}`, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := UnwrapJSONResponse(tt.envelope)
+			if err != nil || string(data) != tt.want {
+				t.Errorf("UnwrapJSONResponse() = %q, %v, want %q", data, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := UnwrapJSONResponse(`{"data":`); err == nil {
+		t.Error("UnwrapJSONResponse() with invalid JSON returned no error")
+	}
+}
@@ -0,0 +1,33 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EscapeJSONString returns s as a quoted, escaped JSON string literal, ready to embed an
+// (often JSON) document as a string field inside another document. HTML characters are not escaped.
+func EscapeJSONString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	// encoding a string never fails
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// UnescapeJSONString is the inverse of EscapeJSONString: it takes a quoted JSON string literal
+// (including \uXXXX escapes) and returns the raw string.
+func UnescapeJSONString(s string) (string, error) {
+	trimmed := strings.TrimSpace(s)
+	if len(trimmed) < 2 || trimmed[0] != '"' || trimmed[len(trimmed)-1] != '"' {
+		return "", fmt.Errorf("not a quoted JSON string: %q", s)
+	}
+	var out string
+	if err := json.Unmarshal([]byte(trimmed), &out); err != nil {
+		return "", err
+	}
+	return out, nil
+}
@@ -0,0 +1,61 @@
+package json
+
+import "testing"
+
+func TestEscapeJSONString(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"plain", "hello", `"hello"`},
+		{"quotes", `say "hi"`, `"say \"hi\""`},
+		{"backslash", `C:\tmp`, `"C:\\tmp"`},
+		{"newline and tab", "a\nb\tc", `"a\nb\tc"`},
+		{"embedded document", `{"a":"b"}`, `"{\"a\":\"b\"}"`},
+		{"html left alone", "<a&b>", `"<a&b>"`},
+		{"control character", "\x01", `"\u0001"`},
+		{"unicode", "héllo ✓", `"héllo ✓"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EscapeJSONString(tt.input)
+			if got != tt.want {
+				t.Errorf("EscapeJSONString() = %s, want %s", got, tt.want)
+			}
+			back, err := UnescapeJSONString(got)
+			if err != nil {
+				t.Fatalf("UnescapeJSONString() error = %v", err)
+			}
+			if back != tt.input {
+				t.Errorf("round trip = %q, want %q", back, tt.input)
+			}
+		})
+	}
+}
+
+func TestUnescapeJSONString(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"unicode escape", `"caf\u00e9"`, "café"},
+		{"surrogate pair", `"\ud83d\ude00"`, "😀"},
+		{"escaped slash", `"a\/b"`, "a/b"},
+		{"whitespace around", ` "x" `, "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnescapeJSONString(tt.input)
+			if err != nil {
+				t.Fatalf("UnescapeJSONString() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("UnescapeJSONString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range []string{`unquoted`, `"open`, `"bad \x"`, `123`, `"a" "b"`} {
+		if got, err := UnescapeJSONString(invalid); err == nil {
+			t.Errorf("UnescapeJSONString(%s) = %q, want error", invalid, got)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errExtractDone stops the token walk early once every requested path has been captured
+var errExtractDone = errors.New("all fields extracted")
+
+// extractNode is a trie of requested path segments
+type extractNode struct {
+	path    string // the requested path ending at this node, if any
+	keys    map[string]*extractNode
+	indexes map[int]*extractNode
+}
+
+func (n *extractNode) hasChildren() bool {
+	return len(n.keys) > 0 || len(n.indexes) > 0
+}
+
+// ExtractFields returns the raw JSON of the values at the given dotted paths (e.g. "id", "meta.tenant",
+// "items[0].sku"), keyed by path. It walks the document's tokens and only keeps the requested values,
+// skipping everything else without building an object graph, and stops reading once every path has been
+// found, so it is much cheaper than a full unmarshal for large documents. Paths that don't exist are
+// missing from the result; wildcards are not supported. Because reading stops early, malformed content
+// after the last requested field is not reported.
+func ExtractFields(data string, paths ...string) (map[string]json.RawMessage, error) {
+	root := &extractNode{}
+	wanted := 0
+	for _, path := range paths {
+		segments, err := parsePath(path)
+		if err != nil {
+			return nil, err
+		}
+		node := root
+		for _, segment := range segments {
+			switch {
+			case segment.wildcard:
+				return nil, fmt.Errorf("invalid path %q: wildcards are not supported", path)
+			case segment.isIndex:
+				if node.indexes == nil {
+					node.indexes = map[int]*extractNode{}
+				}
+				if node.indexes[segment.index] == nil {
+					node.indexes[segment.index] = &extractNode{}
+				}
+				node = node.indexes[segment.index]
+			default:
+				if node.keys == nil {
+					node.keys = map[string]*extractNode{}
+				}
+				if node.keys[segment.key] == nil {
+					node.keys[segment.key] = &extractNode{}
+				}
+				node = node.keys[segment.key]
+			}
+		}
+		if node.path == "" {
+			node.path = path
+			wanted++
+		}
+	}
+
+	result := make(map[string]json.RawMessage, wanted)
+	if wanted == 0 {
+		return result, nil
+	}
+	e := &fieldExtractor{result: result, remaining: wanted}
+	if err := e.walk(json.NewDecoder(strings.NewReader(data)), root); err != nil && err != errExtractDone {
+		return nil, err
+	}
+	return result, nil
+}
+
+type fieldExtractor struct {
+	result    map[string]json.RawMessage
+	remaining int
+}
+
+// walk reads the next value from decoder, descending only into the members and elements node asks for
+func (e *fieldExtractor) walk(decoder *json.Decoder, node *extractNode) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			if err := e.visit(decoder, node.keys[keyToken.(string)]); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			if err := e.visit(decoder, node.indexes[i]); err != nil {
+				return err
+			}
+		}
+	}
+	// consume the closing delimiter
+	_, err = decoder.Token()
+	return err
+}
+
+// visit handles the next value, which belongs to child (nil for values nobody asked for)
+func (e *fieldExtractor) visit(decoder *json.Decoder, child *extractNode) error {
+	if child == nil {
+		var skipped json.RawMessage
+		return decoder.Decode(&skipped)
+	}
+	if child.path == "" {
+		return e.walk(decoder, child)
+	}
+
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+	if _, seen := e.result[child.path]; !seen {
+		e.remaining--
+	}
+	e.result[child.path] = raw
+	if child.hasChildren() {
+		// other requested paths lie inside this value
+		if err := e.walk(json.NewDecoder(strings.NewReader(string(raw))), child); err != nil {
+			return err
+		}
+	}
+	if e.remaining == 0 {
+		return errExtractDone
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractFields(t *testing.T) {
+	const data = `{"id":42,"meta":{"tenant":"acme","region":"eu"},"items":[{"sku":"x1"},{"sku":"y2","tags":["a"]}],"note":null,"flag":true}`
+	tests := []struct {
+		name  string
+		paths []string
+		want  map[string]string
+	}{
+		{"top-level fields", []string{"id", "flag"}, map[string]string{"id": `42`, "flag": `true`}},
+		{"dotted path", []string{"meta.tenant"}, map[string]string{"meta.tenant": `"acme"`}},
+		{"array index", []string{"items[1].sku", "items[1].tags[0]"}, map[string]string{"items[1].sku": `"y2"`, "items[1].tags[0]": `"a"`}},
+		{"whole object and a path inside it", []string{"meta", "meta.region"}, map[string]string{"meta": `{"tenant":"acme","region":"eu"}`, "meta.region": `"eu"`}},
+		{"null value", []string{"note"}, map[string]string{"note": `null`}},
+		{"missing paths omitted", []string{"id", "missing", "meta.zone", "items[5]"}, map[string]string{"id": `42`}},
+		{"no paths", nil, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := ExtractFields(data, tt.paths...)
+			if err != nil {
+				t.Fatalf("ExtractFields() error = %v", err)
+			}
+			got := make(map[string]string, len(raw))
+			for path, value := range raw {
+				got[path] = string(value)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractFields(%v) = %v, want %v", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractFieldsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		paths []string
+	}{
+		{"wildcard", `{"items":[]}`, []string{"items[*].sku"}},
+		{"invalid path", `{"a":1}`, []string{"a["}},
+		{"malformed before the field", `{"a":[1,,2],"b":1}`, []string{"b"}},
+		{"truncated document", `{"a":{"b":`, []string{"a.b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExtractFields(tt.data, tt.paths...); err == nil {
+				t.Errorf("ExtractFields(%s, %v) returned no error", tt.data, tt.paths)
+			}
+		})
+	}
+}
+
+// largeRoutingDocument has the routing fields up front followed by a large body
+func largeRoutingDocument() string {
+	var b strings.Builder
+	b.WriteString(`{"type":"order.created","meta":{"tenant":"acme"},"items":[`)
+	for i := 0; i < 2000; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"sku":"sku-%d","qty":%d,"price":%d.99,"attrs":{"color":"red","size":"m"}}`, i, i%7, i)
+	}
+	b.WriteString(`]}`)
+	return b.String()
+}
+
+func BenchmarkExtractFields(b *testing.B) {
+	data := largeRoutingDocument()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ExtractFields(data, "type", "meta.tenant"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExtractFieldsFullUnmarshal(b *testing.B) {
+	data := largeRoutingDocument()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var document map[string]any
+		if err := json.Unmarshal([]byte(data), &document); err != nil {
+			b.Fatal(err)
+		}
+		_ = document["type"]
+		_ = document["meta"].(map[string]any)["tenant"]
+	}
+}
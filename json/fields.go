@@ -0,0 +1,137 @@
+package json
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// structField describes how a struct field is named in JSON, following encoding/json's tag rules
+type structField struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	omitZero  bool // omitzero, the Go 1.24 encoding/json option, honored by MarshalWithOptions
+	quoted    bool
+	redact    bool   // redact:"true", honored by RedactedMarshal
+	mask      string // mask:"last4" etc., honored by RedactedMarshal
+	required  bool   // required:"true", enforced by StrictUnmarshal
+}
+
+// structFields lists the JSON-visible fields of struct type t, including fields promoted from
+// embedded structs. A field declared at a shallower depth hides a promoted field of the same name.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	seen := map[string]bool{}
+
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+	current := []level{{typ: t}}
+	for len(current) > 0 {
+		var next []level
+		for _, l := range current {
+			for i := 0; i < l.typ.NumField(); i++ {
+				field := l.typ.Field(i)
+				index := append(append([]int{}, l.index...), i)
+
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, options, _ := strings.Cut(tag, ",")
+
+				fieldType := field.Type
+				if field.Anonymous && name == "" {
+					if fieldType.Kind() == reflect.Pointer {
+						fieldType = fieldType.Elem()
+					}
+					if fieldType.Kind() == reflect.Struct {
+						next = append(next, level{typ: fieldType, index: index})
+						continue
+					}
+				}
+				if !field.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = field.Name
+				}
+				if seen[name] {
+					continue
+				}
+				seen[name] = true
+				fields = append(fields, structField{
+					name:      name,
+					index:     index,
+					typ:       field.Type,
+					omitEmpty: hasTagOption(options, "omitempty"),
+					omitZero:  hasTagOption(options, "omitzero"),
+					quoted:    hasTagOption(options, "string"),
+					redact:    field.Tag.Get("redact") == "true",
+					mask:      field.Tag.Get("mask"),
+					required:  field.Tag.Get("required") == "true",
+				})
+			}
+		}
+		current = next
+	}
+	// report fields in declaration order, as encoding/json does
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
+
+func hasTagOption(options, option string) bool {
+	for options != "" {
+		var current string
+		current, options, _ = strings.Cut(options, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupStructField finds the field for a JSON key, preferring an exact match over a case-insensitive one
+func lookupStructField(fields []structField, key string) (structField, bool) {
+	for _, field := range fields {
+		if field.name == key {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return field, true
+		}
+	}
+	return structField{}, false
+}
+
+// fieldByIndexAlloc returns the field at index, allocating nil embedded struct pointers on the way.
+// Like encoding/json, it fails rather than panics when such a pointer is to an unexported type.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct: %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
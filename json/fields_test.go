@@ -0,0 +1,130 @@
+package json
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fieldsBase struct {
+	ID      int    `json:"id"`
+	Created string `json:"created"`
+}
+
+type fieldsOther struct {
+	Note string
+}
+
+type fieldsDoc struct {
+	*fieldsBase
+	fieldsOther
+	Name     string `json:"name,omitempty"`
+	Created  string `json:"created_at"`
+	ID       string `json:"id,string"`
+	Secret   string `json:"secret" redact:"true"`
+	Card     string `json:"card" mask:"last4"`
+	Email    string `json:"email" required:"true"`
+	When     string `json:",omitzero"`
+	Skipped  string `json:"-"`
+	internal string
+}
+
+func TestStructFields(t *testing.T) {
+	got := structFields(reflect.TypeOf(fieldsDoc{}))
+	type summary struct {
+		name                        string
+		index                       []int
+		omitEmpty, omitZero, quoted bool
+		redact, required            bool
+		mask                        string
+	}
+	want := []summary{
+		{name: "created", index: []int{0, 1}},
+		{name: "Note", index: []int{1, 0}},
+		{name: "name", index: []int{2}, omitEmpty: true},
+		{name: "created_at", index: []int{3}},
+		{name: "id", index: []int{4}, quoted: true},
+		{name: "secret", index: []int{5}, redact: true},
+		{name: "card", index: []int{6}, mask: "last4"},
+		{name: "email", index: []int{7}, required: true},
+		{name: "When", index: []int{8}, omitZero: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("structFields() returned %d fields, want %d: %+v", len(got), len(want), got)
+	}
+	for i, field := range got {
+		s := summary{field.name, field.index, field.omitEmpty, field.omitZero, field.quoted, field.redact, field.required, field.mask}
+		if !reflect.DeepEqual(s, want[i]) {
+			t.Errorf("field %d = %+v, want %+v", i, s, want[i])
+		}
+	}
+}
+
+func TestHasTagOption(t *testing.T) {
+	tests := []struct {
+		options, option string
+		want            bool
+	}{
+		{"", "omitempty", false},
+		{"omitempty", "omitempty", true},
+		{"string,omitempty", "omitempty", true},
+		{"omitemptyx", "omitempty", false},
+		{"omitzero,string", "string", true},
+	}
+	for _, tt := range tests {
+		if got := hasTagOption(tt.options, tt.option); got != tt.want {
+			t.Errorf("hasTagOption(%q, %q) = %v, want %v", tt.options, tt.option, got, tt.want)
+		}
+	}
+}
+
+func TestLookupStructField(t *testing.T) {
+	fields := []structField{{name: "userID"}, {name: "UserId"}, {name: "name"}}
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"userID", "userID", true},
+		{"UserId", "UserId", true},
+		{"USERID", "userID", true},
+		{"Name", "name", true},
+		{"missing", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := lookupStructField(fields, tt.key)
+		if ok != tt.wantOK || got.name != tt.want {
+			t.Errorf("lookupStructField(%q) = %q, %v, want %q, %v", tt.key, got.name, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFieldByIndex(t *testing.T) {
+	type Embedded struct {
+		ID int
+	}
+	var doc struct {
+		*Embedded
+		*fieldsBase
+	}
+	v := reflect.ValueOf(&doc).Elem()
+	if _, ok := fieldByIndexNoAlloc(v, []int{0, 0}); ok || doc.Embedded != nil {
+		t.Error("fieldByIndexNoAlloc() went through or allocated a nil embedded pointer")
+	}
+
+	field, err := fieldByIndexAlloc(v, []int{0, 0})
+	if err != nil {
+		t.Fatalf("fieldByIndexAlloc() error = %v", err)
+	}
+	field.SetInt(7)
+	if doc.Embedded == nil || doc.Embedded.ID != 7 {
+		t.Errorf("fieldByIndexAlloc() did not allocate and set the promoted field: %+v", doc.Embedded)
+	}
+	if field, ok := fieldByIndexNoAlloc(v, []int{0, 0}); !ok || field.Int() != 7 {
+		t.Errorf("fieldByIndexNoAlloc() = %v, %v after allocation", field, ok)
+	}
+
+	// reflect can't allocate a pointer to an unexported type through an unexported embedded field
+	if _, err := fieldByIndexAlloc(v, []int{1, 0}); err == nil {
+		t.Error("fieldByIndexAlloc() through a nil pointer to an unexported struct returned no error")
+	}
+}
@@ -0,0 +1,169 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToMap converts a struct (or anything that marshals to a JSON object) into a map[string]any following
+// its JSON tags, so it can be edited or handed to attribute-map APIs. Nested objects become
+// map[string]any, arrays []any and numbers json.Number, which preserves 64-bit integers exactly.
+func ToMap(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeJSONValue(string(raw))
+	if err != nil {
+		return nil, err
+	}
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T marshals to JSON type %s", ErrNotObject, v, jsonTypeOf(value))
+	}
+	return m, nil
+}
+
+// FromMap converts m back into a T through its JSON tags, the inverse of ToMap
+func FromMap[T any](m map[string]any) (T, error) {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return UnmarshalTo[T](raw)
+}
+
+// Flatten turns a nested document into a single-level map keyed by the dotted paths the rest of the
+// package understands, e.g. {"user":{"tags":["a"]}} becomes {"user.tags[0]":"a"}. Keys that are empty
+// or contain '.' or '[' are written in the quoted form ["a.b"], so that no two values share a key and
+// Unflatten restores them exactly. Empty objects and arrays are kept as values for the same reason.
+func Flatten(m map[string]any) map[string]any {
+	flat := map[string]any{}
+	for key, value := range m {
+		flattenValue(keyPath("", key), value, flat)
+	}
+	return flat
+}
+
+func flattenValue(path string, value any, flat map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			flat[path] = v
+		}
+		for key, item := range v {
+			flattenValue(keyPath(path, key), item, flat)
+		}
+	case []any:
+		if len(v) == 0 {
+			flat[path] = v
+		}
+		for i, item := range v {
+			flattenValue(indexPath(path, i), item, flat)
+		}
+	default:
+		flat[path] = v
+	}
+}
+
+// keyPath appends key to path, quoting it as ["key"] when a plain key would be misread
+func keyPath(path, key string) string {
+	if key != "" && !strings.ContainsAny(key, ".[") {
+		return joinPath(path, key)
+	}
+	quoted, _ := json.Marshal(key) // marshaling a string can't fail
+	return path + "[" + string(quoted) + "]"
+}
+
+// maxUnflattenPadding is how many null array elements Unflatten may add for indexes missing from the
+// keys, so that a key like "a[100000000]" from an untrusted patch can't allocate a huge array
+const maxUnflattenPadding = 1024
+
+// Unflatten rebuilds the nested document from a map produced by Flatten. Array elements missing from
+// the keys are filled with null, up to a limit of 1024 such elements per call. Keys that conflict, such
+// as "a" and "a.b", return an error.
+func Unflatten(flat map[string]any) (map[string]any, error) {
+	keys := sortedKeys(flat)
+	paths := make([][]pathSegment, len(keys))
+	u := unflattener{slots: maxUnflattenPadding}
+	for i, key := range keys {
+		segments, err := parsePath(key)
+		if err != nil {
+			return nil, err
+		}
+		if segments[0].isIndex {
+			return nil, fmt.Errorf("key %q: the top level must be an object", key)
+		}
+		for _, segment := range segments {
+			if segment.isIndex {
+				u.slots++
+			}
+		}
+		paths[i] = segments
+	}
+
+	root := map[string]any{}
+	for i, key := range keys {
+		if _, err := u.insert(root, paths[i], flat[key]); err != nil {
+			return nil, fmt.Errorf("key %q: %w", key, err)
+		}
+	}
+	return root, nil
+}
+
+// unflattener tracks how many more array elements Unflatten may allocate: one for every index in the
+// keys, which is all a document from Flatten ever needs, plus maxUnflattenPadding
+type unflattener struct {
+	slots int
+}
+
+// insert stores value at segments below node, creating objects and arrays as needed,
+// and returns the possibly reallocated node
+func (u *unflattener) insert(node any, segments []pathSegment, value any) (any, error) {
+	if len(segments) == 0 {
+		if node != nil {
+			return nil, fmt.Errorf("conflicts with another key")
+		}
+		return value, nil
+	}
+	segment, rest := segments[0], segments[1:]
+	if segment.wildcard {
+		return nil, fmt.Errorf("wildcards can't be used to set a value")
+	}
+
+	if segment.isIndex {
+		array, ok := node.([]any)
+		if node != nil && !ok {
+			return nil, fmt.Errorf("conflicts with another key")
+		}
+		if grow := segment.index + 1 - len(array); grow > 0 {
+			if grow > u.slots {
+				return nil, fmt.Errorf("array index %d leaves too many elements missing", segment.index)
+			}
+			u.slots -= grow
+			array = append(array, make([]any, grow)...)
+		}
+		item, err := u.insert(array[segment.index], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		array[segment.index] = item
+		return array, nil
+	}
+
+	object, ok := node.(map[string]any)
+	if node != nil && !ok {
+		return nil, fmt.Errorf("conflicts with another key")
+	}
+	if object == nil {
+		object = map[string]any{}
+	}
+	item, err := u.insert(object[segment.key], rest, value)
+	if err != nil {
+		return nil, err
+	}
+	object[segment.key] = item
+	return object, nil
+}
@@ -0,0 +1,148 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type flattenUser struct {
+	ID      int64             `json:"id"`
+	Name    string            `json:"name"`
+	Email   string            `json:"email,omitempty"`
+	Tags    []string          `json:"tags"`
+	Profile map[string]string `json:"profile"`
+	secret  string
+}
+
+func TestToMap(t *testing.T) {
+	m, err := ToMap(flattenUser{ID: 9007199254740993, Name: "Ann", Tags: []string{"a"}, Profile: map[string]string{"tz": "UTC"}, secret: "x"})
+	if err != nil {
+		t.Fatalf("ToMap() error = %v", err)
+	}
+	want := map[string]any{
+		"id":      json.Number("9007199254740993"),
+		"name":    "Ann",
+		"tags":    []any{"a"},
+		"profile": map[string]any{"tz": "UTC"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("ToMap() = %#v, want %#v", m, want)
+	}
+
+	for _, v := range []any{[]int{1}, "text", nil} {
+		if _, err := ToMap(v); !errors.Is(err, ErrNotObject) {
+			t.Errorf("ToMap(%v) error = %v, want ErrNotObject", v, err)
+		}
+	}
+	if _, err := ToMap(map[string]any{"f": func() {}}); err == nil {
+		t.Error("ToMap() of an unmarshalable value returned no error")
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	user := flattenUser{ID: 9007199254740993, Name: "Ann", Email: "a@example.com", Tags: []string{"a", "b"}, Profile: map[string]string{"tz": "UTC"}}
+	m, err := ToMap(user)
+	if err != nil {
+		t.Fatalf("ToMap() error = %v", err)
+	}
+	m["name"] = "Anna"
+	got, err := FromMap[flattenUser](m)
+	if err != nil {
+		t.Fatalf("FromMap() error = %v", err)
+	}
+	user.Name = "Anna"
+	if !reflect.DeepEqual(got, user) {
+		t.Errorf("FromMap(ToMap()) = %+v, want %+v", got, user)
+	}
+
+	if _, err := FromMap[flattenUser](map[string]any{"id": "not a number"}); err == nil {
+		t.Error("FromMap() with a mistyped field returned no error")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  map[string]any
+		want map[string]any
+	}{
+		{"nested", map[string]any{"user": map[string]any{"name": "Ann", "tags": []any{"a", "b"}}},
+			map[string]any{"user.name": "Ann", "user.tags[0]": "a", "user.tags[1]": "b"}},
+		{"objects in arrays", map[string]any{"items": []any{map[string]any{"id": 1}, map[string]any{"id": 2}}},
+			map[string]any{"items[0].id": 1, "items[1].id": 2}},
+		{"nested arrays", map[string]any{"grid": []any{[]any{1, 2}, []any{3}}},
+			map[string]any{"grid[0][0]": 1, "grid[0][1]": 2, "grid[1][0]": 3}},
+		{"empty containers kept", map[string]any{"o": map[string]any{}, "l": []any{}},
+			map[string]any{"o": map[string]any{}, "l": []any{}}},
+		{"null kept", map[string]any{"a": nil}, map[string]any{"a": nil}},
+		{"empty", map[string]any{}, map[string]any{}},
+		{"dotted key next to nested key", map[string]any{"a.b": 1, "a": map[string]any{"b": 2}},
+			map[string]any{`["a.b"]`: 1, "a.b": 2}},
+		{"bracket in key", map[string]any{"x": map[string]any{"l[0]": 1}}, map[string]any{`x["l[0]"]`: 1}},
+		{"empty key", map[string]any{"": map[string]any{"": 1}}, map[string]any{`[""][""]`: 1}},
+		{"quote and backslash", map[string]any{`q"\.`: []any{true}}, map[string]any{`["q\"\\."][0]`: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Flatten(tt.doc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flatten() = %#v, want %#v", got, tt.want)
+			}
+			back, err := Unflatten(got)
+			if err != nil {
+				t.Fatalf("Unflatten() error = %v", err)
+			}
+			if !reflect.DeepEqual(back, tt.doc) {
+				t.Errorf("Unflatten(Flatten()) = %#v, want %#v", back, tt.doc)
+			}
+		})
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	tests := []struct {
+		name string
+		flat map[string]any
+		want map[string]any
+	}{
+		{"array gaps filled with null", map[string]any{"l[2]": "c", "l[0]": "a"}, map[string]any{"l": []any{"a", nil, "c"}}},
+		{"indexes sort past ten", map[string]any{"l[10]": 10, "l[2]": 2}, map[string]any{"l": []any{nil, nil, 2, nil, nil, nil, nil, nil, nil, nil, 10}}},
+		{"deep", map[string]any{"a.b.c": true}, map[string]any{"a": map[string]any{"b": map[string]any{"c": true}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unflatten(tt.flat)
+			if err != nil {
+				t.Fatalf("Unflatten() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unflatten() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnflattenErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		flat map[string]any
+	}{
+		{"scalar then object", map[string]any{"a": 1, "a.b": 2}},
+		{"object then index", map[string]any{"a.b": 1, "a[0]": 2}},
+		{"top-level index", map[string]any{"[0]": 1}},
+		{"wildcard", map[string]any{"a[*]": 1}},
+		{"bad path", map[string]any{"a[": 1}},
+		{"unterminated quoted key", map[string]any{`a["b`: 1}},
+		{"huge index", map[string]any{"a[100000000]": 1}},
+		{"padding spread over keys", map[string]any{"a[600]": 1, "b[600]": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Unflatten(tt.flat); err == nil {
+				t.Errorf("Unflatten(%v) = %v, want an error", tt.flat, got)
+			}
+		})
+	}
+}
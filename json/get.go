@@ -0,0 +1,135 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+var ErrTypeMismatch = errors.New("JSON value has an unexpected type")
+
+// Get returns the value at a dotted path such as "data.items[2].id", decoded as by json.Unmarshal into
+// an any except that numbers are json.Number, keeping their exact spelling. It returns ErrPathNotFound
+// if nothing exists at path; the typed Get* variants below avoid the type switch. Keys that are empty
+// or contain '.' or '[' are written quoted, as in `headers["content.type"]`.
+func Get(data, path string) (any, error) {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := lookupPath(root, segments)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrPathNotFound, path)
+	}
+	return value, nil
+}
+
+// Exists is HasKey under the name used by the Get family: it reports whether anything, even null, is at path
+func Exists(data, path string) bool {
+	return HasKey(data, path)
+}
+
+// GetString returns the string at path, or ErrTypeMismatch if the value isn't a string
+func GetString(data, path string) (string, error) {
+	value, err := Get(data, path)
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", typeMismatch(path, "string", value)
+	}
+	return s, nil
+}
+
+// GetInt64 returns the integer at path. Integral numbers written in float form (3.0, 1e3) are accepted;
+// other values, including numeric strings, return ErrTypeMismatch.
+func GetInt64(data, path string) (int64, error) {
+	value, err := Get(data, path)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, typeMismatch(path, "integer", value)
+	}
+	i, ok := parseIntegral(n.String())
+	if !ok {
+		return 0, fmt.Errorf("%w: %q is %s, not an int64", ErrTypeMismatch, path, n)
+	}
+	return i, nil
+}
+
+// GetBool returns the boolean at path, or ErrTypeMismatch if the value isn't a boolean
+func GetBool(data, path string) (bool, error) {
+	value, err := Get(data, path)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, typeMismatch(path, "boolean", value)
+	}
+	return b, nil
+}
+
+// GetTime returns the time at path, which may be an RFC 3339 string or a number of (possibly
+// fractional) Unix seconds, the two forms webhook payloads commonly use
+func GetTime(data, path string) (time.Time, error) {
+	value, err := Get(data, path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch v := value.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q is not an RFC 3339 time: %v", ErrTypeMismatch, path, err)
+		}
+		return t, nil
+	case json.Number:
+		t, ok := parseUnixSeconds(v.String())
+		if !ok {
+			return time.Time{}, fmt.Errorf("%w: %q is %s, not a Unix time", ErrTypeMismatch, path, v)
+		}
+		return t, nil
+	default:
+		return time.Time{}, typeMismatch(path, "time", value)
+	}
+}
+
+func typeMismatch(path, want string, value any) error {
+	return fmt.Errorf("%w: %q is %s, not %s", ErrTypeMismatch, path, jsonTypeOf(value), want)
+}
+
+// parseIntegral parses an integer, also accepting integral values in float form such as 3.0 or 1e3
+func parseIntegral(text string) (int64, bool) {
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return i, true
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil && f == math.Trunc(f) && math.Abs(f) < math.MaxInt64 {
+		return int64(f), true
+	}
+	return 0, false
+}
+
+// parseUnixSeconds parses a number of (possibly fractional) Unix seconds
+func parseUnixSeconds(text string) (time.Time, bool) {
+	seconds, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.Abs(seconds) > math.MaxInt64/float64(time.Second) {
+		return time.Time{}, false
+	}
+	if i, ok := parseIntegral(text); ok {
+		return time.Unix(i, 0), true
+	}
+	// scaling the whole value to nanoseconds would lose the fraction to float64 rounding at today's timestamps
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(math.Round(fraction*float64(time.Second)))), true
+}
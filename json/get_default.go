@@ -0,0 +1,82 @@
+package json
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// GetStringDefault returns the value at the dotted path as a string, or def if the JSON is invalid,
+// the path is missing, or the value is not a scalar. Numbers and booleans are rendered as text.
+func GetStringDefault(data, path, def string) string {
+	value, ok := valueAtPath(data, path)
+	if !ok {
+		return def
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return def
+	}
+}
+
+// GetIntDefault returns the value at the dotted path as an int64, or def if the JSON is invalid,
+// the path is missing, or the value is not an integer. Integral numbers and numeric strings are accepted.
+func GetIntDefault(data, path string, def int64) int64 {
+	value, ok := valueAtPath(data, path)
+	if !ok {
+		return def
+	}
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		text = v.String()
+	case string:
+		text = strings.TrimSpace(v)
+	default:
+		return def
+	}
+	if i, ok := parseIntegral(text); ok {
+		return i
+	}
+	return def
+}
+
+// GetBoolDefault returns the value at the dotted path as a bool, or def if the JSON is invalid,
+// the path is missing, or the value is not a boolean. The strings "true" and "false" are accepted.
+func GetBoolDefault(data, path string, def bool) bool {
+	value, ok := valueAtPath(data, path)
+	if !ok {
+		return def
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	}
+	return def
+}
+
+// valueAtPath decodes data and returns the value at path, reporting false on any failure
+func valueAtPath(data, path string) (any, bool) {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, false
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+	return lookupPath(root, segments)
+}
@@ -0,0 +1,68 @@
+package json
+
+import "testing"
+
+const defaultsDoc = `{"user":{"name":"Ada","age":36,"score":"42","height":1.7,"admin":true,"beta":"TRUE","tags":["x"]}}`
+
+func TestGetStringDefault(t *testing.T) {
+	tests := []struct {
+		name, data, path, want string
+	}{
+		{"present string", defaultsDoc, "user.name", "Ada"},
+		{"number rendered", defaultsDoc, "user.age", "36"},
+		{"bool rendered", defaultsDoc, "user.admin", "true"},
+		{"array element", defaultsDoc, "user.tags[0]", "x"},
+		{"wrong type", defaultsDoc, "user.tags", "def"},
+		{"missing", defaultsDoc, "user.email", "def"},
+		{"invalid json", `{`, "user.name", "def"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetStringDefault(tt.data, tt.path, "def"); got != tt.want {
+				t.Errorf("GetStringDefault(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetIntDefault(t *testing.T) {
+	tests := []struct {
+		name, data, path string
+		want             int64
+	}{
+		{"present int", defaultsDoc, "user.age", 36},
+		{"numeric string", defaultsDoc, "user.score", 42},
+		{"fraction", defaultsDoc, "user.height", -1},
+		{"wrong type", defaultsDoc, "user.admin", -1},
+		{"non-numeric string", defaultsDoc, "user.name", -1},
+		{"missing", defaultsDoc, "user.weight", -1},
+		{"integral float", `{"n":2e3}`, "n", 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetIntDefault(tt.data, tt.path, -1); got != tt.want {
+				t.Errorf("GetIntDefault(%q) = %d, want %d", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBoolDefault(t *testing.T) {
+	tests := []struct {
+		name, data, path string
+		def, want        bool
+	}{
+		{"present bool", defaultsDoc, "user.admin", false, true},
+		{"bool string", defaultsDoc, "user.beta", false, true},
+		{"wrong type", defaultsDoc, "user.age", true, true},
+		{"other string", defaultsDoc, "user.name", false, false},
+		{"missing", defaultsDoc, "user.active", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetBoolDefault(tt.data, tt.path, tt.def); got != tt.want {
+				t.Errorf("GetBoolDefault(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const webhookDoc = `{"data":{"items":[{"id":1},{"id":2},{"id":9007199254740993,"sku":"A-7"}],"paid":true,"total":12.50,
+	"count":3.0,"big":1e3,"created":"2023-11-14T22:13:20Z","at":1700000000,"at_ms":1700000000.25,"before":-2.5,"note":null}}`
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name, path string
+		want       any
+	}{
+		{"number keeps spelling", "data.total", json.Number("12.50")},
+		{"array index", "data.items[2].id", json.Number("9007199254740993")},
+		{"string", "data.items[2].sku", "A-7"},
+		{"bool", "data.paid", true},
+		{"null", "data.note", nil},
+		{"object", "data.items[0]", map[string]any{"id": json.Number("1")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Get(webhookDoc, tt.path)
+			if err != nil {
+				t.Fatalf("Get(%q) error = %v", tt.path, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get(%q) = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetErrors(t *testing.T) {
+	tests := []struct {
+		name, data, path string
+		want             error
+	}{
+		{"missing key", webhookDoc, "data.refund", ErrPathNotFound},
+		{"index out of range", webhookDoc, "data.items[3]", ErrPathNotFound},
+		{"index into object", webhookDoc, "data[0]", ErrPathNotFound},
+		{"key into array", webhookDoc, "data.items.id", ErrPathNotFound},
+		{"invalid json", `{"data":`, "data", nil},
+		{"invalid path", webhookDoc, "data.items[x", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Get(tt.data, tt.path)
+			if err == nil {
+				t.Fatalf("Get(%q) returned no error", tt.path)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Get(%q) error = %v, want %v", tt.path, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"data.items[1].id", true},
+		{"data.note", true},
+		{"data.items[5]", false},
+		{"data.missing", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := Exists(webhookDoc, tt.path); got != tt.want {
+				t.Errorf("Exists(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetString(t *testing.T) {
+	if got, err := GetString(webhookDoc, "data.items[2].sku"); err != nil || got != "A-7" {
+		t.Errorf("GetString() = %q, %v, want A-7", got, err)
+	}
+	for _, path := range []string{"data.paid", "data.total", "data.note"} {
+		if _, err := GetString(webhookDoc, path); !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("GetString(%q) error = %v, want ErrTypeMismatch", path, err)
+		}
+	}
+	if _, err := GetString(webhookDoc, "data.nope"); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("GetString(data.nope) error = %v, want ErrPathNotFound", err)
+	}
+}
+
+func TestGetInt64(t *testing.T) {
+	tests := []struct {
+		name, path string
+		want       int64
+		wantErr    error
+	}{
+		{"beyond float precision", "data.items[2].id", 9007199254740993, nil},
+		{"integral float form", "data.count", 3, nil},
+		{"exponent", "data.big", 1000, nil},
+		{"fraction", "data.total", 0, ErrTypeMismatch},
+		{"numeric string", "data.created", 0, ErrTypeMismatch},
+		{"bool", "data.paid", 0, ErrTypeMismatch},
+		{"missing", "data.nope", 0, ErrPathNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetInt64(webhookDoc, tt.path)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetInt64(%q) error = %v, want %v", tt.path, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("GetInt64(%q) = %d, %v, want %d", tt.path, got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := GetInt64(`{"n":1e30}`, "n"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("GetInt64(1e30) error = %v, want ErrTypeMismatch", err)
+	}
+}
+
+func TestGetBool(t *testing.T) {
+	if got, err := GetBool(webhookDoc, "data.paid"); err != nil || !got {
+		t.Errorf("GetBool(data.paid) = %v, %v, want true", got, err)
+	}
+	if _, err := GetBool(`{"flag":"true"}`, "flag"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("GetBool(\"true\") error = %v, want ErrTypeMismatch", err)
+	}
+}
+
+func TestGetTime(t *testing.T) {
+	tests := []struct {
+		name, path string
+		want       time.Time
+	}{
+		{"rfc 3339", "data.created", time.Unix(1700000000, 0)},
+		{"unix seconds", "data.at", time.Unix(1700000000, 0)},
+		{"fractional unix seconds", "data.at_ms", time.Unix(1700000000, 250_000_000)},
+		{"negative fraction", "data.before", time.Unix(-2, -500_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTime(webhookDoc, tt.path)
+			if err != nil {
+				t.Fatalf("GetTime(%q) error = %v", tt.path, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTime(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	for _, data := range []string{`{"t":"yesterday"}`, `{"t":true}`, `{"t":1e300}`} {
+		if _, err := GetTime(data, "t"); !errors.Is(err, ErrTypeMismatch) {
+			t.Errorf("GetTime(%s) error = %v, want ErrTypeMismatch", data, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package json
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
+// HashJSON returns the hex SHA-256 of data's canonical form, so documents that differ only in key
+// order, whitespace, number spelling (1.0 vs 1) or string escaping hash identically
+func HashJSON(data string) (string, error) {
+	canonical, err := canonicalJSON(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON renders data compactly with sorted object keys and numbers in the minimal form
+// produced by NormalizeJSONNumbers
+func canonicalJSON(data string) ([]byte, error) {
+	normalized, err := NormalizeJSONNumbers(data)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeJSONValue(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeOrderedJSON(&buf, value, sort.Strings); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
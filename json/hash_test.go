@@ -0,0 +1,64 @@
+package json
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestHashJSONEquivalentDocuments(t *testing.T) {
+	const original = `{"id":1,"name":"ann","tags":["a","b"],"meta":{"score":2.5,"active":true}}`
+	reference, err := HashJSON(original)
+	if err != nil {
+		t.Fatalf("HashJSON() error = %v", err)
+	}
+	sum := sha256.Sum256([]byte(`{"id":1,"meta":{"active":true,"score":2.5},"name":"ann","tags":["a","b"]}`))
+	if want := hex.EncodeToString(sum[:]); reference != want {
+		t.Errorf("HashJSON() = %s, want the SHA-256 of the canonical form %s", reference, want)
+	}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"reordered keys", `{"meta":{"active":true,"score":2.5},"tags":["a","b"],"name":"ann","id":1}`},
+		{"reformatted", "{\n  \"id\": 1,\n  \"name\": \"ann\",\n  \"tags\": [ \"a\", \"b\" ],\n  \"meta\": { \"score\": 2.5, \"active\": true }\n}\n"},
+		{"number spelling", `{"id":1.0,"name":"ann","tags":["a","b"],"meta":{"score":25e-1,"active":true}}`},
+		{"string escaping", `{"id":1,"name":"ann","tags":["a","b"],"meta":{"score":2.5,"active":true}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashJSON(tt.data)
+			if err != nil {
+				t.Fatalf("HashJSON() error = %v", err)
+			}
+			if got != reference {
+				t.Errorf("HashJSON() = %s, want %s", got, reference)
+			}
+		})
+	}
+}
+
+func TestHashJSONDifferentDocuments(t *testing.T) {
+	reference, _ := HashJSON(`{"id":1,"tags":["a","b"]}`)
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"changed value", `{"id":2,"tags":["a","b"]}`},
+		{"reordered array", `{"id":1,"tags":["b","a"]}`},
+		{"string instead of number", `{"id":"1","tags":["a","b"]}`},
+		{"extra key", `{"id":1,"tags":["a","b"],"x":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := HashJSON(tt.data); got == reference {
+				t.Errorf("HashJSON(%s) matches a different document", tt.data)
+			}
+		})
+	}
+
+	if _, err := HashJSON(`{"id":`); err == nil {
+		t.Error("HashJSON() with invalid JSON returned no error")
+	}
+}
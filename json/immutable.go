@@ -0,0 +1,68 @@
+package json
+
+import "encoding/json"
+
+// ImmutableJSON is a parsed JSON document that can only be read. Its decoded tree is owned privately
+// and never handed out, so it is safe to share across goroutines without locking.
+type ImmutableJSON struct {
+	root any
+}
+
+// ParseImmutable parses data into a read-only document view
+func ParseImmutable(data string) (*ImmutableJSON, error) {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return &ImmutableJSON{root: root}, nil
+}
+
+// Get returns the value at the dotted path ("" for the whole document) and whether it exists. Objects
+// and arrays are returned as fresh copies, so modifying the result doesn't affect the document; numbers
+// are json.Number.
+func (j *ImmutableJSON) Get(path string) (any, bool) {
+	value, ok := j.lookup(path)
+	if !ok {
+		return nil, false
+	}
+	return deepCopyJSON(value), true
+}
+
+// Has reports whether a value, even null, exists at the dotted path
+func (j *ImmutableJSON) Has(path string) bool {
+	_, ok := j.lookup(path)
+	return ok
+}
+
+// TypeAt returns the JSON type at the dotted path, as reported by JSONTypeAt, and whether it exists
+func (j *ImmutableJSON) TypeAt(path string) (string, bool) {
+	value, ok := j.lookup(path)
+	if !ok {
+		return "", false
+	}
+	return jsonTypeOf(value), true
+}
+
+// Raw returns the compact JSON encoding of the value at the dotted path and whether it exists
+func (j *ImmutableJSON) Raw(path string) (json.RawMessage, bool) {
+	value, ok := j.lookup(path)
+	if !ok {
+		return nil, false
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	return encoded, true
+}
+
+func (j *ImmutableJSON) lookup(path string) (any, bool) {
+	if path == "" {
+		return j.root, true
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+	return lookupPath(j.root, segments)
+}
@@ -0,0 +1,98 @@
+package json
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+const immutableSource = `{"user":{"name":"ann","roles":["admin","dev"]},"count":3,"note":null}`
+
+func TestImmutableJSONConcurrentGet(t *testing.T) {
+	doc, err := ParseImmutable(immutableSource)
+	if err != nil {
+		t.Fatalf("ParseImmutable() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				roles, ok := doc.Get("user.roles")
+				if !ok {
+					t.Error("Get(user.roles) reported a missing value")
+					return
+				}
+				// writers to a returned copy must not race with other readers
+				roles.([]any)[0] = "changed"
+				if name, _ := doc.Get("user.name"); name != "ann" {
+					t.Errorf("Get(user.name) = %v, want ann", name)
+					return
+				}
+				if count, _ := doc.Get("count"); count != json.Number("3") {
+					t.Errorf("Get(count) = %v, want 3", count)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if raw, _ := doc.Raw("user.roles"); string(raw) != `["admin","dev"]` {
+		t.Errorf("Raw(user.roles) = %s after modifying copies, want the original", raw)
+	}
+}
+
+func TestImmutableJSONIndependentOfSource(t *testing.T) {
+	buffer := []byte(immutableSource)
+	// a string sharing the buffer's memory, as a zero-copy caller might pass
+	data := unsafe.String(&buffer[0], len(buffer))
+	doc, err := ParseImmutable(data)
+	if err != nil {
+		t.Fatalf("ParseImmutable() error = %v", err)
+	}
+	for i := range buffer {
+		buffer[i] = ' '
+	}
+
+	want := `{"count":3,"note":null,"user":{"name":"ann","roles":["admin","dev"]}}`
+	if raw, _ := doc.Raw(""); string(raw) != want {
+		t.Errorf("Raw(\"\") = %s after the source changed, want %s", raw, want)
+	}
+}
+
+func TestImmutableJSONAccessors(t *testing.T) {
+	doc, err := ParseImmutable(immutableSource)
+	if err != nil {
+		t.Fatalf("ParseImmutable() error = %v", err)
+	}
+	tests := []struct {
+		path     string
+		has      bool
+		typeName string
+	}{
+		{"user", true, "object"},
+		{"user.roles[1]", true, "string"},
+		{"count", true, "number"},
+		{"note", true, "null"},
+		{"missing", false, ""},
+		{"user.roles[", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := doc.Has(tt.path); got != tt.has {
+				t.Errorf("Has(%q) = %v, want %v", tt.path, got, tt.has)
+			}
+			if got, _ := doc.TypeAt(tt.path); got != tt.typeName {
+				t.Errorf("TypeAt(%q) = %q, want %q", tt.path, got, tt.typeName)
+			}
+		})
+	}
+
+	if _, err := ParseImmutable(`{"a":`); err == nil {
+		t.Error("ParseImmutable() with invalid JSON returned no error")
+	}
+}
@@ -1,11 +1,107 @@
 package json
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
 
+// OnMarshalError, if non-nil, is called with the value and error whenever SafeMarshalJson or
+// MarshalJsonPooled fails and returns "", so the failure can at least be logged. Install it during
+// initialization; it may be called from any goroutine, so it must be concurrency safe.
+var OnMarshalError func(v any, err error)
+
+// reportMarshalError passes a swallowed marshal failure to OnMarshalError
+func reportMarshalError(v any, err error) {
+	if hook := OnMarshalError; hook != nil {
+		hook(v, err)
+	}
+}
+
+// SafeMarshalJson encodes v, returning "" on failure after reporting the error to OnMarshalError.
+// Prefer MarshalJson where the caller can handle the error.
 func SafeMarshalJson(v any) string {
-	jsonBytes, err := json.Marshal(v)
+	jsonBytes, err := CurrentBackend().Marshal(v)
 	if err != nil {
+		reportMarshalError(v, err)
 		return ""
 	}
 	return string(jsonBytes)
 }
+
+// MarshalJson encodes v like json.Marshal, returning the result as a string
+func MarshalJson(v any) (string, error) {
+	jsonBytes, err := CurrentBackend().Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
+
+// MustMarshalJson is MarshalJson for values that can't fail to encode, such as init-time configs.
+// It panics on error.
+func MustMarshalJson(v any) string {
+	s, err := MarshalJson(v)
+	if err != nil {
+		panic(fmt.Sprintf("json: MustMarshalJson(%T): %v", v, err))
+	}
+	return s
+}
+
+// maxPooledBufferSize keeps unusually large buffers from being retained by the pool
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// MarshalJsonPooled behaves like SafeMarshalJson but encodes into a pooled buffer to reduce allocations.
+// The pooled buffer only applies to StdBackend; any other registered backend is used as is.
+func MarshalJsonPooled(v any) string {
+	if !isStd(CurrentBackend()) {
+		return SafeMarshalJson(v)
+	}
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		reportMarshalError(v, err)
+		return ""
+	}
+	// Encoder terminates each value with a newline, which json.Marshal doesn't
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+}
+
+// countingWriter discards everything written to it, keeping only the byte count
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return len(p), nil
+}
+
+// MarshalSize returns the length in bytes of MarshalJson(v). With StdBackend the encoded output isn't
+// kept around; any other registered backend marshals v and measures the result.
+func MarshalSize(v any) (int, error) {
+	if b := CurrentBackend(); !isStd(b) {
+		jsonBytes, err := b.Marshal(v)
+		if err != nil {
+			return 0, err
+		}
+		return len(jsonBytes), nil
+	}
+	var w countingWriter
+	if err := json.NewEncoder(&w).Encode(v); err != nil {
+		return 0, err
+	}
+	// Encoder terminates each value with a newline, which json.Marshal doesn't
+	return w.n - 1, nil
+}
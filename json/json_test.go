@@ -0,0 +1,158 @@
+package json
+
+import "testing"
+
+type benchmarkPayload struct {
+	ID    int64             `json:"id"`
+	Name  string            `json:"name"`
+	Tags  []string          `json:"tags"`
+	Attrs map[string]string `json:"attrs"`
+}
+
+var samplePayload = benchmarkPayload{
+	ID:    42,
+	Name:  "order <created>",
+	Tags:  []string{"a", "b", "c"},
+	Attrs: map[string]string{"region": "eu", "tier": "gold"},
+}
+
+func TestMarshalJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    string
+		wantErr bool
+	}{
+		{"struct", samplePayload, `{"id":42,"name":"order \u003ccreated\u003e","tags":["a","b","c"],"attrs":{"region":"eu","tier":"gold"}}`, false},
+		{"nil", nil, `null`, false},
+		{"sorted map keys", map[string]int{"b": 2, "a": 1}, `{"a":1,"b":2}`, false},
+		{"channel", make(chan int), "", true},
+		{"function", func() {}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalJson(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarshalJson() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MarshalJson() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustMarshalJson(t *testing.T) {
+	if got := MustMarshalJson([]int{1, 2}); got != `[1,2]` {
+		t.Errorf("MustMarshalJson() = %s, want [1,2]", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustMarshalJson(chan) did not panic")
+		}
+	}()
+	MustMarshalJson(make(chan int))
+}
+
+func TestSafeMarshalJsonReportsErrors(t *testing.T) {
+	var reportedValue any
+	var reported error
+	OnMarshalError = func(v any, err error) { reportedValue, reported = v, err }
+	defer func() { OnMarshalError = nil }()
+
+	if got := SafeMarshalJson(map[string]int{"a": 1}); got != `{"a":1}` || reported != nil {
+		t.Errorf("SafeMarshalJson() = %s with reported error %v, want {\"a\":1} and no report", got, reported)
+	}
+	bad := map[string]any{"f": func() {}}
+	if got := SafeMarshalJson(bad); got != "" {
+		t.Errorf("SafeMarshalJson(func) = %q, want empty", got)
+	}
+	if reported == nil {
+		t.Fatal("SafeMarshalJson(func) did not report the error to OnMarshalError")
+	}
+	if m, ok := reportedValue.(map[string]any); !ok || len(m) != 1 {
+		t.Errorf("OnMarshalError got value %v, want the value that failed", reportedValue)
+	}
+
+	// without a handler the failure is still swallowed
+	OnMarshalError = nil
+	if got := SafeMarshalJson(bad); got != "" {
+		t.Errorf("SafeMarshalJson(func) without a handler = %q, want empty", got)
+	}
+}
+
+func TestMarshalJsonPooled(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"struct", samplePayload},
+		{"string with html", "<a & b>"},
+		{"nil", nil},
+		{"slice", []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := MarshalJsonPooled(tt.v), SafeMarshalJson(tt.v); got != want {
+				t.Errorf("MarshalJsonPooled() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestMarshalJsonPooledError(t *testing.T) {
+	var reported error
+	OnMarshalError = func(v any, err error) { reported = err }
+	defer func() { OnMarshalError = nil }()
+
+	if got := MarshalJsonPooled(make(chan int)); got != "" {
+		t.Errorf("MarshalJsonPooled(chan) = %q, want empty", got)
+	}
+	if reported == nil {
+		t.Error("MarshalJsonPooled(chan) did not report the error to OnMarshalError")
+	}
+}
+
+func BenchmarkSafeMarshalJson(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SafeMarshalJson(samplePayload)
+	}
+}
+
+func BenchmarkMarshalJsonPooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		MarshalJsonPooled(samplePayload)
+	}
+}
+
+func TestMarshalSize(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"struct", samplePayload},
+		{"html escaping counted", "<tag> & more"},
+		{"unicode", "héllo ✓"},
+		{"nil", nil},
+		{"number", 3.25},
+		{"nested", map[string]any{"a": []any{1, "two", map[string]any{"three": true}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalSize(tt.v)
+			if err != nil {
+				t.Fatalf("MarshalSize() error = %v", err)
+			}
+			if want := len(SafeMarshalJson(tt.v)); got != want {
+				t.Errorf("MarshalSize() = %d, want %d", got, want)
+			}
+		})
+	}
+
+	if _, err := MarshalSize(make(chan int)); err == nil {
+		t.Error("MarshalSize(chan) returned no error")
+	}
+}
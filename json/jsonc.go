@@ -0,0 +1,111 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrUnterminatedComment = errors.New("unterminated block comment")
+	ErrUnterminatedString  = errors.New("unterminated string literal")
+)
+
+// StripJSONComments removes // line comments and /* */ block comments from JSONC data.
+// Comment-like sequences inside string literals are left alone. Comments are replaced by
+// spaces (newlines are kept) so decoder error offsets still point at the original position.
+func StripJSONComments(data string) (string, error) {
+	out := []byte(data)
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '"':
+			end, err := skipJSONString(out, i)
+			if err != nil {
+				return "", err
+			}
+			i = end
+		case '/':
+			if i+1 >= len(out) {
+				continue
+			}
+			switch out[i+1] {
+			case '/':
+				for ; i < len(out) && out[i] != '\n'; i++ {
+					out[i] = ' '
+				}
+			case '*':
+				start := i
+				for i += 2; i+1 < len(out) && !(out[i] == '*' && out[i+1] == '/'); i++ {
+				}
+				if i+1 >= len(out) {
+					return "", ErrUnterminatedComment
+				}
+				blankOut(out[start : i+2])
+				i++
+			}
+		}
+	}
+	return string(out), nil
+}
+
+// StripTrailingCommas removes commas that directly precede a closing ] or } (ignoring whitespace),
+// leaving string literals untouched. Run it after StripJSONComments when input has comments.
+func StripTrailingCommas(data string) (string, error) {
+	out := []byte(data)
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '"':
+			end, err := skipJSONString(out, i)
+			if err != nil {
+				return "", err
+			}
+			i = end
+		case ',':
+			j := i + 1
+			for j < len(out) && isJSONWhitespace(out[j]) {
+				j++
+			}
+			if j < len(out) && (out[j] == ']' || out[j] == '}') {
+				out[i] = ' '
+			}
+		}
+	}
+	return string(out), nil
+}
+
+// UnmarshalJSONC strips comments and trailing commas from data and unmarshals the result into v
+func UnmarshalJSONC(data string, v any) error {
+	stripped, err := StripJSONComments(data)
+	if err != nil {
+		return err
+	}
+	stripped, err = StripTrailingCommas(stripped)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(stripped), v)
+}
+
+// skipJSONString returns the index of the closing quote of the string literal starting at start
+func skipJSONString(data []byte, start int) (int, error) {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i, nil
+		}
+	}
+	return 0, ErrUnterminatedString
+}
+
+func blankOut(b []byte) {
+	for i := range b {
+		if b[i] != '\n' && b[i] != '\r' {
+			b[i] = ' '
+		}
+	}
+}
+
+func isJSONWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
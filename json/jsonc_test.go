@@ -0,0 +1,80 @@
+package json
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStripJSONComments(t *testing.T) {
+	tests := []struct {
+		name, data, want string
+	}{
+		{"line comment", "{\"a\":1 // one\n}", "{\"a\":1       \n}"},
+		{"block comment", `{/* x */"a":1}`, `{       "a":1}`},
+		{"multi-line block keeps newlines", "[1,/*\nx\n*/2]", "[1,  \n \n  2]"},
+		{"slashes inside string", `{"url":"http://x/*y*/"}`, `{"url":"http://x/*y*/"}`},
+		{"escaped quote inside string", `{"a":"\"//"}// c`, `{"a":"\"//"}    `},
+		{"lone slash", `"a"/`, `"a"/`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StripJSONComments(tt.data)
+			if err != nil {
+				t.Fatalf("StripJSONComments() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("StripJSONComments() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripJSONCommentsErrors(t *testing.T) {
+	if _, err := StripJSONComments(`{"a":1 /* open`); !errors.Is(err, ErrUnterminatedComment) {
+		t.Errorf("StripJSONComments(open block) error = %v, want ErrUnterminatedComment", err)
+	}
+	if _, err := StripJSONComments(`{"a":"open`); !errors.Is(err, ErrUnterminatedString) {
+		t.Errorf("StripJSONComments(open string) error = %v, want ErrUnterminatedString", err)
+	}
+}
+
+func TestStripTrailingCommas(t *testing.T) {
+	tests := []struct {
+		name, data, want string
+	}{
+		{"array", `[1,2,]`, `[1,2 ]`},
+		{"object with whitespace", "{\"a\":1,\n}", "{\"a\":1 \n}"},
+		{"comma inside string", `{"a":",]"}`, `{"a":",]"}`},
+		{"no trailing comma", `[1,2]`, `[1,2]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StripTrailingCommas(tt.data)
+			if err != nil {
+				t.Fatalf("StripTrailingCommas() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("StripTrailingCommas() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONC(t *testing.T) {
+	const data = `{
+		// service settings
+		"name": "api /* not a comment */", /* inline */
+		"ports": [80, 443,],
+	}`
+	var got struct {
+		Name  string `json:"name"`
+		Ports []int  `json:"ports"`
+	}
+	if err := UnmarshalJSONC(data, &got); err != nil {
+		t.Fatalf("UnmarshalJSONC() error = %v", err)
+	}
+	if got.Name != "api /* not a comment */" || !reflect.DeepEqual(got.Ports, []int{80, 443}) {
+		t.Errorf("UnmarshalJSONC() = %+v", got)
+	}
+}
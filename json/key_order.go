@@ -0,0 +1,93 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// MarshalWithKeyOrder marshals v with every object's keys ordered by priority: keys named in
+// priority come first in that order, followed by the remaining keys alphabetically. The ordering
+// is applied recursively to nested objects.
+func MarshalWithKeyOrder(v any, priority []string) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	value, err := decodeJSONValue(string(raw))
+	if err != nil {
+		return "", err
+	}
+
+	rank := make(map[string]int, len(priority))
+	for i, key := range priority {
+		if _, ok := rank[key]; !ok {
+			rank[key] = i
+		}
+	}
+	orderKeys := func(keys []string) {
+		sort.Slice(keys, func(i, j int) bool {
+			ri, iPriority := rank[keys[i]]
+			rj, jPriority := rank[keys[j]]
+			switch {
+			case iPriority && jPriority:
+				return ri < rj
+			case iPriority != jPriority:
+				return iPriority
+			default:
+				return keys[i] < keys[j]
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := writeOrderedJSON(&buf, value, orderKeys); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeOrderedJSON encodes a decoded JSON value, emitting object keys in the order produced by orderKeys
+func writeOrderedJSON(buf *bytes.Buffer, value any, orderKeys func(keys []string)) error {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		orderKeys(keys)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONString(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeOrderedJSON(buf, v[key], orderKeys); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOrderedJSON(buf, item, orderKeys); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package json
+
+import "testing"
+
+func TestMarshalWithKeyOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        any
+		priority []string
+		want     string
+	}{
+		{
+			name:     "priority then alphabetical",
+			v:        map[string]any{"name": "a", "zone": 1, "id": 7, "created": "t"},
+			priority: []string{"id", "name"},
+			want:     `{"id":7,"name":"a","created":"t","zone":1}`,
+		},
+		{
+			name: "applied at each level",
+			v: map[string]any{
+				"b":     []any{map[string]any{"x": 1, "id": 2}},
+				"inner": map[string]any{"y": true, "type": "t", "id": "i"},
+				"id":    1,
+			},
+			priority: []string{"id", "type"},
+			want:     `{"id":1,"b":[{"id":2,"x":1}],"inner":{"id":"i","type":"t","y":true}}`,
+		},
+		{
+			name: "struct fields reordered",
+			v: struct {
+				Zeta string `json:"zeta"`
+				ID   int    `json:"id"`
+				Alfa string `json:"alfa"`
+			}{"z", 1, "a"},
+			priority: []string{"id"},
+			want:     `{"id":1,"alfa":"a","zeta":"z"}`,
+		},
+		{
+			name:     "missing priority keys are skipped",
+			v:        map[string]any{"b": 1, "a": 2},
+			priority: []string{"id", "b", "b"},
+			want:     `{"b":1,"a":2}`,
+		},
+		{"html escaped like json.Marshal", map[string]any{"b": "<", "a": 2.5}, nil, `{"a":2.5,"b":"\u003c"}`},
+		{"scalar", 12, []string{"id"}, `12`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalWithKeyOrder(tt.v, tt.priority)
+			if err != nil {
+				t.Fatalf("MarshalWithKeyOrder() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MarshalWithKeyOrder() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalWithKeyOrderError(t *testing.T) {
+	if _, err := MarshalWithKeyOrder(func() {}, nil); err == nil {
+		t.Error("MarshalWithKeyOrder(func) returned no error")
+	}
+}
@@ -0,0 +1,68 @@
+package json
+
+import "strings"
+
+// KeyPrefixOption customizes AddKeyPrefix and StripKeyPrefix
+type KeyPrefixOption func(*keyPrefixOptions)
+
+type keyPrefixOptions struct {
+	recursive bool
+}
+
+// RecursiveKeyPrefix applies the prefix change to the keys of nested objects too, including objects inside arrays
+func RecursiveKeyPrefix() KeyPrefixOption {
+	return func(o *keyPrefixOptions) {
+		o.recursive = true
+	}
+}
+
+// AddKeyPrefix prepends prefix to every top-level key of the data object, preserving key order
+func AddKeyPrefix(data, prefix string, opts ...KeyPrefixOption) (string, error) {
+	return renameJSONKeys(data, func(key string) string { return prefix + key }, opts)
+}
+
+// StripKeyPrefix removes prefix from every top-level key of the data object that starts with it; other
+// keys are left untouched. If stripping makes two keys equal, the later value wins at the earlier position.
+func StripKeyPrefix(data, prefix string, opts ...KeyPrefixOption) (string, error) {
+	return renameJSONKeys(data, func(key string) string { return strings.TrimPrefix(key, prefix) }, opts)
+}
+
+func renameJSONKeys(data string, rename func(string) string, opts []KeyPrefixOption) (string, error) {
+	var options keyPrefixOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	root, err := ParseOrderedJSON(data)
+	if err != nil {
+		return "", err
+	}
+	out, err := renameOrderedKeys(root, rename, options.recursive).MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func renameOrderedKeys(m *OrderedMap, rename func(string) string, recursive bool) *OrderedMap {
+	renamed := NewOrderedMap()
+	for _, key := range m.keys {
+		value := m.values[key]
+		if recursive {
+			value = renameNestedKeys(value, rename)
+		}
+		renamed.Set(rename(key), value)
+	}
+	return renamed
+}
+
+func renameNestedKeys(value any, rename func(string) string) any {
+	switch v := value.(type) {
+	case *OrderedMap:
+		return renameOrderedKeys(v, rename, true)
+	case []any:
+		for i, item := range v {
+			v[i] = renameNestedKeys(item, rename)
+		}
+	}
+	return value
+}
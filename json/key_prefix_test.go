@@ -0,0 +1,67 @@
+package json
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddKeyPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts []KeyPrefixOption
+		want string
+	}{
+		{"top-level keys only", `{"id":1,"user":{"name":"ann"}}`, nil, `{"ext_id":1,"ext_user":{"name":"ann"}}`},
+		{"recursive", `{"id":1,"user":{"name":"ann"},"tags":[{"k":"v"},2]}`, []KeyPrefixOption{RecursiveKeyPrefix()}, `{"ext_id":1,"ext_user":{"ext_name":"ann"},"ext_tags":[{"ext_k":"v"},2]}`},
+		{"key order kept", `{"z":1,"a":2}`, nil, `{"ext_z":1,"ext_a":2}`},
+		{"empty object", `{}`, nil, `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddKeyPrefix(tt.data, "ext_", tt.opts...)
+			if err != nil {
+				t.Fatalf("AddKeyPrefix() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AddKeyPrefix() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripKeyPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts []KeyPrefixOption
+		want string
+	}{
+		{"present prefix", `{"ext_id":1,"ext_name":"ann"}`, nil, `{"id":1,"name":"ann"}`},
+		{"absent prefix left untouched", `{"ext_id":1,"internal":true}`, nil, `{"id":1,"internal":true}`},
+		{"prefix only at the start", `{"id_ext_":1}`, nil, `{"id_ext_":1}`},
+		{"top-level only", `{"ext_user":{"ext_name":"ann"}}`, nil, `{"user":{"ext_name":"ann"}}`},
+		{"recursive", `{"ext_user":{"ext_name":"ann"},"ext_list":[{"ext_k":1}]}`, []KeyPrefixOption{RecursiveKeyPrefix()}, `{"user":{"name":"ann"},"list":[{"k":1}]}`},
+		{"collision keeps the later value", `{"id":1,"x":0,"ext_id":2}`, nil, `{"id":2,"x":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StripKeyPrefix(tt.data, "ext_", tt.opts...)
+			if err != nil {
+				t.Fatalf("StripKeyPrefix() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("StripKeyPrefix() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyPrefixErrors(t *testing.T) {
+	if _, err := AddKeyPrefix(`[1]`, "ext_"); !errors.Is(err, ErrNotObject) {
+		t.Errorf("AddKeyPrefix() on an array error = %v, want ErrNotObject", err)
+	}
+	if _, err := StripKeyPrefix(`{"a":`, "ext_"); err == nil {
+		t.Error("StripKeyPrefix() with invalid JSON returned no error")
+	}
+}
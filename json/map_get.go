@@ -0,0 +1,135 @@
+package json
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// MapGet returns the value at a dotted path such as "data.items[2].id" inside an already-decoded
+// payload, converted to T. Values that are already a T are returned as-is; beyond that numbers convert
+// between numeric types when no precision is lost (so the float64 3 from json.Unmarshal becomes an
+// int64 3, but 3.5 doesn't), json.Number works like any other number, and a time.Time can be read
+// from an RFC 3339 string or a number of Unix seconds. It returns false if nothing is at path or the
+// value can't be converted.
+func MapGet[T any](m map[string]any, path string) (T, bool) {
+	var zero T
+	segments, err := parsePath(path)
+	if err != nil {
+		return zero, false
+	}
+	value, ok := lookupPath(m, segments)
+	if !ok {
+		return zero, false
+	}
+	if v, ok := value.(T); ok {
+		return v, true
+	}
+	converted, ok := convertMapValue(value, any(zero))
+	if !ok {
+		return zero, false
+	}
+	return converted.(T), true
+}
+
+// MapGetOr is MapGet returning fallback when the value is missing or can't be converted
+func MapGetOr[T any](m map[string]any, path string, fallback T) T {
+	if v, ok := MapGet[T](m, path); ok {
+		return v
+	}
+	return fallback
+}
+
+// convertMapValue converts value to the type of target, returning a value of exactly that type
+func convertMapValue(value, target any) (any, bool) {
+	switch target.(type) {
+	case int:
+		i, ok := mapInt64(value)
+		return int(i), ok && i >= math.MinInt && i <= math.MaxInt
+	case int64:
+		return mapInt64(value)
+	case int32:
+		i, ok := mapInt64(value)
+		return int32(i), ok && i >= math.MinInt32 && i <= math.MaxInt32
+	case uint64:
+		i, ok := mapInt64(value)
+		return uint64(i), ok && i >= 0
+	case float64:
+		f, ok := mapFloat64(value)
+		return f, ok && holdsInteger(value, f)
+	case float32:
+		f, ok := mapFloat64(value)
+		return float32(f), ok && holdsInteger(value, float64(float32(f)))
+	case json.Number:
+		if f, ok := mapFloat64(value); ok {
+			if i, ok := mapInt64(value); ok {
+				return json.Number(strconv.FormatInt(i, 10)), true
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), true
+		}
+	case time.Time:
+		switch v := value.(type) {
+		case string:
+			t, err := time.Parse(time.RFC3339Nano, v)
+			return t, err == nil
+		case json.Number:
+			return parseUnixSeconds(v.String())
+		default:
+			if f, ok := mapFloat64(v); ok {
+				return parseUnixSeconds(strconv.FormatFloat(f, 'f', -1, 64))
+			}
+		}
+	}
+	return nil, false
+}
+
+// mapInt64 converts any integral number, including whole floats like 3.0, to an int64
+func mapInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case float64:
+		return int64(v), v == math.Trunc(v) && math.Abs(v) < math.MaxInt64
+	case float32:
+		return int64(v), float64(v) == math.Trunc(float64(v)) && math.Abs(float64(v)) < math.MaxInt64
+	case json.Number:
+		return parseIntegral(v.String())
+	}
+	return 0, false
+}
+
+// holdsInteger reports whether f still equals value when value is an integer, so converting
+// 9007199254740993 to a float64 is refused rather than rounded; fractions are approximate to begin with
+func holdsInteger(value any, f float64) bool {
+	i, ok := mapInt64(value)
+	return !ok || (f >= math.MinInt64 && f < math.MaxInt64 && int64(f) == i)
+}
+
+// mapFloat64 converts any number to a float64
+func mapFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,148 @@
+package json
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// mapGetPayload is shaped like the output of json.Unmarshal into a map[string]any, where every number is a float64
+var mapGetPayload = map[string]any{
+	"data": map[string]any{
+		"count":   float64(3),
+		"ratio":   0.5,
+		"big":     json.Number("9007199254740993"),
+		"neg":     float64(-7),
+		"name":    "order",
+		"paid":    true,
+		"created": "2023-11-14T22:13:20Z",
+		"at":      float64(1700000000),
+		"items":   []any{map[string]any{"id": float64(1)}, map[string]any{"id": float64(2)}},
+		"native":  int64(42),
+		"missing": nil,
+	},
+}
+
+func TestMapGetNumbers(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		tests := []struct {
+			path   string
+			want   int64
+			wantOK bool
+		}{
+			{"data.count", 3, true},
+			{"data.items[1].id", 2, true},
+			{"data.big", 9007199254740993, true},
+			{"data.native", 42, true},
+			{"data.ratio", 0, false},
+			{"data.name", 0, false},
+			{"data.missing", 0, false},
+			{"data.nope", 0, false},
+		}
+		for _, tt := range tests {
+			if got, ok := MapGet[int64](mapGetPayload, tt.path); got != tt.want || ok != tt.wantOK {
+				t.Errorf("MapGet[int64](%q) = %d, %v, want %d, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		}
+	})
+	t.Run("int and int32", func(t *testing.T) {
+		if got, ok := MapGet[int](mapGetPayload, "data.neg"); got != -7 || !ok {
+			t.Errorf("MapGet[int](data.neg) = %d, %v, want -7", got, ok)
+		}
+		if got, ok := MapGet[int32](mapGetPayload, "data.big"); got != 0 || ok {
+			t.Errorf("MapGet[int32](data.big) = %d, %v, want an overflow to fail", got, ok)
+		}
+		if got, ok := MapGet[uint64](mapGetPayload, "data.neg"); got != 0 || ok {
+			t.Errorf("MapGet[uint64](data.neg) = %d, %v, want a negative value to fail", got, ok)
+		}
+	})
+	t.Run("float64", func(t *testing.T) {
+		tests := []struct {
+			path   string
+			want   float64
+			wantOK bool
+		}{
+			{"data.ratio", 0.5, true},
+			{"data.native", 42, true},
+			{"data.count", 3, true},
+			{"data.big", 0, false}, // not representable as a float64
+			{"data.paid", 0, false},
+		}
+		for _, tt := range tests {
+			if got, ok := MapGet[float64](mapGetPayload, tt.path); got != tt.want || ok != tt.wantOK {
+				t.Errorf("MapGet[float64](%q) = %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		}
+		if got, ok := MapGet[float32](mapGetPayload, "data.ratio"); got != 0.5 || !ok {
+			t.Errorf("MapGet[float32](data.ratio) = %v, %v, want 0.5", got, ok)
+		}
+	})
+	t.Run("json.Number", func(t *testing.T) {
+		if got, ok := MapGet[json.Number](mapGetPayload, "data.count"); got != "3" || !ok {
+			t.Errorf("MapGet[json.Number](data.count) = %q, %v, want 3", got, ok)
+		}
+		if got, ok := MapGet[json.Number](mapGetPayload, "data.ratio"); got != "0.5" || !ok {
+			t.Errorf("MapGet[json.Number](data.ratio) = %q, %v, want 0.5", got, ok)
+		}
+	})
+}
+
+func TestMapGetValues(t *testing.T) {
+	if got, ok := MapGet[string](mapGetPayload, "data.name"); got != "order" || !ok {
+		t.Errorf("MapGet[string](data.name) = %q, %v, want order", got, ok)
+	}
+	if got, ok := MapGet[string](mapGetPayload, "data.count"); got != "" || ok {
+		t.Errorf("MapGet[string](data.count) = %q, %v, want no conversion", got, ok)
+	}
+	if got, ok := MapGet[bool](mapGetPayload, "data.paid"); !got || !ok {
+		t.Errorf("MapGet[bool](data.paid) = %v, %v, want true", got, ok)
+	}
+	if items, ok := MapGet[[]any](mapGetPayload, "data.items"); len(items) != 2 || !ok {
+		t.Errorf("MapGet[[]any](data.items) = %v, %v, want both items", items, ok)
+	}
+	if item, ok := MapGet[map[string]any](mapGetPayload, "data.items[0]"); item["id"] != float64(1) || !ok {
+		t.Errorf("MapGet[map[string]any](data.items[0]) = %v, %v", item, ok)
+	}
+	if _, ok := MapGet[any](mapGetPayload, "data.items[x"); ok {
+		t.Error("MapGet() with an invalid path reported ok")
+	}
+}
+
+func TestMapGetTime(t *testing.T) {
+	want := time.Unix(1700000000, 0)
+	tests := []struct {
+		name string
+		m    map[string]any
+	}{
+		{"rfc 3339", map[string]any{"t": "2023-11-14T22:13:20Z"}},
+		{"float seconds", map[string]any{"t": float64(1700000000)}},
+		{"json.Number seconds", map[string]any{"t": json.Number("1700000000")}},
+		{"int seconds", map[string]any{"t": int64(1700000000)}},
+		{"already a time", map[string]any{"t": want}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MapGet[time.Time](tt.m, "t")
+			if !ok || !got.Equal(want) {
+				t.Errorf("MapGet[time.Time]() = %v, %v, want %v", got, ok, want)
+			}
+		})
+	}
+	for _, bad := range []any{"14 Nov 2023", true, nil} {
+		if _, ok := MapGet[time.Time](map[string]any{"t": bad}, "t"); ok {
+			t.Errorf("MapGet[time.Time](%v) reported ok", bad)
+		}
+	}
+}
+
+func TestMapGetOr(t *testing.T) {
+	if got := MapGetOr(mapGetPayload, "data.count", int64(-1)); got != 3 {
+		t.Errorf("MapGetOr(data.count) = %d, want 3", got)
+	}
+	if got := MapGetOr(mapGetPayload, "data.ratio", int64(-1)); got != -1 {
+		t.Errorf("MapGetOr(data.ratio) = %d, want the fallback", got)
+	}
+	if got := MapGetOr(mapGetPayload, "data.nope", "none"); got != "none" {
+		t.Errorf("MapGetOr(data.nope) = %q, want the fallback", got)
+	}
+}
@@ -0,0 +1,118 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// MarshalOptions controls MarshalWithOptions. The zero value produces compact output with keys in
+// encoding/json's order and no HTML escaping.
+type MarshalOptions struct {
+	// EscapeHTML escapes <, > and & inside strings, as json.Marshal does, for embedding in HTML
+	EscapeHTML bool
+	// Indent, if non-empty, pretty-prints with one Indent per nesting level
+	Indent string
+	// SortKeys orders the keys of every object, including struct fields, alphabetically
+	SortKeys bool
+	// OmitZero leaves out struct fields holding their type's zero value, as if every field were tagged omitzero
+	// (zero-value semantics rather than omitempty's: an empty non-nil slice is kept, a zero struct is dropped,
+	// and types with an IsZero method such as time.Time decide for themselves). Fields tagged omitzero are
+	// left out by MarshalWithOptions whether or not this is set.
+	OmitZero bool
+}
+
+// MarshalWithOptions marshals v according to opts
+func MarshalWithOptions(v any, opts MarshalOptions) (string, error) {
+	encoder := treeEncoder{omitZero: opts.OmitZero}
+	value, err := encoder.encode(reflect.ValueOf(v))
+	if err != nil {
+		return "", err
+	}
+
+	out, err := encodeJSON(value, opts.EscapeHTML)
+	if err != nil {
+		return "", err
+	}
+	if opts.SortKeys {
+		// decoded objects are maps, which encoding/json writes with sorted keys
+		decoded, err := decodeJSONValue(string(out))
+		if err != nil {
+			return "", err
+		}
+		if out, err = encodeJSON(decoded, opts.EscapeHTML); err != nil {
+			return "", err
+		}
+	}
+	if opts.Indent != "" {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, out, "", opts.Indent); err != nil {
+			return "", err
+		}
+		out = buf.Bytes()
+	}
+	return string(out), nil
+}
+
+// MarshalPretty marshals v indented by indent per level, without HTML escaping
+func MarshalPretty(v any, indent string) (string, error) {
+	return MarshalWithOptions(v, MarshalOptions{Indent: indent})
+}
+
+// MarshalCanonical marshals v compactly with every object's keys sorted and no HTML escaping, so equal
+// values always produce identical bytes that can be hashed, signed or compared in tests
+func MarshalCanonical(v any) (string, error) {
+	return MarshalWithOptions(v, MarshalOptions{SortKeys: true})
+}
+
+// encodeJSON is json.Marshal with control over HTML escaping
+func encodeJSON(v any, escapeHTML bool) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(escapeHTML)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	// Encoder terminates each value with a newline, which json.Marshal doesn't
+	out := bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})
+	if !escapeHTML {
+		// SetEscapeHTML doesn't reach inside the output of MarshalJSON methods (including OrderedMap's)
+		out = unescapeHTML(out)
+	}
+	return out, nil
+}
+
+// unescapeHTML turns the \u003c, \u003e and \u0026 escapes encoding/json uses for <, > and & back
+// into the literal characters. Backslashes only occur inside strings in valid JSON, so walking the
+// escape sequences pairwise is enough to tell a real escape from an escaped backslash followed by "u003c".
+func unescapeHTML(data []byte) []byte {
+	if !bytes.Contains(data, []byte(`\u00`)) {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' || i+1 >= len(data) {
+			out = append(out, data[i])
+			continue
+		}
+		if i+5 < len(data) && data[i+1] == 'u' {
+			switch string(data[i+2 : i+6]) {
+			case "003c":
+				out = append(out, '<')
+				i += 5
+				continue
+			case "003e":
+				out = append(out, '>')
+				i += 5
+				continue
+			case "0026":
+				out = append(out, '&')
+				i += 5
+				continue
+			}
+		}
+		out = append(out, data[i], data[i+1])
+		i++
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package json
+
+import (
+	"testing"
+	"time"
+)
+
+type optionsPayload struct {
+	Zeta   string            `json:"zeta"`
+	Alpha  int               `json:"alpha"`
+	HTML   string            `json:"html"`
+	Tags   []string          `json:"tags"`
+	When   time.Time         `json:"when"`
+	Nested map[string]any    `json:"nested"`
+	Skip   string            `json:"skip,omitzero"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func TestMarshalWithOptions(t *testing.T) {
+	payload := optionsPayload{Zeta: "z", Alpha: 1, HTML: "<b>&</b>", Tags: []string{}, Nested: map[string]any{"b": 2, "a": 1}}
+	tests := []struct {
+		name string
+		opts MarshalOptions
+		want string
+	}{
+		{"zero options", MarshalOptions{},
+			`{"zeta":"z","alpha":1,"html":"<b>&</b>","tags":[],"when":"0001-01-01T00:00:00Z","nested":{"a":1,"b":2}}`},
+		{"escape html", MarshalOptions{EscapeHTML: true},
+			`{"zeta":"z","alpha":1,"html":"\u003cb\u003e\u0026\u003c/b\u003e","tags":[],"when":"0001-01-01T00:00:00Z","nested":{"a":1,"b":2}}`},
+		{"sort keys", MarshalOptions{SortKeys: true},
+			`{"alpha":1,"html":"<b>&</b>","nested":{"a":1,"b":2},"tags":[],"when":"0001-01-01T00:00:00Z","zeta":"z"}`},
+		{"omit zero", MarshalOptions{OmitZero: true},
+			`{"zeta":"z","alpha":1,"html":"<b>&</b>","tags":[],"nested":{"a":1,"b":2}}`},
+		{"indent", MarshalOptions{Indent: "  ", OmitZero: true, SortKeys: true},
+			"{\n  \"alpha\": 1,\n  \"html\": \"<b>&</b>\",\n  \"nested\": {\n    \"a\": 1,\n    \"b\": 2\n  },\n  \"tags\": [],\n  \"zeta\": \"z\"\n}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalWithOptions(payload, tt.opts)
+			if err != nil {
+				t.Fatalf("MarshalWithOptions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MarshalWithOptions(%+v) =\n%s\nwant\n%s", tt.opts, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := MarshalWithOptions(make(chan int), MarshalOptions{}); err == nil {
+		t.Error("MarshalWithOptions(chan) returned no error")
+	}
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	a := map[string]any{"b": []any{"<x>", 1.5}, "a": map[string]any{"z": true, "y": nil}}
+	b := struct {
+		A map[string]any `json:"a"`
+		B []any          `json:"b"`
+	}{A: map[string]any{"y": nil, "z": true}, B: []any{"<x>", 1.5}}
+
+	want := `{"a":{"y":null,"z":true},"b":["<x>",1.5]}`
+	for _, v := range []any{a, b} {
+		got, err := MarshalCanonical(v)
+		if err != nil {
+			t.Fatalf("MarshalCanonical() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("MarshalCanonical(%T) = %s, want %s", v, got, want)
+		}
+	}
+}
+
+func TestMarshalPretty(t *testing.T) {
+	got, err := MarshalPretty(map[string]any{"b": []int{1}, "a": "<&>"}, "\t")
+	if err != nil {
+		t.Fatalf("MarshalPretty() error = %v", err)
+	}
+	if want := "{\n\t\"a\": \"<&>\",\n\t\"b\": [\n\t\t1\n\t]\n}"; got != want {
+		t.Errorf("MarshalPretty() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnescapeHTML(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"no escapes", `{"a":"b"}`, `{"a":"b"}`},
+		{"html escapes", `"\u003cb\u003e \u0026"`, `"<b> &"`},
+		{"escaped backslash kept", `"\\u003c"`, `"\\u003c"`},
+		{"other unicode escapes kept", `"\u00e9\u0022"`, `"\u00e9\u0022"`},
+		{"trailing backslash", `\`, `\`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(unescapeHTML([]byte(tt.input))); got != tt.want {
+				t.Errorf("unescapeHTML(%s) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
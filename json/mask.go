@@ -0,0 +1,27 @@
+package json
+
+import "encoding/json"
+
+// MaskJSONPaths replaces the value at each dotted path with masker(value), leaving the rest of the
+// document intact. Paths may use [*] to mask a field in every element of an array, e.g. "users[*].email".
+// Paths that match nothing are skipped. masker receives decoded values (numbers as json.Number, objects
+// as map[string]any) and can return any JSON-marshalable value, so it can hash, truncate or redact.
+func MaskJSONPaths(data string, paths []string, masker func(any) any) (string, error) {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range paths {
+		segments, err := parsePath(path)
+		if err != nil {
+			return "", err
+		}
+		updatePath(root, segments, masker)
+	}
+
+	out, err := json.Marshal(root)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,72 @@
+package json
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+// hashMasker replaces a value with a short digest of its text, as an export might for PII
+func hashMasker(v any) any {
+	sum := sha256.Sum256([]byte(fmt.Sprint(v)))
+	return hex.EncodeToString(sum[:4])
+}
+
+func TestMaskJSONPaths(t *testing.T) {
+	const data = `{"account":{"owner":{"email":"ann@x.io","name":"Ann"},"plan":"pro"},` +
+		`"users":[{"email":"bob@x.io","id":1},{"email":"cy@x.io","id":2},{"id":3}],"phone":5551234}`
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{
+			"nested path",
+			[]string{"account.owner.email"},
+			`{"account":{"owner":{"email":"` + hashMasker("ann@x.io").(string) + `","name":"Ann"},"plan":"pro"},` +
+				`"phone":5551234,"users":[{"email":"bob@x.io","id":1},{"email":"cy@x.io","id":2},{"id":3}]}`,
+		},
+		{
+			"wildcard path",
+			[]string{"users[*].email"},
+			`{"account":{"owner":{"email":"ann@x.io","name":"Ann"},"plan":"pro"},"phone":5551234,` +
+				`"users":[{"email":"` + hashMasker("bob@x.io").(string) + `","id":1},{"email":"` + hashMasker("cy@x.io").(string) + `","id":2},{"id":3}]}`,
+		},
+		{
+			"number, indexed element and missing path",
+			[]string{"phone", "users[2].id", "account.billing.card"},
+			`{"account":{"owner":{"email":"ann@x.io","name":"Ann"},"plan":"pro"},"phone":"` + hashMasker("5551234").(string) + `",` +
+				`"users":[{"email":"bob@x.io","id":1},{"email":"cy@x.io","id":2},{"id":"` + hashMasker("3").(string) + `"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MaskJSONPaths(data, tt.paths, hashMasker)
+			if err != nil {
+				t.Fatalf("MaskJSONPaths() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MaskJSONPaths() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskJSONPathsWholeObject(t *testing.T) {
+	redact := func(any) any { return nil }
+	got, err := MaskJSONPaths(`{"card":{"number":"4111","cvv":"123"},"id":1}`, []string{"card"}, redact)
+	if err != nil {
+		t.Fatalf("MaskJSONPaths() error = %v", err)
+	}
+	if want := `{"card":null,"id":1}`; got != want {
+		t.Errorf("MaskJSONPaths() = %s, want %s", got, want)
+	}
+
+	if _, err := MaskJSONPaths(`{"a":`, []string{"a"}, redact); err == nil {
+		t.Error("MaskJSONPaths() with invalid JSON returned no error")
+	}
+	if _, err := MaskJSONPaths(`{"a":1}`, []string{"a["}, redact); err == nil {
+		t.Error("MaskJSONPaths() with an invalid path returned no error")
+	}
+}
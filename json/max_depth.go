@@ -0,0 +1,43 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrMaxDepthExceeded = errors.New("JSON nesting exceeds maximum depth")
+
+// UnmarshalMaxDepth unmarshals data into v like json.Unmarshal, but first scans the document and
+// rejects it with ErrMaxDepthExceeded if objects and arrays nest more than maxDepth levels deep.
+// A top-level scalar has depth 0 and {"a":[1]} has depth 2. Malformed JSON that passes the scan is
+// reported by json.Unmarshal as usual.
+func UnmarshalMaxDepth(data string, v any, maxDepth int) error {
+	if err := checkJSONDepth([]byte(data), maxDepth); err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(data), v)
+}
+
+// checkJSONDepth scans data without decoding it, tracking how deeply brackets nest outside strings
+func checkJSONDepth(data []byte, maxDepth int) error {
+	depth := 0
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			end, err := skipJSONString(data, i)
+			if err != nil {
+				return err
+			}
+			i = end
+		case '{', '[':
+			depth++
+			if depth > maxDepth {
+				return fmt.Errorf("%w: limit is %d at offset %d", ErrMaxDepthExceeded, maxDepth, i)
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package json
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// nestedArrays returns a document of depth levels of nested arrays around a number
+func nestedArrays(depth int) string {
+	return strings.Repeat("[", depth) + "1" + strings.Repeat("]", depth)
+}
+
+func TestUnmarshalMaxDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		maxDepth int
+		wantErr  bool
+	}{
+		{"scalar", `42`, 0, false},
+		{"at the limit", nestedArrays(5), 5, false},
+		{"over the limit", nestedArrays(6), 5, true},
+		{"moderately nested object", `{"user":{"roles":[{"name":"admin","scopes":["read","write"]}]}}`, 8, false},
+		{"object over the limit", `{"a":{"b":{"c":{}}}}`, 3, true},
+		{"brackets inside strings ignored", `{"s":"[[[[{{{{\"]]]]"}`, 1, false},
+		{"siblings don't add depth", `[[1],[2],[3],{"a":[4]}]`, 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v any
+			err := UnmarshalMaxDepth(tt.data, &v, tt.maxDepth)
+			if tt.wantErr {
+				if !errors.Is(err, ErrMaxDepthExceeded) {
+					t.Fatalf("UnmarshalMaxDepth() error = %v, want ErrMaxDepthExceeded", err)
+				}
+				if !strings.Contains(err.Error(), fmt.Sprintf("limit is %d", tt.maxDepth)) {
+					t.Errorf("UnmarshalMaxDepth() error = %q, want it to name the depth limit", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalMaxDepth() error = %v", err)
+			}
+			if v == nil {
+				t.Error("UnmarshalMaxDepth() did not bind the document")
+			}
+		})
+	}
+}
+
+func TestUnmarshalMaxDepthRejectsBeforeBinding(t *testing.T) {
+	var v struct{ A any }
+	err := UnmarshalMaxDepth(`{"A":`+nestedArrays(10000), &v, 64)
+	if !errors.Is(err, ErrMaxDepthExceeded) {
+		t.Errorf("UnmarshalMaxDepth() error = %v, want ErrMaxDepthExceeded", err)
+	}
+	if v.A != nil {
+		t.Errorf("UnmarshalMaxDepth() bound %v from a rejected document", v.A)
+	}
+	if err := UnmarshalMaxDepth(`{"A":[1`, &v, 64); err == nil || errors.Is(err, ErrMaxDepthExceeded) {
+		t.Errorf("UnmarshalMaxDepth() with malformed JSON error = %v, want a syntax error", err)
+	}
+}
@@ -0,0 +1,81 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MergeOption customizes Merge and MergePatch
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	appendArrays bool
+}
+
+// AppendArrays makes an array in the source extend an array at the same place in the destination,
+// instead of replacing it. Note that this goes beyond RFC 7396 when used with MergePatch.
+func AppendArrays() MergeOption {
+	return func(o *mergeOptions) {
+		o.appendArrays = true
+	}
+}
+
+// Merge deep-merges src into dst, e.g. to layer an environment config over a base one: objects merge
+// key by key recursively, and any other src value, including null, replaces the dst value. Arrays are
+// replaced unless AppendArrays is given. Keys only present in dst are kept. Like the package's other
+// document helpers it takes the documents as strings; pass string(b) for raw bytes.
+func Merge(dst, src string, opts ...MergeOption) (string, error) {
+	return mergeJSON(dst, src, opts, mergeValue)
+}
+
+// MergePatch applies src to dst as an RFC 7396 JSON merge patch, like ApplyMergePatch: null deletes
+// the key, objects merge recursively and other values replace the target. AppendArrays makes
+// arrays concatenate instead. Like the package's other document helpers it takes the documents as
+// strings; pass string(b) for raw bytes.
+func MergePatch(dst, src string, opts ...MergeOption) (string, error) {
+	return mergeJSON(dst, src, opts, func(dst, src any, options mergeOptions) any {
+		return mergePatchValue(dst, src, options.appendArrays)
+	})
+}
+
+func mergeJSON(dst, src string, opts []MergeOption, merge func(dst, src any, options mergeOptions) any) (string, error) {
+	var options mergeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	dstValue, err := decodeJSONValue(dst)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination: %w", err)
+	}
+	srcValue, err := decodeJSONValue(src)
+	if err != nil {
+		return "", fmt.Errorf("invalid source: %w", err)
+	}
+
+	out, err := json.Marshal(merge(dstValue, srcValue, options))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func mergeValue(dst, src any, options mergeOptions) any {
+	switch s := src.(type) {
+	case map[string]any:
+		d, ok := dst.(map[string]any)
+		if !ok {
+			d = map[string]any{}
+		}
+		for key, value := range s {
+			d[key] = mergeValue(d[key], value, options)
+		}
+		return d
+	case []any:
+		if d, ok := dst.([]any); ok && options.appendArrays {
+			return append(d, s...)
+		}
+		return s
+	default:
+		return src
+	}
+}
@@ -0,0 +1,105 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ApplyMergePatch applies an RFC 7396 JSON merge patch to target.
+// A null value in the patch deletes the key, objects merge recursively, and
+// arrays or scalars replace the target value wholesale. A patch that is not an
+// object replaces the whole target.
+func ApplyMergePatch(target, patch string) (string, error) {
+	targetValue, err := decodeJSONValue(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid target: %w", err)
+	}
+	patchValue, err := decodeJSONValue(patch)
+	if err != nil {
+		return "", fmt.Errorf("invalid patch: %w", err)
+	}
+
+	out, err := json.Marshal(mergePatchValue(targetValue, patchValue, false))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// mergePatchValue implements the MergePatch(Target, Patch) pseudocode from RFC 7396 section 2.
+// With appendArrays, an array in patch extends an array in target instead of replacing it.
+func mergePatchValue(target, patch any, appendArrays bool) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		if patchArray, ok := patch.([]any); ok && appendArrays {
+			if targetArray, ok := target.([]any); ok {
+				return append(targetArray, patchArray...)
+			}
+		}
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergePatchValue(targetObject[key], value, appendArrays)
+		}
+	}
+	return targetObject
+}
+
+// CreateMergePatch computes the minimal RFC 7396 merge patch that turns from into to: unchanged keys
+// are omitted, removed keys are set to null and added or changed keys carry their new value, recursing
+// into nested objects. Since merge patches can't express storing a null, null values inside to's
+// objects are treated as removals.
+func CreateMergePatch(from, to string) (string, error) {
+	fromValue, err := decodeJSONValue(from)
+	if err != nil {
+		return "", fmt.Errorf("invalid source document: %w", err)
+	}
+	toValue, err := decodeJSONValue(to)
+	if err != nil {
+		return "", fmt.Errorf("invalid target document: %w", err)
+	}
+
+	out, err := json.Marshal(diffMergePatch(fromValue, toValue))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func diffMergePatch(from, to any) any {
+	fromObject, fromIsObject := from.(map[string]any)
+	toObject, toIsObject := to.(map[string]any)
+	if !fromIsObject || !toIsObject {
+		return to
+	}
+
+	patch := map[string]any{}
+	for key := range fromObject {
+		if value, ok := toObject[key]; !ok || value == nil {
+			patch[key] = nil
+		}
+	}
+	for key, toItem := range toObject {
+		if toItem == nil {
+			continue
+		}
+		fromItem, ok := fromObject[key]
+		if !ok {
+			patch[key] = toItem
+			continue
+		}
+		if jsonValuesEqual(fromItem, toItem) {
+			continue
+		}
+		patch[key] = diffMergePatch(fromItem, toItem)
+	}
+	return patch
+}
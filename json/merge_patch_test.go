@@ -0,0 +1,80 @@
+package json
+
+import "testing"
+
+func TestApplyMergePatch(t *testing.T) {
+	// the test cases from RFC 7396 Appendix A
+	tests := []struct {
+		target, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target+" + "+tt.patch, func(t *testing.T) {
+			got, err := ApplyMergePatch(tt.target, tt.patch)
+			if err != nil {
+				t.Fatalf("ApplyMergePatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplyMergePatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyMergePatchInvalidJSON(t *testing.T) {
+	if _, err := ApplyMergePatch(`{`, `{}`); err == nil {
+		t.Error("ApplyMergePatch() with an invalid target returned no error")
+	}
+	if _, err := ApplyMergePatch(`{}`, `{`); err == nil {
+		t.Error("ApplyMergePatch() with an invalid patch returned no error")
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tests := []struct {
+		name, from, to, want string
+	}{
+		{"addition", `{"a":1}`, `{"a":1,"b":2}`, `{"b":2}`},
+		{"removal becomes null", `{"a":1,"b":2}`, `{"a":1}`, `{"b":null}`},
+		{"change", `{"a":1}`, `{"a":"x"}`, `{"a":"x"}`},
+		{"nested change", `{"a":{"b":1,"c":2}}`, `{"a":{"b":1,"c":3,"d":4}}`, `{"a":{"c":3,"d":4}}`},
+		{"nested removal", `{"a":{"b":1,"c":2}}`, `{"a":{"b":1}}`, `{"a":{"c":null}}`},
+		{"array replaced whole", `{"l":[1,2]}`, `{"l":[1,3]}`, `{"l":[1,3]}`},
+		{"no change", `{"a":{"b":[1]}}`, `{"a":{"b":[1]}}`, `{}`},
+		{"object to scalar", `{"a":{"b":1}}`, `{"a":5}`, `{"a":5}`},
+		{"non-object document", `[1]`, `{"a":1}`, `{"a":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := CreateMergePatch(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("CreateMergePatch() error = %v", err)
+			}
+			if patch != tt.want {
+				t.Errorf("CreateMergePatch() = %s, want %s", patch, tt.want)
+			}
+			applied, err := ApplyMergePatch(tt.from, patch)
+			if err != nil {
+				t.Fatalf("ApplyMergePatch() error = %v", err)
+			}
+			if applied != tt.to {
+				t.Errorf("ApplyMergePatch(from, patch) = %s, want %s", applied, tt.to)
+			}
+		})
+	}
+}
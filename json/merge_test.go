@@ -0,0 +1,90 @@
+package json
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name, dst, src, want string
+		opts                 []MergeOption
+	}{
+		{"layered config", `{"db":{"host":"localhost","port":5432},"debug":false}`, `{"db":{"host":"prod-db"},"debug":true}`,
+			`{"db":{"host":"prod-db","port":5432},"debug":true}`, nil},
+		{"new nested key", `{"a":1}`, `{"b":{"c":2}}`, `{"a":1,"b":{"c":2}}`, nil},
+		{"null replaces", `{"a":1,"b":2}`, `{"a":null}`, `{"a":null,"b":2}`, nil},
+		{"object replaces scalar", `{"a":1}`, `{"a":{"b":2}}`, `{"a":{"b":2}}`, nil},
+		{"scalar replaces object", `{"a":{"b":2}}`, `{"a":"x"}`, `{"a":"x"}`, nil},
+		{"arrays replaced", `{"l":[1,2]}`, `{"l":[3]}`, `{"l":[3]}`, nil},
+		{"arrays appended", `{"l":[1,2]}`, `{"l":[3]}`, `{"l":[1,2,3]}`, []MergeOption{AppendArrays()}},
+		{"append onto non-array", `{"l":"x"}`, `{"l":[3]}`, `{"l":[3]}`, []MergeOption{AppendArrays()}},
+		{"non-object source", `{"a":1}`, `[1]`, `[1]`, nil},
+		{"object into non-object", `[1]`, `{"a":1}`, `{"a":1}`, nil},
+		{"empty source", `{"a":1}`, `{}`, `{"a":1}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Merge(tt.dst, tt.src, tt.opts...)
+			if err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Merge(%s, %s) = %s, want %s", tt.dst, tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name, dst, src, want string
+		opts                 []MergeOption
+	}{
+		{"null deletes", `{"a":1,"b":2}`, `{"a":null}`, `{"b":2}`, nil},
+		{"nested null deletes", `{"a":{"b":1,"c":2}}`, `{"a":{"c":null}}`, `{"a":{"b":1}}`, nil},
+		{"nested merge", `{"a":{"b":1}}`, `{"a":{"c":2}}`, `{"a":{"b":1,"c":2}}`, nil},
+		{"arrays replaced", `{"l":[1,2]}`, `{"l":[3]}`, `{"l":[3]}`, nil},
+		{"arrays appended", `{"l":[1,2]}`, `{"l":[3]}`, `{"l":[1,2,3]}`, []MergeOption{AppendArrays()}},
+		{"deleting a missing key", `{"a":1}`, `{"z":null}`, `{"a":1}`, nil},
+		{"non-object patch replaces", `{"a":1}`, `"x"`, `"x"`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch(tt.dst, tt.src, tt.opts...)
+			if err != nil {
+				t.Fatalf("MergePatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MergePatch(%s, %s) = %s, want %s", tt.dst, tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatchMatchesApplyMergePatch(t *testing.T) {
+	docs := [][2]string{
+		{`{"a":"b"}`, `{"a":"c"}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`},
+	}
+	for _, doc := range docs {
+		got, err := MergePatch(doc[0], doc[1])
+		if err != nil {
+			t.Fatalf("MergePatch() error = %v", err)
+		}
+		want, _ := ApplyMergePatch(doc[0], doc[1])
+		if got != want {
+			t.Errorf("MergePatch(%s, %s) = %s, ApplyMergePatch() = %s", doc[0], doc[1], got, want)
+		}
+	}
+}
+
+func TestMergeInvalidJSON(t *testing.T) {
+	for _, merge := range []func(string, string, ...MergeOption) (string, error){Merge, MergePatch} {
+		if _, err := merge(`{`, `{}`); err == nil {
+			t.Error("merge with an invalid destination returned no error")
+		}
+		if _, err := merge(`{}`, `{"a":}`); err == nil {
+			t.Error("merge with an invalid source returned no error")
+		}
+	}
+}
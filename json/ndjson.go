@@ -0,0 +1,108 @@
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// NDJSONWriter writes values to an io.Writer as newline-delimited JSON, one marshaled value per line,
+// without buffering the whole export in memory. Wrap w in a bufio.Writer (and flush it when done) to
+// batch small writes.
+type NDJSONWriter struct {
+	encoder *json.Encoder
+	count   int
+}
+
+// NewNDJSONWriter creates an NDJSONWriter appending to w
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write marshals v and appends it as one line
+func (w *NDJSONWriter) Write(v any) error {
+	if err := w.encoder.Encode(v); err != nil {
+		return fmt.Errorf("record %d: %w", w.count, err)
+	}
+	w.count++
+	return nil
+}
+
+// Count returns the number of records written
+func (w *NDJSONWriter) Count() int {
+	return w.count
+}
+
+// NDJSONReader decodes newline-delimited JSON from an io.Reader one line at a time into values of type T.
+// Blank lines are skipped. Unlike DecodeNDJSON, each record must fit on a single line, which lets a
+// malformed line be reported and skipped instead of ending the stream.
+type NDJSONReader[T any] struct {
+	reader *bufio.Reader
+	line   int
+
+	// OnLineError, if non-nil, is called with the 1-based line number, the raw line and the decode error
+	// for each line that can't be decoded into a T. Returning nil skips the line; returning an error aborts
+	// reading with it. Without a callback, the first bad line aborts reading.
+	OnLineError func(line int, raw []byte, err error) error
+}
+
+// NewNDJSONReader creates an NDJSONReader reading from r
+func NewNDJSONReader[T any](r io.Reader) *NDJSONReader[T] {
+	return &NDJSONReader[T]{reader: bufio.NewReader(r)}
+}
+
+// Next decodes the next record, returning io.EOF at the end of the stream. ctx is checked before each
+// line is read; a read that is already blocked is not interrupted.
+func (r *NDJSONReader[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	for {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		raw, err := r.reader.ReadBytes('\n')
+		if len(raw) == 0 && err != nil {
+			return zero, err
+		}
+		r.line++
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			if err != nil {
+				return zero, err
+			}
+			continue
+		}
+
+		var record T
+		if decodeErr := json.Unmarshal(raw, &record); decodeErr != nil {
+			decodeErr = fmt.Errorf("line %d: %w", r.line, decodeErr)
+			if r.OnLineError == nil {
+				return zero, decodeErr
+			}
+			if cbErr := r.OnLineError(r.line, raw, decodeErr); cbErr != nil {
+				return zero, cbErr
+			}
+			continue
+		}
+		return record, nil
+	}
+}
+
+// Each calls fn for every remaining record, stopping at the end of the stream (returning nil),
+// when ctx is done, on an unhandled bad line or when fn returns an error
+func (r *NDJSONReader[T]) Each(ctx context.Context, fn func(T) error) error {
+	for {
+		record, err := r.Next(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package json
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ndjsonRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+	for _, v := range []any{ndjsonRecord{1, "a"}, map[string]int{"n": 2}, "three", nil} {
+		if err := writer.Write(v); err != nil {
+			t.Fatalf("Write(%v) error = %v", v, err)
+		}
+	}
+	if err := writer.Write(make(chan int)); err == nil || !strings.Contains(err.Error(), "record 4") {
+		t.Errorf("Write(chan) error = %v, want one naming record 4", err)
+	}
+
+	want := "{\"id\":1,\"name\":\"a\"}\n{\"n\":2}\n\"three\"\nnull\n"
+	if buf.String() != want {
+		t.Errorf("NDJSONWriter wrote %q, want %q", buf.String(), want)
+	}
+	if writer.Count() != 4 {
+		t.Errorf("Count() = %d, want 4", writer.Count())
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []ndjsonRecord
+	}{
+		{"lines", "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n", []ndjsonRecord{{1, "a"}, {2, "b"}}},
+		{"no trailing newline", "{\"id\":1}\n{\"id\":2}", []ndjsonRecord{{ID: 1}, {ID: 2}}},
+		{"blank lines and crlf", "\n{\"id\":1}\r\n   \n{\"id\":2}\r\n\n", []ndjsonRecord{{ID: 1}, {ID: 2}}},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewNDJSONReader[ndjsonRecord](strings.NewReader(tt.input))
+			var got []ndjsonRecord
+			if err := reader.Each(context.Background(), func(r ndjsonRecord) error {
+				got = append(got, r)
+				return nil
+			}); err != nil {
+				t.Fatalf("Each() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Each() read %+v, want %+v", got, tt.want)
+			}
+			if _, err := reader.Next(context.Background()); err != io.EOF {
+				t.Errorf("Next() after the end error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestNDJSONReaderLineErrors(t *testing.T) {
+	const input = "{\"id\":1}\n{\"id\":\n\n{\"id\":\"x\"}\n{\"id\":4}\n"
+
+	reader := NewNDJSONReader[ndjsonRecord](strings.NewReader(input))
+	if _, err := reader.Next(context.Background()); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := reader.Next(context.Background()); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Next() on a bad line without OnLineError error = %v, want one naming line 2", err)
+	}
+
+	var badLines []int
+	var raws []string
+	reader = NewNDJSONReader[ndjsonRecord](strings.NewReader(input))
+	reader.OnLineError = func(line int, raw []byte, err error) error {
+		badLines = append(badLines, line)
+		raws = append(raws, string(raw))
+		return nil
+	}
+	var ids []int
+	if err := reader.Each(context.Background(), func(r ndjsonRecord) error {
+		ids = append(ids, r.ID)
+		return nil
+	}); err != nil {
+		t.Fatalf("Each() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 4}) || !reflect.DeepEqual(badLines, []int{2, 4}) {
+		t.Errorf("Each() read IDs %v with bad lines %v, want [1 4] and [2 4]", ids, badLines)
+	}
+	if raws[1] != `{"id":"x"}` {
+		t.Errorf("OnLineError raw line = %q, want the undecodable line", raws[1])
+	}
+
+	abort := errors.New("too many bad lines")
+	reader = NewNDJSONReader[ndjsonRecord](strings.NewReader(input))
+	reader.OnLineError = func(int, []byte, error) error { return abort }
+	if err := reader.Each(context.Background(), func(ndjsonRecord) error { return nil }); !errors.Is(err, abort) {
+		t.Errorf("Each() with an aborting OnLineError error = %v, want %v", err, abort)
+	}
+}
+
+func TestNDJSONReaderStops(t *testing.T) {
+	const input = "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"
+
+	stop := errors.New("stop")
+	var seen int
+	err := NewNDJSONReader[ndjsonRecord](strings.NewReader(input)).Each(context.Background(), func(r ndjsonRecord) error {
+		seen++
+		if r.ID == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || seen != 2 {
+		t.Errorf("Each() = %v after %d records, want stop after 2", err, seen)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	seen = 0
+	err = NewNDJSONReader[ndjsonRecord](strings.NewReader(input)).Each(ctx, func(ndjsonRecord) error {
+		seen++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || seen != 1 {
+		t.Errorf("Each() = %v after %d records, want Canceled after 1", err, seen)
+	}
+}
+
+func TestNDJSONRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+	want := make([]ndjsonRecord, 1000)
+	for i := range want {
+		want[i] = ndjsonRecord{ID: i, Name: strings.Repeat("x", i%7) + "\n<tab>"}
+		if err := writer.Write(want[i]); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	var got []ndjsonRecord
+	reader := NewNDJSONReader[ndjsonRecord](&buf)
+	if err := reader.Each(context.Background(), func(r ndjsonRecord) error {
+		got = append(got, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("Each() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip read %d records, want the %d written", len(got), len(want))
+	}
+}
@@ -0,0 +1,77 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// maxExpandedZeros is how many zeros NormalizeJSONNumbers pads a number with when writing it without
+// an exponent. Numbers that would need more, such as 1e100000, keep an exponent instead, so a few bytes
+// of input can't expand into megabytes of output.
+const maxExpandedZeros = 100
+
+// NormalizeJSONNumbers re-emits data as compact JSON with every number rewritten in a canonical
+// minimal form that keeps its exact value: no exponent, no trailing fractional zeros and no negative
+// zero, so 1.0 becomes 1, 1e2 becomes 100, 2.50 becomes 2.5 and -0 becomes 0. Integers of any size
+// stay exact integers. Numbers whose plain form would need more than 100 padding zeros are written
+// in exponent form instead, with one digit before the point and no trailing zeros (1e100000, 1.5e-300).
+// Key order and string contents are preserved, which makes the output suitable for hashing or signing.
+func NormalizeJSONNumbers(data string) (string, error) {
+	normalized, err := rewriteJSON([]byte(data), nil, canonicalJSONNumber)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// canonicalJSONNumber rewrites a JSON number literal textually, without materializing its value, as
+// significant digits times a power of ten
+func canonicalJSONNumber(n json.Number) (string, error) {
+	s := n.String()
+	if !isJSONNumberLiteral(s) {
+		return "", fmt.Errorf("invalid number %q", s)
+	}
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	mantissa, exponentText, _ := strings.Cut(strings.ToLower(s), "e")
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	exponent := new(big.Int)
+	if exponentText != "" {
+		exponent.SetString(strings.TrimPrefix(exponentText, "+"), 10)
+	}
+	exponent.Sub(exponent, big.NewInt(int64(len(fraction))))
+
+	digits := strings.TrimLeft(integer+fraction, "0")
+	trimmed := strings.TrimRight(digits, "0")
+	exponent.Add(exponent, big.NewInt(int64(len(digits)-len(trimmed))))
+	digits = trimmed
+	if digits == "" {
+		return "0", nil
+	}
+
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	// plain form: digits followed by exponent zeros, or with the point exponent places from the right
+	if exponent.Sign() >= 0 && exponent.IsInt64() && exponent.Int64() <= maxExpandedZeros {
+		return sign + digits + strings.Repeat("0", int(exponent.Int64())), nil
+	}
+	if exponent.Sign() < 0 && exponent.IsInt64() && -exponent.Int64()-int64(len(digits)) <= maxExpandedZeros {
+		point := len(digits) + int(exponent.Int64())
+		if point > 0 {
+			return sign + digits[:point] + "." + digits[point:], nil
+		}
+		return sign + "0." + strings.Repeat("0", -point) + digits, nil
+	}
+
+	scientific := exponent.Add(exponent, big.NewInt(int64(len(digits)-1)))
+	out := sign + digits[:1]
+	if len(digits) > 1 {
+		out += "." + digits[1:]
+	}
+	return out + "e" + scientific.String(), nil
+}
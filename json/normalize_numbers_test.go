@@ -0,0 +1,39 @@
+package json
+
+import "testing"
+
+func TestNormalizeJSONNumbers(t *testing.T) {
+	tests := []struct {
+		name, data, want string
+	}{
+		{"trailing zero float", `1.0`, `1`},
+		{"trailing fractional zeros", `2.500`, `2.5`},
+		{"exponent", `1e2`, `100`},
+		{"signed exponent", `1.5E+3`, `1500`},
+		{"negative exponent", `25e-3`, `0.025`},
+		{"negative zero", `-0`, `0`},
+		{"negative zero float", `-0.0e5`, `0`},
+		{"negative number kept", `-1.50`, `-1.5`},
+		{"large integer preserved", `123456789012345678901234567890`, `123456789012345678901234567890`},
+		{"large integer from exponent", `1.2345e25`, `12345000000000000000000000`},
+		{"huge exponent kept compact", `1e100000`, `1e100000`},
+		{"tiny exponent kept compact", `15e-301`, `1.5e-300`},
+		{"recursive", `{"a":[1.0,{"b":2e1}],"c":"1.0"}`, `{"a":[1,{"b":20}],"c":"1.0"}`},
+		{"key order kept", `{"z":1.10, "a":0.0}`, `{"z":1.1,"a":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeJSONNumbers(tt.data)
+			if err != nil {
+				t.Fatalf("NormalizeJSONNumbers() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeJSONNumbers(%s) = %s, want %s", tt.data, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NormalizeJSONNumbers(`{"a":`); err == nil {
+		t.Error("NormalizeJSONNumbers() with invalid JSON returned no error")
+	}
+}
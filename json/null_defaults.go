@@ -0,0 +1,36 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalReplaceNulls unmarshals data into v after replacing explicit nulls with defaults.
+// defaults is keyed by dotted path (e.g. "settings.theme" or "items[0].qty"); a default is only
+// applied when the key is present with a null value, unlike encoding/json which leaves the field zero.
+// Missing keys are left missing.
+func UnmarshalReplaceNulls(data string, v any, defaults map[string]any) error {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range sortedKeys(defaults) {
+		segments, err := parsePath(path)
+		if err != nil {
+			return err
+		}
+		if value, ok := lookupPath(root, segments); !ok || value != nil {
+			continue
+		}
+		if err := setPath(root, segments, defaults[path]); err != nil {
+			return fmt.Errorf("path %q: %w", path, err)
+		}
+	}
+
+	replaced, err := json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(replaced, v)
+}
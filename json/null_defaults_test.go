@@ -0,0 +1,80 @@
+package json
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalReplaceNulls(t *testing.T) {
+	type item struct {
+		Qty int `json:"qty"`
+	}
+	type settings struct {
+		Theme string `json:"theme"`
+		Size  int    `json:"size"`
+	}
+	type profile struct {
+		Name     string    `json:"name"`
+		Role     string    `json:"role"`
+		Settings *settings `json:"settings"`
+		Items    []item    `json:"items"`
+	}
+	defaults := map[string]any{
+		"role":           "member",
+		"settings.theme": "dark",
+		"settings.size":  12,
+		"items[1].qty":   1,
+	}
+	tests := []struct {
+		name string
+		data string
+		want profile
+	}{
+		{
+			name: "top-level null",
+			data: `{"name":"Ada","role":null}`,
+			want: profile{Name: "Ada", Role: "member"},
+		},
+		{
+			name: "nested nulls",
+			data: `{"name":"Ada","settings":{"theme":null,"size":null},"items":[{"qty":null},{"qty":null}]}`,
+			want: profile{Name: "Ada", Settings: &settings{Theme: "dark", Size: 12}, Items: []item{{}, {Qty: 1}}},
+		},
+		{
+			name: "present values are kept",
+			data: `{"role":"admin","settings":{"theme":"light","size":0}}`,
+			want: profile{Role: "admin", Settings: &settings{Theme: "light"}},
+		},
+		{
+			name: "missing keys stay missing",
+			data: `{"name":"Ada"}`,
+			want: profile{Name: "Ada"},
+		},
+		{
+			name: "null parent is not a null leaf",
+			data: `{"settings":null}`,
+			want: profile{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got profile
+			if err := UnmarshalReplaceNulls(tt.data, &got, defaults); err != nil {
+				t.Fatalf("UnmarshalReplaceNulls() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalReplaceNulls() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalReplaceNullsErrors(t *testing.T) {
+	var got map[string]any
+	if err := UnmarshalReplaceNulls(`{"a":`, &got, nil); err == nil {
+		t.Error("UnmarshalReplaceNulls(truncated) returned no error")
+	}
+	if err := UnmarshalReplaceNulls(`{"a":null}`, &got, map[string]any{"a[": 1}); err == nil {
+		t.Error("UnmarshalReplaceNulls(bad path) returned no error")
+	}
+}
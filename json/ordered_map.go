@@ -0,0 +1,164 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OrderedMap is a JSON object that remembers key insertion order, so a document can be decoded
+// and re-encoded without reshuffling its keys. Nested objects decode as *OrderedMap, arrays as []any
+// and numbers as json.Number.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewOrderedMap creates an empty OrderedMap
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{values: map[string]any{}}
+}
+
+// ParseOrderedJSON decodes a JSON object, preserving the order of its keys at every level
+func ParseOrderedJSON(data string) (*OrderedMap, error) {
+	m := NewOrderedMap()
+	if err := m.UnmarshalJSON([]byte(data)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap) Keys() []string {
+	return append([]string(nil), m.keys...)
+}
+
+// Len returns the number of keys
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// Get returns the value stored under key
+func (m *OrderedMap) Get(key string) (any, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Set stores value under key; new keys are appended, existing keys keep their position
+func (m *OrderedMap) Set(key string, value any) {
+	if m.values == nil {
+		m.values = map[string]any{}
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Delete removes key
+func (m *OrderedMap) Delete(key string) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSONString(&buf, key); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (m *OrderedMap) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return ErrNotObject
+	}
+	m.keys, m.values = nil, map[string]any{}
+	if err := m.decodeObjectBody(decoder); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after top-level value")
+	}
+	return nil
+}
+
+// decodeObjectBody reads key/value pairs up to and including the closing brace
+func (m *OrderedMap) decodeObjectBody(decoder *json.Decoder) error {
+	for decoder.More() {
+		keyToken, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		value, err := decodeOrderedValue(decoder)
+		if err != nil {
+			return err
+		}
+		m.Set(keyToken.(string), value)
+	}
+	_, err := decoder.Token()
+	return err
+}
+
+func decodeOrderedValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	switch delim {
+	case '{':
+		child := NewOrderedMap()
+		if err := child.decodeObjectBody(decoder); err != nil {
+			return nil, err
+		}
+		return child, nil
+	case '[':
+		items := []any{}
+		for decoder.More() {
+			item, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unexpected delimiter %s", strings.TrimSpace(delim.String()))
+	}
+}
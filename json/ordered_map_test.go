@@ -0,0 +1,85 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOrderedMapRoundTrip(t *testing.T) {
+	tests := []struct {
+		name, data string
+	}{
+		{"flat", `{"zeta":1,"alpha":2,"mid":3}`},
+		{"nested", `{"b":{"z":true,"a":null},"a":[{"y":1,"x":2}],"c":"s"}`},
+		{"numbers kept verbatim", `{"big":12345678901234567890,"f":1.50}`},
+		{"empty", `{}`},
+		{"empty containers", `{"o":{},"l":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseOrderedJSON(tt.data)
+			if err != nil {
+				t.Fatalf("ParseOrderedJSON() error = %v", err)
+			}
+			got, err := json.Marshal(m)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.data {
+				t.Errorf("round trip = %s, want %s", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestOrderedMapEditing(t *testing.T) {
+	m := NewOrderedMap()
+	m.Set("b", 1)
+	m.Set("a", 2)
+	m.Set("c", 3)
+	m.Set("b", 4) // existing keys keep their position
+	m.Delete("a")
+	m.Delete("missing")
+
+	if want := []string{"b", "c"}; !reflect.DeepEqual(m.Keys(), want) || m.Len() != 2 {
+		t.Errorf("Keys() = %v, want %v", m.Keys(), want)
+	}
+	if value, ok := m.Get("b"); !ok || value != 4 {
+		t.Errorf("Get(b) = %v, %v, want 4", value, ok)
+	}
+	got, err := json.Marshal(m)
+	if err != nil || string(got) != `{"b":4,"c":3}` {
+		t.Errorf("Marshal() = %s, %v, want {\"b\":4,\"c\":3}", got, err)
+	}
+
+	var zero OrderedMap
+	zero.Set("x", true)
+	if got, _ := json.Marshal(&zero); string(got) != `{"x":true}` {
+		t.Errorf("Marshal(zero value after Set) = %s", got)
+	}
+}
+
+func TestOrderedMapInStruct(t *testing.T) {
+	var payload struct {
+		Attrs *OrderedMap `json:"attrs"`
+	}
+	if err := json.Unmarshal([]byte(`{"attrs":{"z":1,"a":2}}`), &payload); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if want := []string{"z", "a"}; !reflect.DeepEqual(payload.Attrs.Keys(), want) {
+		t.Errorf("Keys() = %v, want %v", payload.Attrs.Keys(), want)
+	}
+}
+
+func TestParseOrderedJSONErrors(t *testing.T) {
+	if _, err := ParseOrderedJSON(`[1]`); !errors.Is(err, ErrNotObject) {
+		t.Errorf("ParseOrderedJSON(array) error = %v, want ErrNotObject", err)
+	}
+	for _, data := range []string{`{"a":`, `{"a":1} {}`, `{"a":[1}`} {
+		if _, err := ParseOrderedJSON(data); err == nil {
+			t.Errorf("ParseOrderedJSON(%s) returned no error", data)
+		}
+	}
+}
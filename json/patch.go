@@ -0,0 +1,401 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// patchOperation is a single RFC 6902 operation
+type patchOperation struct {
+	Op    string     `json:"op"`
+	Path  *string    `json:"path"`
+	From  *string    `json:"from"`
+	Value patchValue `json:"value"`
+}
+
+// patchValue is an operation's "value" member, which may legitimately be null: present tells a
+// null value apart from a missing one
+type patchValue struct {
+	raw     json.RawMessage
+	present bool
+}
+
+func (v *patchValue) UnmarshalJSON(data []byte) error {
+	v.raw = append(v.raw[:0], data...)
+	v.present = true
+	return nil
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON patch to doc and returns the patched document.
+// Operations are applied in order against a working copy; if any operation fails
+// (including a "test" that doesn't match) the whole patch is rejected and doc is left untouched.
+func ApplyJSONPatch(doc string, patch string) (string, error) {
+	target, err := decodeJSONValue(doc)
+	if err != nil {
+		return "", fmt.Errorf("invalid document: %w", err)
+	}
+
+	var ops []patchOperation
+	if err := json.Unmarshal([]byte(patch), &ops); err != nil {
+		return "", fmt.Errorf("invalid patch: %w", err)
+	}
+
+	for i, op := range ops {
+		target, err = applyPatchOperation(target, op)
+		if err != nil {
+			return "", fmt.Errorf("patch operation %d (%s): %w", i, op.Op, err)
+		}
+	}
+
+	out, err := json.Marshal(target)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func applyPatchOperation(doc any, op patchOperation) (any, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("missing \"path\"")
+	}
+	path, err := parseJSONPointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := operationValue(op)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	case "remove":
+		doc, _, err := pointerRemove(doc, path)
+		return doc, err
+	case "replace":
+		value, err := operationValue(op)
+		if err != nil {
+			return nil, err
+		}
+		doc, _, err = pointerRemove(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	case "move":
+		from, err := operationFrom(op)
+		if err != nil {
+			return nil, err
+		}
+		if isPointerPrefix(from, path) && len(from) != len(path) {
+			return nil, fmt.Errorf("cannot move %q into one of its children", *op.From)
+		}
+		doc, value, err := pointerRemove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	case "copy":
+		from, err := operationFrom(op)
+		if err != nil {
+			return nil, err
+		}
+		value, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, deepCopyJSON(value))
+	case "test":
+		value, err := operationValue(op)
+		if err != nil {
+			return nil, err
+		}
+		actual, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonValuesEqual(actual, value) {
+			return nil, fmt.Errorf("test failed: value at %q does not match", *op.Path)
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+func operationValue(op patchOperation) (any, error) {
+	if !op.Value.present {
+		return nil, fmt.Errorf("missing \"value\"")
+	}
+	return decodeJSONValue(string(op.Value.raw))
+}
+
+func operationFrom(op patchOperation) ([]string, error) {
+	if op.From == nil {
+		return nil, fmt.Errorf("missing \"from\"")
+	}
+	return parseJSONPointer(*op.From)
+}
+
+// region JSON pointer (RFC 6901)
+
+// parseJSONPointer splits a JSON pointer into its unescaped reference tokens
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func formatJSONPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return sb.String()
+}
+
+func isPointerPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseArrayIndex parses an array reference token; "-" is only accepted when allowEnd is set
+func parseArrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if index > max {
+		return 0, fmt.Errorf("array index %d out of bounds (length %d)", index, length)
+	}
+	return index, nil
+}
+
+func pointerGet(doc any, path []string) (any, error) {
+	current := doc
+	for i, token := range path {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", formatJSONPointer(path[:i+1]))
+			}
+			current = value
+		case []any:
+			index, err := parseArrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %w", formatJSONPointer(path[:i+1]), err)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("path %q does not exist: parent is not a container", formatJSONPointer(path[:i+1]))
+		}
+	}
+	return current, nil
+}
+
+// pointerAdd inserts value at path and returns the (possibly new) root
+func pointerAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := pointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+		return doc, nil
+	case []any:
+		index, err := parseArrayIndex(last, len(node), true)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", formatJSONPointer(path), err)
+		}
+		node = append(node, nil)
+		copy(node[index+1:], node[index:])
+		node[index] = value
+		return pointerSet(doc, path[:len(path)-1], node)
+	default:
+		return nil, fmt.Errorf("path %q does not exist: parent is not a container", formatJSONPointer(path))
+	}
+}
+
+// pointerRemove deletes the value at path and returns the new root and the removed value
+func pointerRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := pointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		value, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path %q does not exist", formatJSONPointer(path))
+		}
+		delete(node, last)
+		return doc, value, nil
+	case []any:
+		index, err := parseArrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("path %q: %w", formatJSONPointer(path), err)
+		}
+		value := node[index]
+		node = append(node[:index:index], node[index+1:]...)
+		doc, err = pointerSet(doc, path[:len(path)-1], node)
+		return doc, value, err
+	default:
+		return nil, nil, fmt.Errorf("path %q does not exist: parent is not a container", formatJSONPointer(path))
+	}
+}
+
+// pointerSet replaces the value at an existing path, used to store resized arrays back into their parent
+func pointerSet(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := pointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+	case []any:
+		index, err := parseArrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = value
+	}
+	return doc, nil
+}
+
+// endregion
+
+// region generic JSON value helpers
+
+// decodeJSONValue decodes a single JSON value, keeping numbers as json.Number to avoid precision loss
+func decodeJSONValue(data string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	return value, nil
+}
+
+func deepCopyJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = deepCopyJSON(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = deepCopyJSON(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// jsonValuesEqual compares two decoded JSON values, treating numbers as equal when their values are
+func jsonValuesEqual(a, b any) bool {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, item := range av {
+			other, ok := bv[key]
+			if !ok || !jsonValuesEqual(item, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonValuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number, float64:
+		ar, ok := jsonNumberRat(av)
+		if !ok {
+			return false
+		}
+		br, ok := jsonNumberRat(b)
+		return ok && ar.Cmp(br) == 0
+	default:
+		return a == b
+	}
+}
+
+func jsonNumberRat(value any) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		return new(big.Rat).SetString(v.String())
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(v) == nil {
+			return nil, false
+		}
+		return r, true
+	default:
+		return nil, false
+	}
+}
+
+// endregion
@@ -0,0 +1,71 @@
+package json
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	const doc = `{"name":"svc","tags":["a","b"],"limits":{"cpu":1}}`
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{"add member", `[{"op":"add","path":"/region","value":"eu"}]`, `{"limits":{"cpu":1},"name":"svc","region":"eu","tags":["a","b"]}`},
+		{"add array element", `[{"op":"add","path":"/tags/1","value":"x"}]`, `{"limits":{"cpu":1},"name":"svc","tags":["a","x","b"]}`},
+		{"add appends with dash", `[{"op":"add","path":"/tags/-","value":"c"}]`, `{"limits":{"cpu":1},"name":"svc","tags":["a","b","c"]}`},
+		{"add null value", `[{"op":"add","path":"/owner","value":null}]`, `{"limits":{"cpu":1},"name":"svc","owner":null,"tags":["a","b"]}`},
+		{"remove", `[{"op":"remove","path":"/limits/cpu"}]`, `{"limits":{},"name":"svc","tags":["a","b"]}`},
+		{"replace", `[{"op":"replace","path":"/name","value":"api"}]`, `{"limits":{"cpu":1},"name":"api","tags":["a","b"]}`},
+		{"replace with null", `[{"op":"replace","path":"/name","value":null}]`, `{"limits":{"cpu":1},"name":null,"tags":["a","b"]}`},
+		{"move", `[{"op":"move","from":"/limits/cpu","path":"/cpu"}]`, `{"cpu":1,"limits":{},"name":"svc","tags":["a","b"]}`},
+		{"copy", `[{"op":"copy","from":"/tags/0","path":"/first"}]`, `{"first":"a","limits":{"cpu":1},"name":"svc","tags":["a","b"]}`},
+		{"test passes", `[{"op":"test","path":"/limits","value":{"cpu":1.0}},{"op":"remove","path":"/tags"}]`, `{"limits":{"cpu":1},"name":"svc"}`},
+		{"whole document", `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyJSONPatch(doc, tt.patch)
+			if err != nil {
+				t.Fatalf("ApplyJSONPatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplyJSONPatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	const doc = `{"name":"svc","tags":["a"]}`
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr string
+	}{
+		{"failing test op", `[{"op":"replace","path":"/name","value":"api"},{"op":"test","path":"/name","value":"svc"}]`, "operation 1 (test)"},
+		{"missing parent", `[{"op":"add","path":"/a/b","value":1}]`, "operation 0 (add)"},
+		{"index out of range", `[{"op":"add","path":"/tags/5","value":1}]`, "operation 0 (add)"},
+		{"remove missing member", `[{"op":"remove","path":"/nope"}]`, "operation 0 (remove)"},
+		{"missing value", `[{"op":"add","path":"/x"}]`, "operation 0 (add)"},
+		{"move into own child", `[{"op":"move","from":"/tags","path":"/tags/0"}]`, "operation 0 (move)"},
+		{"invalid pointer", `[{"op":"add","path":"name","value":1}]`, "operation 0 (add)"},
+		{"unknown op", `[{"op":"frob","path":"/name"}]`, "operation 0 (frob)"},
+		{"invalid patch", `{"op":"add"}`, "invalid patch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyJSONPatch(doc, tt.patch)
+			if err == nil {
+				t.Fatalf("ApplyJSONPatch() = %s, want error", got)
+			}
+			if got != "" {
+				t.Errorf("ApplyJSONPatch() = %q on error, want empty", got)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ApplyJSONPatch() error = %q, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrPathNotFound = errors.New("JSON path not found")
+
+// pathSegment is one step of a dotted/bracketed path such as "data.items[2].id"
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool // [*], matching every element of an array
+}
+
+// parsePath parses a dotted path with optional [n] array indexes, e.g. "user.emails[0]" or "[1].id".
+// A key that is empty or contains '.' or '[' is written as a quoted JSON string in brackets, e.g.
+// `headers["content.type"]`. A [*] wildcard segment is accepted too, but only collectPath and
+// updatePath expand it.
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var segments []pathSegment
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			if i == 0 || i == len(path)-1 || path[i+1] == '.' {
+				return nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+			i++
+		case '[':
+			if i+1 < len(path) && path[i+1] == '"' {
+				key, end, err := parseQuotedKey(path, i+1)
+				if err != nil {
+					return nil, err
+				}
+				segments = append(segments, pathSegment{key: key})
+				i = end
+				continue
+			}
+			end := i + 1
+			for end < len(path) && path[end] != ']' {
+				end++
+			}
+			if end >= len(path) {
+				return nil, fmt.Errorf("invalid path %q: missing ']'", path)
+			}
+			if path[i+1:end] == "*" {
+				segments = append(segments, pathSegment{isIndex: true, wildcard: true})
+				i = end + 1
+				continue
+			}
+			index, err := strconv.Atoi(path[i+1 : end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path %q: bad index %q", path, path[i+1:end])
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			segments = append(segments, pathSegment{key: path[i:end]})
+			i = end
+		}
+	}
+	return segments, nil
+}
+
+// parseQuotedKey decodes the JSON string starting at path[start] and the ']' closing it, returning the
+// key and the index just past the bracket
+func parseQuotedKey(path string, start int) (string, int, error) {
+	end := start + 1
+	for end < len(path) && path[end] != '"' {
+		if path[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end+1 >= len(path) || path[end+1] != ']' {
+		return "", 0, fmt.Errorf("invalid path %q: unterminated quoted key", path)
+	}
+	var key string
+	if err := json.Unmarshal([]byte(path[start:end+1]), &key); err != nil {
+		return "", 0, fmt.Errorf("invalid path %q: bad quoted key: %v", path, err)
+	}
+	return key, end + 2, nil
+}
+
+// lookupPath returns the value at segments inside a decoded JSON value
+func lookupPath(root any, segments []pathSegment) (any, bool) {
+	current := root
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]any:
+			if segment.isIndex {
+				return nil, false
+			}
+			value, ok := node[segment.key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []any:
+			if !segment.isIndex || segment.wildcard || segment.index >= len(node) {
+				return nil, false
+			}
+			current = node[segment.index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// collectPath returns every value matched by segments, expanding [*] wildcards in document order
+func collectPath(root any, segments []pathSegment) []any {
+	if len(segments) == 0 {
+		return []any{root}
+	}
+	segment, rest := segments[0], segments[1:]
+	switch node := root.(type) {
+	case map[string]any:
+		if value, ok := node[segment.key]; ok && !segment.isIndex {
+			return collectPath(value, rest)
+		}
+	case []any:
+		if segment.wildcard {
+			var matches []any
+			for _, item := range node {
+				matches = append(matches, collectPath(item, rest)...)
+			}
+			return matches
+		}
+		if segment.isIndex && segment.index < len(node) {
+			return collectPath(node[segment.index], rest)
+		}
+	}
+	return nil
+}
+
+// setPath stores value at segments, creating intermediate objects for missing keys.
+// Array indexes must already exist.
+func setPath(root any, segments []pathSegment, value any) error {
+	current := root
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment.wildcard {
+			return fmt.Errorf("wildcards can't be used to set a value")
+		}
+		switch node := current.(type) {
+		case map[string]any:
+			if segment.isIndex {
+				return fmt.Errorf("cannot index object with [%d]", segment.index)
+			}
+			if last {
+				node[segment.key] = value
+				return nil
+			}
+			child, ok := node[segment.key]
+			if !ok || child == nil {
+				child = map[string]any{}
+				node[segment.key] = child
+			}
+			current = child
+		case []any:
+			if !segment.isIndex {
+				return fmt.Errorf("cannot access key %q on an array", segment.key)
+			}
+			if segment.index >= len(node) {
+				return fmt.Errorf("array index %d out of bounds (length %d)", segment.index, len(node))
+			}
+			if last {
+				node[segment.index] = value
+				return nil
+			}
+			current = node[segment.index]
+		default:
+			return fmt.Errorf("cannot descend into a scalar value")
+		}
+	}
+	return nil
+}
+
+// updatePath replaces every value matched by segments with fn(value), expanding [*] wildcards.
+// Paths that don't match anything are ignored. segments must not be empty.
+func updatePath(root any, segments []pathSegment, fn func(any) any) {
+	segment, rest := segments[0], segments[1:]
+	apply := func(value any) any {
+		if len(rest) == 0 {
+			return fn(value)
+		}
+		updatePath(value, rest, fn)
+		return value
+	}
+	switch node := root.(type) {
+	case map[string]any:
+		if value, ok := node[segment.key]; ok && !segment.isIndex {
+			node[segment.key] = apply(value)
+		}
+	case []any:
+		if segment.wildcard {
+			for i, item := range node {
+				node[i] = apply(item)
+			}
+		} else if segment.isIndex && segment.index < len(node) {
+			node[segment.index] = apply(node[segment.index])
+		}
+	}
+}
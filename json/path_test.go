@@ -0,0 +1,162 @@
+package json
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    []pathSegment
+		wantErr bool
+	}{
+		{"a", []pathSegment{{key: "a"}}, false},
+		{"user.emails[0]", []pathSegment{{key: "user"}, {key: "emails"}, {index: 0, isIndex: true}}, false},
+		{"[1].id", []pathSegment{{index: 1, isIndex: true}, {key: "id"}}, false},
+		{"items[*].price", []pathSegment{{key: "items"}, {isIndex: true, wildcard: true}, {key: "price"}}, false},
+		{"m[2][10]", []pathSegment{{key: "m"}, {index: 2, isIndex: true}, {index: 10, isIndex: true}}, false},
+		{`headers["content.type"]`, []pathSegment{{key: "headers"}, {key: "content.type"}}, false},
+		{`[""].a`, []pathSegment{{key: ""}, {key: "a"}}, false},
+		{`["a\"]"][0]`, []pathSegment{{key: `a"]`}, {index: 0, isIndex: true}}, false},
+		{`a["b"`, nil, true},
+		{`a["b\"]`, nil, true},
+		{`a["\x"]`, nil, true},
+		{"", nil, true},
+		{".a", nil, true},
+		{"a.", nil, true},
+		{"a..b", nil, true},
+		{"a[0", nil, true},
+		{"a[x]", nil, true},
+		{"a[-1]", nil, true},
+		{"a[]", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parsePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePath(%q) = %+v, want %+v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func mustParsePath(t *testing.T, path string) []pathSegment {
+	t.Helper()
+	segments, err := parsePath(path)
+	if err != nil {
+		t.Fatalf("parsePath(%q) error = %v", path, err)
+	}
+	return segments
+}
+
+const pathDoc = `{"user":{"name":"ann","tags":["a","b"]},"items":[{"price":1},{"price":2},{"other":3}],"n":null}`
+
+func TestLookupPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   any
+		wantOK bool
+	}{
+		{"user.name", "ann", true},
+		{"user.tags[1]", "b", true},
+		{"items[0].price", json.Number("1"), true},
+		{"n", nil, true},
+		{"user.tags[2]", nil, false},
+		{"user[0]", nil, false},
+		{"items.price", nil, false},
+		{"items[*].price", nil, false},
+		{"user.name.first", nil, false},
+		{"missing", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			root, _ := decodeJSONValue(pathDoc)
+			got, ok := lookupPath(root, mustParsePath(t, tt.path))
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lookupPath(%q) = %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCollectPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []any
+	}{
+		{"items[*].price", []any{json.Number("1"), json.Number("2")}},
+		{"user.tags[*]", []any{"a", "b"}},
+		{"items[1].price", []any{json.Number("2")}},
+		{"user.name", []any{"ann"}},
+		{"items[*].missing", nil},
+		{"user[*]", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			root, _ := decodeJSONValue(pathDoc)
+			if got := collectPath(root, mustParsePath(t, tt.path)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collectPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"replace", "user.name", `{"user":{"name":1,"tags":["a","b"]}}`, false},
+		{"array element", "user.tags[0]", `{"user":{"name":"ann","tags":[1,"b"]}}`, false},
+		{"creates objects", "a.b.c", `{"a":{"b":{"c":1}},"user":{"name":"ann","tags":["a","b"]}}`, false},
+		{"index out of bounds", "user.tags[5]", "", true},
+		{"index on an object", "user[0]", "", true},
+		{"key on an array", "user.tags.x", "", true},
+		{"into a scalar", "user.name.x", "", true},
+		{"wildcard", "user.tags[*]", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, _ := decodeJSONValue(`{"user":{"name":"ann","tags":["a","b"]}}`)
+			err := setPath(root, mustParsePath(t, tt.path), float64(1))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := SafeMarshalJson(root); got != tt.want {
+				t.Errorf("after setPath(%q) = %s, want %s", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdatePath(t *testing.T) {
+	wrap := func(v any) any { return []any{v} }
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"items[*].price", `{"items":[{"price":[1]},{"price":[2]},{"other":3}],"n":null,"user":{"name":"ann","tags":["a","b"]}}`},
+		{"items[2].other", `{"items":[{"price":1},{"price":2},{"other":[3]}],"n":null,"user":{"name":"ann","tags":["a","b"]}}`},
+		{"items[9].price", `{"items":[{"price":1},{"price":2},{"other":3}],"n":null,"user":{"name":"ann","tags":["a","b"]}}`},
+		{"user.missing", `{"items":[{"price":1},{"price":2},{"other":3}],"n":null,"user":{"name":"ann","tags":["a","b"]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			root, _ := decodeJSONValue(pathDoc)
+			updatePath(root, mustParsePath(t, tt.path), wrap)
+			if got := SafeMarshalJson(root); got != tt.want {
+				t.Errorf("after updatePath(%q) = %s, want %s", tt.path, got, tt.want)
+			}
+		})
+	}
+}
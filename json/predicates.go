@@ -0,0 +1,15 @@
+package json
+
+// HasKey reports whether something, even an explicit null, exists at the dotted path.
+// It returns false for invalid JSON or paths, and when an intermediate node is missing.
+func HasKey(data, path string) bool {
+	_, ok := valueAtPath(data, path)
+	return ok
+}
+
+// IsNullAt reports whether the value at the dotted path is an explicit null. A missing key,
+// invalid JSON or an invalid path all report false.
+func IsNullAt(data, path string) bool {
+	value, ok := valueAtPath(data, path)
+	return ok && value == nil
+}
@@ -0,0 +1,36 @@
+package json
+
+import "testing"
+
+func TestHasKeyAndIsNullAt(t *testing.T) {
+	const data = `{"user":{"name":"ann","email":null,"roles":[{"id":1},null]},"count":0}`
+	tests := []struct {
+		name   string
+		data   string
+		path   string
+		hasKey bool
+		isNull bool
+	}{
+		{"present key", data, "user.name", true, false},
+		{"present falsy value", data, "count", true, false},
+		{"explicit null", data, "user.email", true, true},
+		{"bracketed index", data, "user.roles[0].id", true, false},
+		{"null array item", data, "user.roles[1]", true, true},
+		{"missing key", data, "user.phone", false, false},
+		{"missing intermediate node", data, "account.id", false, false},
+		{"lookup below null", data, "user.email.domain", false, false},
+		{"index out of range", data, "user.roles[5]", false, false},
+		{"invalid path", data, "user.roles[", false, false},
+		{"invalid JSON", `{"user":`, "user", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasKey(tt.data, tt.path); got != tt.hasKey {
+				t.Errorf("HasKey(%q) = %v, want %v", tt.path, got, tt.hasKey)
+			}
+			if got := IsNullAt(tt.data, tt.path); got != tt.isNull {
+				t.Errorf("IsNullAt(%q) = %v, want %v", tt.path, got, tt.isNull)
+			}
+		})
+	}
+}
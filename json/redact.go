@@ -0,0 +1,89 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RedactedPlaceholder replaces values removed by Redact
+const RedactedPlaceholder = "[REDACTED]"
+
+// maskedValue stands in for values a mask tag hides completely
+const maskedValue = "****"
+
+// RedactedMarshal marshals v like json.Marshal for safe logging of requests and responses, honoring two
+// struct tags: fields tagged redact:"true" are left out, and fields with a mask tag are rendered as a masked
+// string. mask:"last4" (or any lastN / firstN) keeps that many characters of the value's text and replaces
+// the rest with '*', while mask:"all" hides it completely; null values stay null. An unknown mask is an error.
+func RedactedMarshal(v any) (string, error) {
+	encoder := treeEncoder{redactTags: true}
+	tree, err := encoder.encode(reflect.ValueOf(v))
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Redact replaces the value at each dotted path with RedactedPlaceholder, e.g.
+// Redact(raw, "user.password", "cards[*].number"), for payloads that aren't typed structs.
+// Paths that match nothing are skipped.
+func Redact(data string, paths ...string) (string, error) {
+	return MaskJSONPaths(data, paths, func(any) any { return RedactedPlaceholder })
+}
+
+// maskFieldValue applies a mask tag to an encoded field value
+func maskFieldValue(value any, mask string) (any, error) {
+	if mask == "all" {
+		if value == nil {
+			return nil, nil
+		}
+		return maskedValue, nil
+	}
+
+	var keepFirst bool
+	var digits string
+	switch {
+	case strings.HasPrefix(mask, "last"):
+		digits = strings.TrimPrefix(mask, "last")
+	case strings.HasPrefix(mask, "first"):
+		keepFirst, digits = true, strings.TrimPrefix(mask, "first")
+	}
+	keep, err := strconv.Atoi(digits)
+	if err != nil || keep < 0 {
+		return nil, fmt.Errorf("unknown mask %q", mask)
+	}
+
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		text = v
+	case json.RawMessage:
+		// custom marshalers: mask the text of a JSON string, hide anything structured
+		if err := json.Unmarshal(v, &text); err != nil {
+			return maskedValue, nil
+		}
+	case *OrderedMap, map[string]any, []any, []byte:
+		return maskedValue, nil
+	default:
+		text = fmt.Sprint(v)
+	}
+
+	runes := []rune(text)
+	if keep >= len(runes) {
+		// nothing would be hidden, so hide everything rather than leak a short value
+		return strings.Repeat("*", len(runes)), nil
+	}
+	if keepFirst {
+		return string(runes[:keep]) + strings.Repeat("*", len(runes)-keep), nil
+	}
+	return strings.Repeat("*", len(runes)-keep) + string(runes[len(runes)-keep:]), nil
+}
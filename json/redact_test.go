@@ -0,0 +1,93 @@
+package json
+
+import (
+	"strings"
+	"testing"
+)
+
+type redactedCard struct {
+	Holder string  `json:"holder"`
+	Number string  `json:"number" mask:"last4"`
+	CVC    string  `json:"cvc" redact:"true"`
+	Bank   *string `json:"bank" mask:"all"`
+}
+
+type redactedLogin struct {
+	User     string         `json:"user" mask:"first2"`
+	Password string         `json:"password" redact:"true"`
+	Token    string         `json:"token,omitempty" mask:"all"`
+	PIN      int            `json:"pin" mask:"last1"`
+	Cards    []redactedCard `json:"cards"`
+	Short    string         `json:"short" mask:"last4"`
+}
+
+func TestRedactedMarshal(t *testing.T) {
+	bank := "ACME"
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"login", redactedLogin{
+			User: "ada@example.com", Password: "hunter2", Token: "secret", PIN: 1234, Short: "abc",
+			Cards: []redactedCard{{Holder: "Ada", Number: "4111111111111111", CVC: "123", Bank: &bank}},
+		}, `{"user":"ad*************","token":"****","pin":"***4","cards":[{"holder":"Ada","number":"************1111","bank":"****"}],"short":"***"}`},
+		{"null stays null", redactedCard{Holder: "Bob", Number: "5500"}, `{"holder":"Bob","number":"****","bank":null}`},
+		{"pointer", &redactedCard{Number: "12345"}, `{"holder":"","number":"*2345","bank":null}`},
+		{"untagged values unchanged", map[string]any{"password": "plain"}, `{"password":"plain"}`},
+		{"unicode masked by character", redactedCard{Number: "ñandú-9876"}, `{"holder":"","number":"******9876","bank":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RedactedMarshal(tt.v)
+			if err != nil {
+				t.Fatalf("RedactedMarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RedactedMarshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactedMarshalUnknownMask(t *testing.T) {
+	type badMask struct {
+		Secret string `json:"secret" mask:"middle3"`
+	}
+	if _, err := RedactedMarshal(badMask{Secret: "x"}); err == nil || !strings.Contains(err.Error(), "middle3") {
+		t.Errorf("RedactedMarshal() with an unknown mask error = %v, want one naming the mask", err)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	const payload = `{"user":{"name":"ada","password":"hunter2"},"cards":[{"number":"4111","exp":"12/30"},{"number":"5500"}]}`
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{"single path", []string{"user.password"},
+			`{"cards":[{"exp":"12/30","number":"4111"},{"number":"5500"}],"user":{"name":"ada","password":"[REDACTED]"}}`},
+		{"wildcard", []string{"cards[*].number"},
+			`{"cards":[{"exp":"12/30","number":"[REDACTED]"},{"number":"[REDACTED]"}],"user":{"name":"ada","password":"hunter2"}}`},
+		{"whole object", []string{"user"},
+			`{"cards":[{"exp":"12/30","number":"4111"},{"number":"5500"}],"user":"[REDACTED]"}`},
+		{"missing path skipped", []string{"user.ssn", "cards[5].number"},
+			`{"cards":[{"exp":"12/30","number":"4111"},{"number":"5500"}],"user":{"name":"ada","password":"hunter2"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Redact(payload, tt.paths...)
+			if err != nil {
+				t.Fatalf("Redact() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Redact(%v) = %s, want %s", tt.paths, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Redact(`{"a":`, "a"); err == nil {
+		t.Error("Redact() with invalid JSON returned no error")
+	}
+}
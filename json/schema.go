@@ -0,0 +1,722 @@
+package json
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Tealseed-Lab/easy_go_lib/cache"
+)
+
+// SchemaViolation is one way a document fails a JSON Schema: the dotted path of the offending value
+// ("" for the root), the schema keyword that failed and a readable message
+type SchemaViolation struct {
+	Path    string
+	Keyword string
+	Message string
+}
+
+func (v *SchemaViolation) Error() string {
+	path := v.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("%s: %s", path, v.Message)
+}
+
+// Schema is a compiled JSON Schema. Only draft 2020-12 is supported, and the forms drafts 4-7 used
+// differently (array-form items, boolean exclusiveMinimum and exclusiveMaximum, dependencies) make
+// CompileSchema fail. The supported keywords are type, enum, const, the numeric, string, array and
+// object constraints, properties, patternProperties, additionalProperties, propertyNames, required,
+// dependentRequired, dependentSchemas, items, prefixItems, contains, allOf, anyOf, oneOf, not,
+// if/then/else and local $ref pointers such as "#/$defs/address". The annotations $schema, $id,
+// $comment, title, description, format, default and examples are ignored; any other keyword, including
+// unevaluatedProperties, unevaluatedItems, $anchor and $dynamicRef, makes CompileSchema fail rather than
+// silently accept everything. Patterns use Go's RE2 syntax. A Schema is safe for concurrent use.
+type Schema struct {
+	root *schemaNode
+}
+
+type schemaNode struct {
+	alwaysValid, neverValid bool // the boolean schemas true and false
+
+	ref *schemaNode
+
+	types      []string
+	enum       []any
+	constValue any
+	hasConst   bool
+
+	minimum, maximum                   *big.Rat
+	exclusiveMinimum, exclusiveMaximum *big.Rat
+	multipleOf                         *big.Rat
+
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+
+	items                    *schemaNode
+	prefixItems              []*schemaNode
+	contains                 *schemaNode
+	minContains, maxContains *int
+	minItems, maxItems       *int
+	uniqueItems              bool
+
+	properties                   map[string]*schemaNode
+	patternProperties            []schemaPattern
+	additionalProperties         *schemaNode
+	propertyNames                *schemaNode
+	required                     []string
+	dependentRequired            map[string][]string
+	dependentSchemas             map[string]*schemaNode
+	minProperties, maxProperties *int
+
+	allOf, anyOf, oneOf []*schemaNode
+	not                 *schemaNode
+	ifSchema            *schemaNode
+	thenSchema          *schemaNode
+	elseSchema          *schemaNode
+}
+
+type schemaPattern struct {
+	pattern *regexp.Regexp
+	schema  *schemaNode
+}
+
+// CompileSchema parses and compiles a JSON Schema document, reporting unsupported or malformed keywords.
+// Compile once and reuse the Schema to validate many documents
+func CompileSchema(schema string) (*Schema, error) {
+	root, err := decodeJSONValue(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	compiler := schemaCompiler{root: root, nodes: map[string]*schemaNode{}}
+	node, err := compiler.compile("#", root)
+	if err != nil {
+		return nil, err
+	}
+	if err := compiler.checkCycles(); err != nil {
+		return nil, err
+	}
+	return &Schema{root: node}, nil
+}
+
+// schemaCacheSize bounds how many compiled schemas ValidateSchema keeps around
+const schemaCacheSize = 256
+
+// schemaCache holds the schemas compiled by ValidateSchema, keyed by a hash of their text so large
+// schemas aren't kept twice
+var schemaCache = cache.New[[sha256.Size]byte, *Schema](cache.WithMaxEntries(schemaCacheSize))
+
+// ValidateSchema validates doc against schema and returns every violation as a *SchemaViolation, or nil
+// if doc is valid. Compiled schemas are cached (up to the most recently used 256), so repeated calls with
+// the same schema only compile it once; CompileSchema and Schema.Validate skip the hashing as well. Like
+// the package's other document helpers it takes the documents as strings; pass string(b) for raw bytes.
+// Invalid JSON in doc, or a schema that doesn't compile, is returned as the only error.
+func ValidateSchema(doc, schema string) []error {
+	compiled, err := schemaCache.GetOrLoad(context.Background(), sha256.Sum256([]byte(schema)),
+		func(context.Context, [sha256.Size]byte) (*Schema, error) {
+			return CompileSchema(schema)
+		})
+	if err != nil {
+		return []error{err}
+	}
+	return compiled.Validate(doc)
+}
+
+// Validate checks doc against the schema and returns every violation as a *SchemaViolation, or nil if
+// doc is valid. Invalid JSON is returned as the only error.
+func (s *Schema) Validate(doc string) []error {
+	value, err := decodeJSONValue(doc)
+	if err != nil {
+		return []error{fmt.Errorf("invalid JSON: %w", err)}
+	}
+	var errs []error
+	s.root.validate(value, "", &errs)
+	return errs
+}
+
+// region compilation
+
+type schemaCompiler struct {
+	root  any
+	nodes map[string]*schemaNode // by JSON pointer, so $ref cycles resolve to the same node
+}
+
+func (c *schemaCompiler) compile(pointer string, raw any) (*schemaNode, error) {
+	if node, ok := c.nodes[pointer]; ok {
+		return node, nil
+	}
+	node := &schemaNode{}
+	c.nodes[pointer] = node
+
+	switch v := raw.(type) {
+	case bool:
+		node.alwaysValid, node.neverValid = v, !v
+		return node, nil
+	case map[string]any:
+		if err := c.fill(node, pointer, v); err != nil {
+			return nil, err
+		}
+		return node, nil
+	default:
+		return nil, fmt.Errorf("schema at %s must be an object or a boolean", pointer)
+	}
+}
+
+func (c *schemaCompiler) fill(node *schemaNode, pointer string, object map[string]any) error {
+	var err error
+	fail := func(keyword, format string, args ...any) error {
+		return fmt.Errorf("schema at %s: %s: %s", pointer, keyword, fmt.Sprintf(format, args...))
+	}
+	sub := func(keyword string, raw any) (*schemaNode, error) {
+		return c.compile(pointer+"/"+keyword, raw)
+	}
+	subList := func(keyword string, raw any) ([]*schemaNode, error) {
+		list, ok := raw.([]any)
+		if !ok || len(list) == 0 {
+			return nil, fail(keyword, "must be a non-empty array")
+		}
+		nodes := make([]*schemaNode, len(list))
+		for i, item := range list {
+			if nodes[i], err = c.compile(pointer+"/"+keyword+"/"+strconv.Itoa(i), item); err != nil {
+				return nil, err
+			}
+		}
+		return nodes, nil
+	}
+	number := func(keyword string, raw any) (*big.Rat, error) {
+		r, ok := jsonNumberRat(raw)
+		if !ok {
+			return nil, fail(keyword, "must be a number")
+		}
+		return r, nil
+	}
+	count := func(keyword string, raw any) (*int, error) {
+		r, ok := jsonNumberRat(raw)
+		if !ok || !r.IsInt() || r.Sign() < 0 || !r.Num().IsInt64() {
+			return nil, fail(keyword, "must be a non-negative integer")
+		}
+		n := int(r.Num().Int64())
+		return &n, nil
+	}
+	compilePattern := func(keyword, pattern string) (*regexp.Regexp, error) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fail(keyword, "invalid pattern %q: %v", pattern, err)
+		}
+		return re, nil
+	}
+
+	for _, keyword := range sortedKeys(object) {
+		raw := object[keyword]
+		switch keyword {
+		case "$ref":
+			ref, ok := raw.(string)
+			if !ok {
+				return fail(keyword, "must be a string")
+			}
+			if node.ref, err = c.resolve(ref); err != nil {
+				return fail(keyword, "%v", err)
+			}
+		case "type":
+			switch t := raw.(type) {
+			case string:
+				node.types = []string{t}
+			case []any:
+				for _, item := range t {
+					name, ok := item.(string)
+					if !ok {
+						return fail(keyword, "must be a string or an array of strings")
+					}
+					node.types = append(node.types, name)
+				}
+			default:
+				return fail(keyword, "must be a string or an array of strings")
+			}
+			for _, name := range node.types {
+				switch name {
+				case "null", "boolean", "object", "array", "number", "string", "integer":
+				default:
+					return fail(keyword, "unknown type %q", name)
+				}
+			}
+		case "enum":
+			list, ok := raw.([]any)
+			if !ok {
+				return fail(keyword, "must be an array")
+			}
+			node.enum = list
+		case "const":
+			node.constValue, node.hasConst = raw, true
+		case "minimum":
+			node.minimum, err = number(keyword, raw)
+		case "maximum":
+			node.maximum, err = number(keyword, raw)
+		case "exclusiveMinimum":
+			node.exclusiveMinimum, err = number(keyword, raw)
+		case "exclusiveMaximum":
+			node.exclusiveMaximum, err = number(keyword, raw)
+		case "multipleOf":
+			if node.multipleOf, err = number(keyword, raw); err == nil && node.multipleOf.Sign() <= 0 {
+				return fail(keyword, "must be greater than 0")
+			}
+		case "minLength":
+			node.minLength, err = count(keyword, raw)
+		case "maxLength":
+			node.maxLength, err = count(keyword, raw)
+		case "pattern":
+			pattern, ok := raw.(string)
+			if !ok {
+				return fail(keyword, "must be a string")
+			}
+			node.pattern, err = compilePattern(keyword, pattern)
+		case "items":
+			node.items, err = sub(keyword, raw)
+		case "minItems":
+			node.minItems, err = count(keyword, raw)
+		case "maxItems":
+			node.maxItems, err = count(keyword, raw)
+		case "uniqueItems":
+			node.uniqueItems, _ = raw.(bool)
+		case "properties", "patternProperties":
+			properties, ok := raw.(map[string]any)
+			if !ok {
+				return fail(keyword, "must be an object")
+			}
+			for _, name := range sortedKeys(properties) {
+				schema, err := c.compile(pointer+"/"+keyword+"/"+escapeJSONPointer(name), properties[name])
+				if err != nil {
+					return err
+				}
+				if keyword == "properties" {
+					if node.properties == nil {
+						node.properties = map[string]*schemaNode{}
+					}
+					node.properties[name] = schema
+					continue
+				}
+				re, err := compilePattern(keyword, name)
+				if err != nil {
+					return err
+				}
+				node.patternProperties = append(node.patternProperties, schemaPattern{pattern: re, schema: schema})
+			}
+		case "additionalProperties":
+			node.additionalProperties, err = sub(keyword, raw)
+		case "required":
+			list, ok := raw.([]any)
+			if !ok {
+				return fail(keyword, "must be an array of strings")
+			}
+			for _, item := range list {
+				name, ok := item.(string)
+				if !ok {
+					return fail(keyword, "must be an array of strings")
+				}
+				node.required = append(node.required, name)
+			}
+		case "minProperties":
+			node.minProperties, err = count(keyword, raw)
+		case "maxProperties":
+			node.maxProperties, err = count(keyword, raw)
+		case "allOf":
+			node.allOf, err = subList(keyword, raw)
+		case "anyOf":
+			node.anyOf, err = subList(keyword, raw)
+		case "oneOf":
+			node.oneOf, err = subList(keyword, raw)
+		case "not":
+			node.not, err = sub(keyword, raw)
+		case "prefixItems":
+			node.prefixItems, err = subList(keyword, raw)
+		case "contains":
+			node.contains, err = sub(keyword, raw)
+		case "minContains":
+			node.minContains, err = count(keyword, raw)
+		case "maxContains":
+			node.maxContains, err = count(keyword, raw)
+		case "propertyNames":
+			node.propertyNames, err = sub(keyword, raw)
+		case "if":
+			node.ifSchema, err = sub(keyword, raw)
+		case "then":
+			node.thenSchema, err = sub(keyword, raw)
+		case "else":
+			node.elseSchema, err = sub(keyword, raw)
+		case "dependentRequired":
+			dependencies, ok := raw.(map[string]any)
+			if !ok {
+				return fail(keyword, "must be an object of string arrays")
+			}
+			node.dependentRequired = map[string][]string{}
+			for _, name := range sortedKeys(dependencies) {
+				list, ok := dependencies[name].([]any)
+				if !ok {
+					return fail(keyword, "must be an object of string arrays")
+				}
+				for _, item := range list {
+					dependency, ok := item.(string)
+					if !ok {
+						return fail(keyword, "must be an object of string arrays")
+					}
+					node.dependentRequired[name] = append(node.dependentRequired[name], dependency)
+				}
+			}
+		case "dependentSchemas":
+			schemas, ok := raw.(map[string]any)
+			if !ok {
+				return fail(keyword, "must be an object")
+			}
+			node.dependentSchemas = map[string]*schemaNode{}
+			for _, name := range sortedKeys(schemas) {
+				if node.dependentSchemas[name], err = c.compile(pointer+"/"+keyword+"/"+escapeJSONPointer(name), schemas[name]); err != nil {
+					return err
+				}
+			}
+		case "$schema", "$id", "$defs", "definitions", "$comment", "title", "description", "format", "default", "examples":
+			// annotations and containers for $ref targets, which are compiled when referenced
+		default:
+			return fail(keyword, "unsupported keyword")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCycles rejects schemas whose $ref chains loop back to a schema without descending into the
+// instance (through properties, items and the like), which would make validation recurse forever
+func (c *schemaCompiler) checkCycles() error {
+	pointers := make(map[*schemaNode]string, len(c.nodes))
+	for pointer, node := range c.nodes {
+		pointers[node] = pointer
+	}
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[*schemaNode]int{}
+	var visit func(node *schemaNode) error
+	visit = func(node *schemaNode) error {
+		switch state[node] {
+		case visiting:
+			return fmt.Errorf("schema at %s: $ref cycle does not consume any of the instance", pointers[node])
+		case done:
+			return nil
+		}
+		state[node] = visiting
+		for _, next := range node.inPlaceSubschemas() {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		state[node] = done
+		return nil
+	}
+	for _, pointer := range sortedKeys(c.nodes) {
+		if err := visit(c.nodes[pointer]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inPlaceSubschemas lists the subschemas applied to the same instance as n itself
+func (n *schemaNode) inPlaceSubschemas() []*schemaNode {
+	var nodes []*schemaNode
+	if n.ref != nil {
+		nodes = append(nodes, n.ref)
+	}
+	nodes = append(nodes, n.allOf...)
+	nodes = append(nodes, n.anyOf...)
+	nodes = append(nodes, n.oneOf...)
+	for _, node := range []*schemaNode{n.not, n.ifSchema, n.thenSchema, n.elseSchema} {
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	for _, name := range sortedKeys(n.dependentSchemas) {
+		nodes = append(nodes, n.dependentSchemas[name])
+	}
+	return nodes
+}
+
+// resolve compiles the schema a local $ref such as "#/$defs/address" points to
+func (c *schemaCompiler) resolve(ref string) (*schemaNode, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("only local references are supported, got %q", ref)
+	}
+	target := c.root
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer != "" {
+		if !strings.HasPrefix(pointer, "/") {
+			return nil, fmt.Errorf("unsupported reference %q", ref)
+		}
+		for _, token := range strings.Split(pointer[1:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			switch node := target.(type) {
+			case map[string]any:
+				value, ok := node[token]
+				if !ok {
+					return nil, fmt.Errorf("reference %q not found", ref)
+				}
+				target = value
+			case []any:
+				index, err := strconv.Atoi(token)
+				if err != nil || index < 0 || index >= len(node) {
+					return nil, fmt.Errorf("reference %q not found", ref)
+				}
+				target = node[index]
+			default:
+				return nil, fmt.Errorf("reference %q not found", ref)
+			}
+		}
+	}
+	return c.compile("#"+pointer, target)
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// endregion
+
+// region validation
+
+func (n *schemaNode) validate(value any, path string, errs *[]error) {
+	fail := func(keyword, format string, args ...any) {
+		*errs = append(*errs, &SchemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+	if n.alwaysValid {
+		return
+	}
+	if n.neverValid {
+		fail("false", "no value is allowed here")
+		return
+	}
+	if n.ref != nil {
+		n.ref.validate(value, path, errs)
+	}
+
+	actualType := jsonTypeOf(value)
+	if len(n.types) > 0 && !schemaTypeMatches(n.types, value, actualType) {
+		fail("type", "must be of type %s, got %s", strings.Join(n.types, " or "), actualType)
+		return
+	}
+	if n.enum != nil && !containsJSONValue(n.enum, value) {
+		fail("enum", "must be one of %s", renderDiffValue(n.enum))
+	}
+	if n.hasConst && !jsonValuesEqual(n.constValue, value) {
+		fail("const", "must be %s", renderDiffValue(n.constValue))
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		n.validateNumber(v, fail)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if n.minLength != nil && length < *n.minLength {
+			fail("minLength", "must be at least %d characters, got %d", *n.minLength, length)
+		}
+		if n.maxLength != nil && length > *n.maxLength {
+			fail("maxLength", "must be at most %d characters, got %d", *n.maxLength, length)
+		}
+		if n.pattern != nil && !n.pattern.MatchString(v) {
+			fail("pattern", "must match pattern %q", n.pattern.String())
+		}
+	case []any:
+		if n.minItems != nil && len(v) < *n.minItems {
+			fail("minItems", "must have at least %d items, got %d", *n.minItems, len(v))
+		}
+		if n.maxItems != nil && len(v) > *n.maxItems {
+			fail("maxItems", "must have at most %d items, got %d", *n.maxItems, len(v))
+		}
+		if n.uniqueItems {
+			for i := range v {
+				if containsJSONValue(v[:i], v[i]) {
+					fail("uniqueItems", "must not contain duplicates, item %d repeats an earlier one", i)
+					break
+				}
+			}
+		}
+		for i, item := range v {
+			switch {
+			case i < len(n.prefixItems):
+				n.prefixItems[i].validate(item, indexPath(path, i), errs)
+			case n.items != nil:
+				n.items.validate(item, indexPath(path, i), errs)
+			}
+		}
+		if n.contains != nil {
+			n.validateContains(v, path, fail)
+		}
+	case map[string]any:
+		n.validateObject(v, path, fail, errs)
+	}
+
+	for _, schema := range n.allOf {
+		schema.validate(value, path, errs)
+	}
+	if n.anyOf != nil && countMatches(n.anyOf, value, path) == 0 {
+		fail("anyOf", "must match at least one of the anyOf schemas")
+	}
+	if n.oneOf != nil {
+		if matches := countMatches(n.oneOf, value, path); matches != 1 {
+			fail("oneOf", "must match exactly one of the oneOf schemas, matched %d", matches)
+		}
+	}
+	if n.not != nil && countMatches([]*schemaNode{n.not}, value, path) == 1 {
+		fail("not", "must not match the schema in not")
+	}
+	if n.ifSchema != nil {
+		if countMatches([]*schemaNode{n.ifSchema}, value, path) == 1 {
+			if n.thenSchema != nil {
+				n.thenSchema.validate(value, path, errs)
+			}
+		} else if n.elseSchema != nil {
+			n.elseSchema.validate(value, path, errs)
+		}
+	}
+}
+
+func (n *schemaNode) validateContains(items []any, path string, fail func(keyword, format string, args ...any)) {
+	matches := 0
+	for i, item := range items {
+		matches += countMatches([]*schemaNode{n.contains}, item, indexPath(path, i))
+	}
+	minimum := 1
+	if n.minContains != nil {
+		minimum = *n.minContains
+	}
+	if matches < minimum {
+		if n.minContains == nil {
+			fail("contains", "must contain at least one item matching the contains schema")
+		} else {
+			fail("minContains", "must contain at least %d items matching the contains schema, got %d", minimum, matches)
+		}
+	}
+	if n.maxContains != nil && matches > *n.maxContains {
+		fail("maxContains", "must contain at most %d items matching the contains schema, got %d", *n.maxContains, matches)
+	}
+}
+
+func (n *schemaNode) validateNumber(v json.Number, fail func(keyword, format string, args ...any)) {
+	r, ok := jsonNumberRat(v)
+	if !ok {
+		return
+	}
+	if n.minimum != nil && r.Cmp(n.minimum) < 0 {
+		fail("minimum", "must be at least %s, got %s", n.minimum.RatString(), v)
+	}
+	if n.maximum != nil && r.Cmp(n.maximum) > 0 {
+		fail("maximum", "must be at most %s, got %s", n.maximum.RatString(), v)
+	}
+	if n.exclusiveMinimum != nil && r.Cmp(n.exclusiveMinimum) <= 0 {
+		fail("exclusiveMinimum", "must be greater than %s, got %s", n.exclusiveMinimum.RatString(), v)
+	}
+	if n.exclusiveMaximum != nil && r.Cmp(n.exclusiveMaximum) >= 0 {
+		fail("exclusiveMaximum", "must be less than %s, got %s", n.exclusiveMaximum.RatString(), v)
+	}
+	if n.multipleOf != nil && !new(big.Rat).Quo(r, n.multipleOf).IsInt() {
+		fail("multipleOf", "must be a multiple of %s, got %s", n.multipleOf.RatString(), v)
+	}
+}
+
+func (n *schemaNode) validateObject(object map[string]any, path string, fail func(keyword, format string, args ...any), errs *[]error) {
+	for _, name := range n.required {
+		if _, ok := object[name]; !ok {
+			*errs = append(*errs, &SchemaViolation{Path: joinPath(path, name), Keyword: "required", Message: "is required"})
+		}
+	}
+	for _, name := range sortedKeys(n.dependentRequired) {
+		if _, ok := object[name]; !ok {
+			continue
+		}
+		for _, dependency := range n.dependentRequired[name] {
+			if _, ok := object[dependency]; !ok {
+				*errs = append(*errs, &SchemaViolation{Path: joinPath(path, dependency), Keyword: "dependentRequired", Message: fmt.Sprintf("is required when %q is present", name)})
+			}
+		}
+	}
+	for _, name := range sortedKeys(n.dependentSchemas) {
+		if _, ok := object[name]; ok {
+			n.dependentSchemas[name].validate(object, path, errs)
+		}
+	}
+	if n.minProperties != nil && len(object) < *n.minProperties {
+		fail("minProperties", "must have at least %d properties, got %d", *n.minProperties, len(object))
+	}
+	if n.maxProperties != nil && len(object) > *n.maxProperties {
+		fail("maxProperties", "must have at most %d properties, got %d", *n.maxProperties, len(object))
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if n.propertyNames != nil {
+			n.propertyNames.validate(key, joinPath(path, key), errs)
+		}
+		matched := false
+		if schema, ok := n.properties[key]; ok {
+			schema.validate(object[key], joinPath(path, key), errs)
+			matched = true
+		}
+		for _, pattern := range n.patternProperties {
+			if pattern.pattern.MatchString(key) {
+				pattern.schema.validate(object[key], joinPath(path, key), errs)
+				matched = true
+			}
+		}
+		if !matched && n.additionalProperties != nil {
+			if n.additionalProperties.neverValid {
+				*errs = append(*errs, &SchemaViolation{Path: joinPath(path, key), Keyword: "additionalProperties", Message: "is not an allowed property"})
+				continue
+			}
+			n.additionalProperties.validate(object[key], joinPath(path, key), errs)
+		}
+	}
+}
+
+func schemaTypeMatches(types []string, value any, actual string) bool {
+	for _, name := range types {
+		if specTypeMatches(name, value, actual) {
+			return true
+		}
+	}
+	return false
+}
+
+// countMatches reports how many of schemas value satisfies
+func countMatches(schemas []*schemaNode, value any, path string) int {
+	matches := 0
+	for _, schema := range schemas {
+		var errs []error
+		schema.validate(value, path, &errs)
+		if len(errs) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func containsJSONValue(list []any, value any) bool {
+	for _, item := range list {
+		if jsonValuesEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// endregion
@@ -0,0 +1,271 @@
+package json
+
+import (
+	"crypto/sha256"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// violationList renders errs as "path keyword" pairs, with "(root)" for the root path
+func violationList(t *testing.T, errs []error) []string {
+	t.Helper()
+	var list []string
+	for _, err := range errs {
+		var violation *SchemaViolation
+		if !errors.As(err, &violation) {
+			t.Fatalf("error %v is not a *SchemaViolation", err)
+		}
+		path := violation.Path
+		if path == "" {
+			path = "(root)"
+		}
+		list = append(list, path+" "+violation.Keyword)
+	}
+	return list
+}
+
+func TestValidateSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		name, schema, doc string
+		want              []string
+	}{
+		{"type ok", `{"type":"string"}`, `"x"`, nil},
+		{"type", `{"type":"string"}`, `1`, []string{"(root) type"}},
+		{"type list", `{"type":["string","null"]}`, `null`, nil},
+		{"integer accepts 1.0", `{"type":"integer"}`, `1.0`, nil},
+		{"integer rejects fraction", `{"type":"integer"}`, `1.5`, []string{"(root) type"}},
+		{"enum", `{"enum":["a",1,{"k":null}]}`, `{"k":null}`, nil},
+		{"enum miss", `{"enum":["a",1]}`, `"b"`, []string{"(root) enum"}},
+		{"const numbers by value", `{"const":10}`, `1e1`, nil},
+		{"const", `{"const":"x"}`, `"y"`, []string{"(root) const"}},
+		{"minimum", `{"minimum":5}`, `4.99`, []string{"(root) minimum"}},
+		{"maximum", `{"maximum":5}`, `5`, nil},
+		{"exclusive bounds", `{"exclusiveMinimum":0,"exclusiveMaximum":10}`, `10`, []string{"(root) exclusiveMaximum"}},
+		{"multipleOf decimals exact", `{"multipleOf":0.01}`, `19.99`, nil},
+		{"multipleOf", `{"multipleOf":3}`, `10`, []string{"(root) multipleOf"}},
+		{"big integer", `{"maximum":9007199254740992}`, `9007199254740993`, []string{"(root) maximum"}},
+		{"string length counts runes", `{"minLength":2,"maxLength":3}`, `"héé"`, nil},
+		{"minLength", `{"minLength":2}`, `"a"`, []string{"(root) minLength"}},
+		{"maxLength", `{"maxLength":2}`, `"abc"`, []string{"(root) maxLength"}},
+		{"pattern unanchored", `{"pattern":"[0-9]+"}`, `"ab12"`, nil},
+		{"pattern", `{"pattern":"^[0-9]+$"}`, `"ab12"`, []string{"(root) pattern"}},
+		{"items", `{"items":{"type":"integer"}}`, `[1,"x",3,true]`, []string{"[1] type", "[3] type"}},
+		{"prefixItems", `{"prefixItems":[{"type":"string"},{"type":"integer"}],"items":false}`, `["a",1,2]`, []string{"[2] false"}},
+		{"array size", `{"minItems":2,"maxItems":3}`, `[1]`, []string{"(root) minItems"}},
+		{"uniqueItems", `{"uniqueItems":true}`, `[1,{"a":2},1.0]`, []string{"(root) uniqueItems"}},
+		{"contains", `{"contains":{"const":5}}`, `[1,2]`, []string{"(root) contains"}},
+		{"minContains", `{"contains":{"type":"string"},"minContains":2}`, `["a",1]`, []string{"(root) minContains"}},
+		{"maxContains", `{"contains":{"type":"string"},"maxContains":1}`, `["a","b"]`, []string{"(root) maxContains"}},
+		{"required", `{"required":["id","name"]}`, `{"id":1}`, []string{"name required"}},
+		{"properties", `{"properties":{"id":{"type":"integer"},"tags":{"items":{"type":"string"}}}}`, `{"id":"x","tags":["a",2]}`,
+			[]string{"id type", "tags[1] type"}},
+		{"additionalProperties false", `{"properties":{"id":{}},"additionalProperties":false}`, `{"id":1,"b":2,"a":3}`,
+			[]string{"a additionalProperties", "b additionalProperties"}},
+		{"additionalProperties schema", `{"additionalProperties":{"type":"string"}}`, `{"a":"x","b":1}`, []string{"b type"}},
+		{"patternProperties", `{"patternProperties":{"^x_":{"type":"integer"}},"additionalProperties":false}`, `{"x_a":1,"x_b":"2","y":0}`,
+			[]string{"x_b type", "y additionalProperties"}},
+		{"propertyNames", `{"propertyNames":{"maxLength":3}}`, `{"abcd":1,"ab":2}`, []string{"abcd maxLength"}},
+		{"property count", `{"minProperties":1,"maxProperties":2}`, `{}`, []string{"(root) minProperties"}},
+		{"dependentRequired", `{"dependentRequired":{"card":["cvc","exp"]}}`, `{"card":"4111","exp":"12/30"}`, []string{"cvc dependentRequired"}},
+		{"dependentSchemas", `{"dependentSchemas":{"card":{"required":["cvc"]}}}`, `{"card":"4111"}`, []string{"cvc required"}},
+		{"allOf", `{"allOf":[{"type":"integer"},{"minimum":3}]}`, `2`, []string{"(root) minimum"}},
+		{"anyOf", `{"anyOf":[{"type":"string"},{"type":"integer"}]}`, `true`, []string{"(root) anyOf"}},
+		{"oneOf matching both", `{"oneOf":[{"type":"integer"},{"minimum":0}]}`, `1`, []string{"(root) oneOf"}},
+		{"oneOf", `{"oneOf":[{"type":"integer"},{"type":"string"}]}`, `"x"`, nil},
+		{"not", `{"not":{"type":"null"}}`, `null`, []string{"(root) not"}},
+		{"if then", `{"if":{"properties":{"kind":{"const":"card"}}},"then":{"required":["last4"]},"else":{"required":["iban"]}}`,
+			`{"kind":"card"}`, []string{"last4 required"}},
+		{"if else", `{"if":{"properties":{"kind":{"const":"card"}}},"then":{"required":["last4"]},"else":{"required":["iban"]}}`,
+			`{"kind":"bank"}`, []string{"iban required"}},
+		{"true schema", `true`, `{"anything":[1]}`, nil},
+		{"false schema", `false`, `1`, []string{"(root) false"}},
+		{"annotations ignored", `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"t","format":"email","default":1}`, `"not an email"`, nil},
+		{"type failure stops other checks", `{"type":"string","minLength":5,"enum":["abcdef"]}`, `1`, []string{"(root) type"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violationList(t, ValidateSchema(tt.doc, tt.schema))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateSchema(%s, %s) = %v, want %v", tt.doc, tt.schema, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaRef(t *testing.T) {
+	schema, err := CompileSchema(`{
+		"$defs": {
+			"address": {"type":"object","required":["city"],"properties":{"city":{"type":"string"}}},
+			"node": {"type":"object","properties":{"value":{"type":"integer"},"children":{"type":"array","items":{"$ref":"#/$defs/node"}}}},
+			"a/b": {"const":1}
+		},
+		"properties": {
+			"billing": {"$ref":"#/$defs/address"},
+			"shipping": {"$ref":"#/$defs/address"},
+			"tree": {"$ref":"#/$defs/node"},
+			"escaped": {"$ref":"#/$defs/a~1b"},
+			"first": {"$ref":"#/properties/billing"}
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("CompileSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name, doc string
+		want      []string
+	}{
+		{"valid", `{"billing":{"city":"Oslo"},"tree":{"value":1,"children":[{"value":2,"children":[]}]},"escaped":1}`, nil},
+		{"shared definition", `{"billing":{},"shipping":{"city":5}}`, []string{"billing.city required", "shipping.city type"}},
+		{"recursive", `{"tree":{"children":[{"children":[{"value":"x"}]}]}}`, []string{"tree.children[0].children[0].value type"}},
+		{"escaped pointer", `{"escaped":2}`, []string{"escaped const"}},
+		{"ref to a property", `{"first":{}}`, []string{"first.city required"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := violationList(t, schema.Validate(tt.doc)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%s) = %v, want %v", tt.doc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaViolationError(t *testing.T) {
+	errs := ValidateSchema(`{"user":{"age":-1}}`, `{"properties":{"user":{"properties":{"age":{"minimum":0}}}},"required":["id"]}`)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	want := []string{"id: is required", "user.age: must be at least 0, got -1"}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("violation messages = %q, want %q", messages, want)
+	}
+	if got := (&SchemaViolation{Keyword: "type", Message: "must be of type object, got array"}).Error(); got != "(root): must be of type object, got array" {
+		t.Errorf("root SchemaViolation.Error() = %q", got)
+	}
+}
+
+func TestCompileSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name, schema, wantErr string
+	}{
+		{"invalid json", `{"type":`, "invalid schema JSON"},
+		{"not a schema", `"string"`, ""},
+		{"unknown type", `{"type":"float"}`, `unknown type "float"`},
+		{"bad type value", `{"type":5}`, "type"},
+		{"unsupported keyword", `{"unevaluatedProperties":false}`, "unsupported keyword"},
+		{"typo keyword", `{"propertys":{}}`, "unsupported keyword"},
+		{"negative count", `{"minLength":-1}`, "non-negative integer"},
+		{"fractional count", `{"maxItems":1.5}`, "non-negative integer"},
+		{"bad number", `{"minimum":"5"}`, "must be a number"},
+		{"zero multipleOf", `{"multipleOf":0}`, "greater than 0"},
+		{"bad pattern", `{"pattern":"(["}`, "invalid pattern"},
+		{"bad patternProperties", `{"patternProperties":{"(":{}}}`, "invalid pattern"},
+		{"empty allOf", `{"allOf":[]}`, "non-empty array"},
+		{"bad required", `{"required":[1]}`, "array of strings"},
+		{"remote ref", `{"$ref":"https://example.com/schema.json"}`, "only local references"},
+		{"missing ref", `{"$ref":"#/$defs/nope"}`, "not found"},
+		{"nested error path", `{"properties":{"a":{"minimum":"x"}}}`, "#/properties/a"},
+		{"ref cycle", `{"$defs":{"a":{"$ref":"#/$defs/b"},"b":{"allOf":[{"$ref":"#/$defs/a"}]}},"$ref":"#/$defs/a"}`, "cycle"},
+		{"self ref", `{"$ref":"#"}`, "cycle"},
+		{"draft 4 array items", `{"items":[{"type":"string"}]}`, "#/items"},
+		{"draft 4 boolean exclusiveMinimum", `{"minimum":1,"exclusiveMinimum":true}`, "must be a number"},
+		{"draft 4 dependencies", `{"dependencies":{"a":["b"]}}`, "unsupported keyword"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := CompileSchema(tt.schema)
+			if err == nil {
+				t.Fatalf("CompileSchema(%s) = %v, want an error", tt.schema, schema)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CompileSchema(%s) error = %v, want one containing %q", tt.schema, err, tt.wantErr)
+			}
+			if errs := ValidateSchema(`{}`, tt.schema); len(errs) != 1 || errs[0].Error() != err.Error() {
+				t.Errorf("ValidateSchema() with a bad schema = %v, want only the compile error", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaCachesCompiledSchemas(t *testing.T) {
+	schema := `{"type":"object","required":["cached-schema-test"]}`
+	if errs := ValidateSchema(`{}`, schema); len(errs) != 1 {
+		t.Fatalf("ValidateSchema() = %v, want one violation", errs)
+	}
+	compiled, ok := schemaCache.Get(sha256.Sum256([]byte(schema)))
+	if !ok {
+		t.Fatal("ValidateSchema() did not cache the compiled schema")
+	}
+	if errs := ValidateSchema(`{"cached-schema-test":1}`, schema); errs != nil {
+		t.Errorf("ValidateSchema() with the cached schema = %v, want nil", errs)
+	}
+	if again, _ := schemaCache.Get(sha256.Sum256([]byte(schema))); again != compiled {
+		t.Error("ValidateSchema() recompiled a cached schema")
+	}
+	bad := `{"minimum":"cached-schema-test"}`
+	if errs := ValidateSchema(`{}`, bad); len(errs) != 1 {
+		t.Fatalf("ValidateSchema() with a bad schema = %v, want only the compile error", errs)
+	}
+	if _, ok := schemaCache.Get(sha256.Sum256([]byte(bad))); ok {
+		t.Error("ValidateSchema() cached a schema that failed to compile")
+	}
+}
+
+func TestSchemaValidateInvalidDocument(t *testing.T) {
+	schema, err := CompileSchema(`{"type":"object"}`)
+	if err != nil {
+		t.Fatalf("CompileSchema() error = %v", err)
+	}
+	errs := schema.Validate(`{"a":`)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "invalid JSON") {
+		t.Errorf("Validate(truncated) = %v, want a single invalid JSON error", errs)
+	}
+	var violation *SchemaViolation
+	if errors.As(errs[0], &violation) {
+		t.Errorf("Validate(truncated) returned a SchemaViolation, want a parse error")
+	}
+}
+
+func TestSchemaConcurrentValidate(t *testing.T) {
+	schema, err := CompileSchema(`{"type":"object","required":["id"],"properties":{"id":{"type":"integer","minimum":1}}}`)
+	if err != nil {
+		t.Fatalf("CompileSchema() error = %v", err)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				if errs := schema.Validate(`{"id":5}`); errs != nil {
+					t.Errorf("Validate(valid) = %v", errs)
+					return
+				}
+				if errs := schema.Validate(`{"id":0}`); len(errs) != 1 {
+					t.Errorf("Validate(id 0) = %v, want one violation", errs)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkSchemaValidate(b *testing.B) {
+	schema, err := CompileSchema(`{"type":"object","required":["id","items"],"properties":{
+		"id":{"type":"string","pattern":"^ord_[0-9a-z]+$"},
+		"items":{"type":"array","minItems":1,"items":{"type":"object","required":["sku","qty"],
+			"properties":{"sku":{"type":"string"},"qty":{"type":"integer","minimum":1}}}}}}`)
+	if err != nil {
+		b.Fatalf("CompileSchema() error = %v", err)
+	}
+	doc := `{"id":"ord_42x","items":[{"sku":"A-1","qty":2},{"sku":"B-7","qty":1}]}`
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		schema.Validate(doc)
+	}
+}
@@ -0,0 +1,123 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// FieldSpec declares the constraints ValidateAgainstSpec checks for one field
+type FieldSpec struct {
+	// Required fails validation when the field is missing
+	Required bool
+	// Type is one of "string", "number", "integer", "boolean", "object", "array" or "null"; empty accepts any type
+	Type string
+	// Min and Max bound numbers by value, and strings (in characters) and arrays by length
+	Min *float64
+	Max *float64
+	// Pattern is a regular expression that string values must match
+	Pattern string
+}
+
+// FieldError reports a problem with the value at Path
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidateAgainstSpec checks data against spec, which is keyed by dotted path, and returns every
+// violation as a *FieldError, ordered by path. Missing optional fields are not checked. It returns
+// nil when data satisfies the spec.
+func ValidateAgainstSpec(data string, spec map[string]FieldSpec) []error {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return []error{fmt.Errorf("invalid JSON: %w", err)}
+	}
+
+	var errs []error
+	for _, path := range sortedKeys(spec) {
+		fieldSpec := spec[path]
+		fail := func(format string, args ...any) {
+			errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+		}
+
+		segments, err := parsePath(path)
+		if err != nil {
+			fail("%v", err)
+			continue
+		}
+		value, ok := lookupPath(root, segments)
+		if !ok {
+			if fieldSpec.Required {
+				fail("is required")
+			}
+			continue
+		}
+
+		actualType := jsonTypeOf(value)
+		if fieldSpec.Type != "" && !specTypeMatches(fieldSpec.Type, value, actualType) {
+			fail("must be of type %s, got %s", fieldSpec.Type, actualType)
+			continue
+		}
+
+		if size, unit, ok := specSize(value); ok {
+			if fieldSpec.Min != nil && size < *fieldSpec.Min {
+				fail("must be at least %s%s, got %s", formatSpecNumber(*fieldSpec.Min), unit, formatSpecNumber(size))
+			}
+			if fieldSpec.Max != nil && size > *fieldSpec.Max {
+				fail("must be at most %s%s, got %s", formatSpecNumber(*fieldSpec.Max), unit, formatSpecNumber(size))
+			}
+		}
+
+		if fieldSpec.Pattern != "" {
+			s, isString := value.(string)
+			if !isString {
+				continue
+			}
+			pattern, err := regexp.Compile(fieldSpec.Pattern)
+			if err != nil {
+				fail("invalid pattern %q: %v", fieldSpec.Pattern, err)
+			} else if !pattern.MatchString(s) {
+				fail("must match pattern %q", fieldSpec.Pattern)
+			}
+		}
+	}
+	return errs
+}
+
+func specTypeMatches(expected string, value any, actual string) bool {
+	if expected == "integer" {
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		r, ok := jsonNumberRat(n)
+		return ok && r.IsInt()
+	}
+	return expected == actual
+}
+
+// specSize returns the quantity Min/Max apply to: a number's value or a string's or array's length
+func specSize(value any) (float64, string, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, "", err == nil
+	case string:
+		return float64(utf8.RuneCountInString(v)), " characters", true
+	case []any:
+		return float64(len(v)), " items", true
+	default:
+		return 0, "", false
+	}
+}
+
+func formatSpecNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,81 @@
+package json
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func specBound(f float64) *float64 { return &f }
+
+var signupSpec = map[string]FieldSpec{
+	"email":        {Required: true, Type: "string", Pattern: `^[^@]+@[^@]+$`},
+	"age":          {Required: true, Type: "integer", Min: specBound(18), Max: specBound(130)},
+	"name":         {Type: "string", Min: specBound(2)},
+	"tags":         {Type: "array", Max: specBound(2)},
+	"address.city": {Required: true, Type: "string"},
+}
+
+func TestValidateAgainstSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			"valid",
+			`{"email":"a@b.io","age":30,"name":"ann","address":{"city":"Oslo"}}`,
+			nil,
+		},
+		{
+			"missing required fields",
+			`{"name":"ann"}`,
+			[]string{"address.city: is required", "age: is required", "email: is required"},
+		},
+		{
+			"wrong types",
+			`{"email":5,"age":30.5,"name":true,"address":{"city":null}}`,
+			[]string{
+				"address.city: must be of type string, got null",
+				"age: must be of type integer, got number",
+				"email: must be of type string, got number",
+				"name: must be of type string, got boolean",
+			},
+		},
+		{
+			"pattern and bounds",
+			`{"email":"nope","age":12,"name":"a","tags":[1,2,3],"address":{"city":"Oslo"}}`,
+			[]string{
+				"age: must be at least 18, got 12",
+				`email: must match pattern "^[^@]+@[^@]+$"`,
+				"name: must be at least 2 characters, got 1",
+				"tags: must be at most 2 items, got 3",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range ValidateAgainstSpec(tt.data, signupSpec) {
+				var fieldErr *FieldError
+				if !errors.As(err, &fieldErr) {
+					t.Errorf("ValidateAgainstSpec() error %v is not a *FieldError", err)
+				}
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateAgainstSpec() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAgainstSpecInvalidInput(t *testing.T) {
+	if errs := ValidateAgainstSpec(`{"email":`, signupSpec); len(errs) != 1 {
+		t.Errorf("ValidateAgainstSpec() with invalid JSON = %v, want a single error", errs)
+	}
+	errs := ValidateAgainstSpec(`{"code":"x"}`, map[string]FieldSpec{"code": {Pattern: "("}})
+	if len(errs) != 1 {
+		t.Errorf("ValidateAgainstSpec() with an invalid pattern = %v, want a single error", errs)
+	}
+}
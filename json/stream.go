@@ -0,0 +1,72 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// TransformJSONArray streams a JSON array from r, applies fn to each element and writes the
+// resulting array to w incrementally, holding only one element in memory at a time.
+// An error from fn aborts the transformation and is returned as-is; output written so far is
+// left incomplete.
+func TransformJSONArray(r io.Reader, w io.Writer, fn func(json.RawMessage) (json.RawMessage, error)) error {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return ErrNotArray
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i := 0; decoder.More(); i++ {
+		var element json.RawMessage
+		if err := decoder.Decode(&element); err != nil {
+			return err
+		}
+		transformed, err := fn(element)
+		if err != nil {
+			return err
+		}
+		if !json.Valid(transformed) {
+			return fmt.Errorf("element %d: transform produced invalid JSON", i)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(transformed); err != nil {
+			return err
+		}
+	}
+	// consume the closing bracket so a truncated input is reported
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+// DecodeNDJSON reads successive JSON values from r, either newline-delimited (NDJSON) or simply
+// concatenated with whitespace, decoding each into a T and passing it to fn. It stops at the end of
+// the stream, at the first malformed record (reporting its index), or when fn returns an error.
+func DecodeNDJSON[T any](r io.Reader, fn func(T) error) error {
+	decoder := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		var record T
+		if err := decoder.Decode(&record); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTransformJSONArray(t *testing.T) {
+	incrementCount := func(element json.RawMessage) (json.RawMessage, error) {
+		var item map[string]any
+		if err := json.Unmarshal(element, &item); err != nil {
+			return nil, err
+		}
+		item["count"] = item["count"].(float64) + 1
+		return json.Marshal(item)
+	}
+	tests := []struct {
+		name, input, want string
+	}{
+		{"increments each element", `[{"count":1},{"count":41}]`, `[{"count":2},{"count":42}]`},
+		{"empty array", ` [ ] `, `[]`},
+		{"pretty input", "[\n  {\"count\": 0}\n]", `[{"count":1}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := TransformJSONArray(strings.NewReader(tt.input), &out, incrementCount); err != nil {
+				t.Fatalf("TransformJSONArray() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("TransformJSONArray() wrote %s, want %s", out.String(), tt.want)
+			}
+			if !json.Valid([]byte(out.String())) {
+				t.Errorf("TransformJSONArray() wrote invalid JSON %s", out.String())
+			}
+		})
+	}
+}
+
+func TestTransformJSONArrayAborts(t *testing.T) {
+	errStop := errors.New("stop")
+	calls := 0
+	stopAtSecond := func(element json.RawMessage) (json.RawMessage, error) {
+		calls++
+		if calls == 2 {
+			return nil, errStop
+		}
+		return element, nil
+	}
+	var out strings.Builder
+	if err := TransformJSONArray(strings.NewReader(`[1,2,3]`), &out, stopAtSecond); !errors.Is(err, errStop) {
+		t.Fatalf("TransformJSONArray() error = %v, want the fn error", err)
+	}
+	if calls != 2 || out.String() != "[1" {
+		t.Errorf("TransformJSONArray() made %d calls and wrote %q, want 2 calls and [1", calls, out.String())
+	}
+
+	identity := func(element json.RawMessage) (json.RawMessage, error) { return element, nil }
+	if err := TransformJSONArray(strings.NewReader(`{"a":1}`), &out, identity); !errors.Is(err, ErrNotArray) {
+		t.Errorf("TransformJSONArray(object) error = %v, want ErrNotArray", err)
+	}
+	if err := TransformJSONArray(strings.NewReader(`[1,2`), &out, identity); err == nil {
+		t.Error("TransformJSONArray(truncated) returned no error")
+	}
+	invalid := func(json.RawMessage) (json.RawMessage, error) { return json.RawMessage(`{`), nil }
+	if err := TransformJSONArray(strings.NewReader(`[1]`), &out, invalid); err == nil {
+		t.Error("TransformJSONArray() accepted an invalid transformed element")
+	}
+}
+
+type ndjsonEvent struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []ndjsonEvent
+	}{
+		{"newline delimited", "{\"id\":1,\"kind\":\"a\"}\n{\"id\":2,\"kind\":\"b\"}\n", []ndjsonEvent{{1, "a"}, {2, "b"}}},
+		{"space separated", `{"id":1} {"id":2}  {"id":3}`, []ndjsonEvent{{ID: 1}, {ID: 2}, {ID: 3}}},
+		{"back to back", `{"id":1}{"id":2}`, []ndjsonEvent{{ID: 1}, {ID: 2}}},
+		{"blank lines and crlf", "\r\n{\"id\":1}\r\n\r\n{\"id\":2}\r\n", []ndjsonEvent{{ID: 1}, {ID: 2}}},
+		{"empty stream", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []ndjsonEvent
+			err := DecodeNDJSON(strings.NewReader(tt.input), func(e ndjsonEvent) error {
+				got = append(got, e)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("DecodeNDJSON() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("DecodeNDJSON() decoded %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("record %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeNDJSONErrors(t *testing.T) {
+	var decoded int
+	err := DecodeNDJSON(strings.NewReader("{\"id\":1}\n{\"id\":,}\n{\"id\":3}\n"), func(ndjsonEvent) error {
+		decoded++
+		return nil
+	})
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) || !strings.Contains(err.Error(), "record 1") {
+		t.Errorf("DecodeNDJSON() malformed record error = %v, want a syntax error for record 1", err)
+	}
+	if decoded != 1 {
+		t.Errorf("DecodeNDJSON() called fn %d times before the malformed record, want 1", decoded)
+	}
+
+	stop := errors.New("stop")
+	err = DecodeNDJSON(strings.NewReader(`{"id":1} {"id":2}`), func(e ndjsonEvent) error {
+		if e.ID == 1 {
+			return stop
+		}
+		t.Error("DecodeNDJSON() kept going after fn failed")
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("DecodeNDJSON() error = %v, want the callback error", err)
+	}
+}
@@ -0,0 +1,103 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// StrictUnmarshal decodes data into a new T like UnmarshalTo, but fails on keys that don't match a field
+// of T (so a renamed API field is caught instead of silently leaving the new field zero) and enforces
+// required fields: struct fields tagged required:"true", at any depth including inside arrays and maps,
+// and the dotted requiredPaths (without [*] wildcards). A null counts as missing. All missing fields are
+// reported together as *FieldError values joined with errors.Join.
+func StrictUnmarshal[T any](data string, requiredPaths ...string) (T, error) {
+	var zero, v T
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&v); err != nil {
+		return zero, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return zero, fmt.Errorf("unexpected data after top-level value")
+	}
+
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return zero, err
+	}
+	var errs []error
+	checkRequiredFields(root, reflect.TypeOf(v), "", &errs)
+	for _, path := range requiredPaths {
+		segments, err := parsePath(path)
+		if err != nil {
+			return zero, err
+		}
+		for _, segment := range segments {
+			if segment.wildcard {
+				return zero, fmt.Errorf("required path %q: wildcards are not supported", path)
+			}
+		}
+		if value, ok := lookupPath(root, segments); !ok || value == nil {
+			errs = append(errs, &FieldError{Path: path, Message: "required field is missing"})
+		}
+	}
+	if len(errs) > 0 {
+		return zero, errors.Join(errs...)
+	}
+	return v, nil
+}
+
+// checkRequiredFields walks a decoded document alongside the Go type it was decoded into, reporting
+// missing required:"true" fields
+func checkRequiredFields(value any, t reflect.Type, path string, errs *[]error) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, field := range structFields(t) {
+			fieldValue, ok := objectField(object, field.name)
+			if ok && fieldValue != nil {
+				checkRequiredFields(fieldValue, field.typ, joinPath(path, field.name), errs)
+			} else if field.required {
+				*errs = append(*errs, &FieldError{Path: joinPath(path, field.name), Message: "required field is missing"})
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := value.([]any); ok {
+			for i, item := range items {
+				checkRequiredFields(item, t.Elem(), indexPath(path, i), errs)
+			}
+		}
+	case reflect.Map:
+		if object, ok := value.(map[string]any); ok {
+			for _, key := range sortedKeys(object) {
+				checkRequiredFields(object[key], t.Elem(), joinPath(path, key), errs)
+			}
+		}
+	}
+}
+
+// objectField finds key in a decoded object the way encoding/json matches struct fields: exactly, or else case-insensitively
+func objectField(object map[string]any, key string) (any, bool) {
+	if value, ok := object[key]; ok {
+		return value, true
+	}
+	for candidate, value := range object {
+		if strings.EqualFold(candidate, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
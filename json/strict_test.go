@@ -0,0 +1,112 @@
+package json
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type strictAddress struct {
+	City string `json:"city" required:"true"`
+	Zip  string `json:"zip"`
+}
+
+type strictOrder struct {
+	ID       int64                    `json:"id" required:"true"`
+	Customer string                   `json:"customer_name" required:"true"`
+	Note     string                   `json:"note"`
+	Ship     *strictAddress           `json:"ship"`
+	Lines    []strictAddress          `json:"lines"`
+	ByName   map[string]strictAddress `json:"by_name"`
+}
+
+// missingPaths lists the paths of the *FieldError values joined into err
+func missingPaths(err error) []string {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+	var paths []string
+	for _, e := range joined.Unwrap() {
+		var fieldErr *FieldError
+		if errors.As(e, &fieldErr) {
+			paths = append(paths, fieldErr.Path)
+		}
+	}
+	return paths
+}
+
+func TestStrictUnmarshal(t *testing.T) {
+	got, err := StrictUnmarshal[strictOrder](`{"id":7,"customer_name":"Ann","ship":{"city":"Oslo"},"lines":[{"city":"Rome","zip":"00100"}]}`)
+	if err != nil {
+		t.Fatalf("StrictUnmarshal() error = %v", err)
+	}
+	want := strictOrder{ID: 7, Customer: "Ann", Ship: &strictAddress{City: "Oslo"}, Lines: []strictAddress{{City: "Rome", Zip: "00100"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StrictUnmarshal() = %+v, want %+v", got, want)
+	}
+
+	// field names match case-insensitively, like encoding/json
+	if _, err := StrictUnmarshal[strictOrder](`{"ID":7,"Customer_Name":"Ann"}`); err != nil {
+		t.Errorf("StrictUnmarshal() with differently cased keys error = %v", err)
+	}
+}
+
+func TestStrictUnmarshalMissingFields(t *testing.T) {
+	tests := []struct {
+		name, data    string
+		requiredPaths []string
+		want          []string
+	}{
+		{"top level", `{"note":"x"}`, nil, []string{"id", "customer_name"}},
+		{"null counts as missing", `{"id":null,"customer_name":"Ann"}`, nil, []string{"id"}},
+		{"nested struct", `{"id":1,"customer_name":"Ann","ship":{"zip":"1"}}`, nil, []string{"ship.city"}},
+		{"absent optional struct", `{"id":1,"customer_name":"Ann","ship":null}`, nil, nil},
+		{"inside arrays", `{"id":1,"customer_name":"Ann","lines":[{"city":"a"},{},{"zip":"2"}]}`, nil, []string{"lines[1].city", "lines[2].city"}},
+		{"inside maps", `{"id":1,"customer_name":"Ann","by_name":{"b":{},"a":{"city":"x"}}}`, nil, []string{"by_name.b.city"}},
+		{"required paths", `{"id":1,"customer_name":"Ann","ship":{"city":"x"}}`, []string{"note", "ship.zip"}, []string{"note", "ship.zip"}},
+		{"tags and paths together", `{"customer_name":"Ann"}`, []string{"note"}, []string{"id", "note"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StrictUnmarshal[strictOrder](tt.data, tt.requiredPaths...)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("StrictUnmarshal() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("StrictUnmarshal() returned no error")
+			}
+			if paths := missingPaths(err); !reflect.DeepEqual(paths, tt.want) {
+				t.Errorf("StrictUnmarshal() missing %v, want %v (error: %v)", paths, tt.want, err)
+			}
+			if !reflect.DeepEqual(got, strictOrder{}) {
+				t.Errorf("StrictUnmarshal() on error = %+v, want the zero value", got)
+			}
+		})
+	}
+}
+
+func TestStrictUnmarshalRejects(t *testing.T) {
+	tests := []struct {
+		name, data, wantErr string
+		requiredPaths       []string
+	}{
+		{"unknown field", `{"id":1,"customer_name":"Ann","customerName":"Ann"}`, "unknown field", nil},
+		{"unknown nested field", `{"id":1,"customer_name":"Ann","ship":{"town":"x"}}`, "unknown field", nil},
+		{"trailing data", `{"id":1,"customer_name":"Ann"} {}`, "after top-level value", nil},
+		{"malformed", `{"id":`, "unexpected EOF", nil},
+		{"wildcard path", `{"id":1,"customer_name":"Ann"}`, "wildcards are not supported", []string{"lines[*].zip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := StrictUnmarshal[strictOrder](tt.data, tt.requiredPaths...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("StrictUnmarshal(%s) error = %v, want one containing %q", tt.data, err, tt.wantErr)
+			}
+		})
+	}
+}
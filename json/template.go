@@ -0,0 +1,158 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var ErrMissingTemplateVar = errors.New("missing template variable")
+
+var templatePlaceholder = regexp.MustCompile(`\$\{([^{}]+)\}`)
+
+// RenderJSONTemplate substitutes ${name} placeholders inside the string values of a JSON template.
+// A string that consists of a single placeholder is replaced by the variable's value with its own
+// JSON type (so "${count}" can become 3 or an object); placeholders embedded in longer strings are
+// replaced by the value's text (strings as-is, everything else as JSON). Object keys are not
+// substituted and key order is preserved. A placeholder without a matching variable returns
+// ErrMissingTemplateVar.
+func RenderJSONTemplate(template string, vars map[string]any) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(template))
+	decoder.UseNumber()
+	root, err := decodeOrderedValue(decoder)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", fmt.Errorf("invalid template: unexpected data after top-level value")
+	}
+
+	rendered, err := renderTemplateValue(root, vars)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(rendered)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func renderTemplateValue(value any, vars map[string]any) (any, error) {
+	switch v := value.(type) {
+	case *OrderedMap:
+		for _, key := range v.keys {
+			rendered, err := renderTemplateValue(v.values[key], vars)
+			if err != nil {
+				return nil, err
+			}
+			v.values[key] = rendered
+		}
+		return v, nil
+	case []any:
+		for i, item := range v {
+			rendered, err := renderTemplateValue(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	case string:
+		return renderTemplateString(v, vars)
+	default:
+		return v, nil
+	}
+}
+
+func renderTemplateString(s string, vars map[string]any) (any, error) {
+	if match := templatePlaceholder.FindStringSubmatchIndex(s); match != nil && match[0] == 0 && match[1] == len(s) {
+		name := s[match[2]:match[3]]
+		value, ok := vars[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrMissingTemplateVar, name)
+		}
+		return value, nil
+	}
+
+	var renderErr error
+	out := templatePlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := placeholder[2 : len(placeholder)-1]
+		value, ok := vars[name]
+		if !ok {
+			if renderErr == nil {
+				renderErr = fmt.Errorf("%w: %q", ErrMissingTemplateVar, name)
+			}
+			return placeholder
+		}
+		text, err := stringifyJSONValue(value)
+		if err != nil && renderErr == nil {
+			renderErr = fmt.Errorf("variable %q: %w", name, err)
+		}
+		return text
+	})
+	if renderErr != nil {
+		return nil, renderErr
+	}
+	return out, nil
+}
+
+// stringifyJSONValue renders a value as text: strings as-is, anything else as its JSON encoding
+func stringifyJSONValue(value any) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+var interpolationPlaceholder = regexp.MustCompile(`\{([^{}:]+)(:[^{}]*)?\}`)
+
+// InterpolateFromJSON builds a string from template by replacing each {path} placeholder with the value
+// at that dotted path in data: strings as-is and anything else as JSON, numbers keeping their original spelling.
+// A missing path returns ErrPathNotFound unless the placeholder supplies a fallback as {path:fallback};
+// {path:} falls back to an empty string.
+func InterpolateFromJSON(template, data string) (string, error) {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return "", err
+	}
+
+	var renderErr error
+	out := interpolationPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		match := interpolationPlaceholder.FindStringSubmatch(placeholder)
+		path, fallback := match[1], match[2]
+		segments, err := parsePath(path)
+		if err != nil {
+			if renderErr == nil {
+				renderErr = err
+			}
+			return placeholder
+		}
+		value, ok := lookupPath(root, segments)
+		if !ok {
+			if fallback != "" {
+				return fallback[1:]
+			}
+			if renderErr == nil {
+				renderErr = fmt.Errorf("%w: %q", ErrPathNotFound, path)
+			}
+			return placeholder
+		}
+		text, err := stringifyJSONValue(value)
+		if err != nil && renderErr == nil {
+			renderErr = fmt.Errorf("path %q: %w", path, err)
+		}
+		return text
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+	return out, nil
+}
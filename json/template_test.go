@@ -0,0 +1,92 @@
+package json
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderJSONTemplate(t *testing.T) {
+	vars := map[string]any{
+		"name":  "Ada",
+		"count": 3,
+		"tags":  []string{"a", "b"},
+		"owner": map[string]any{"id": 7},
+		"quote": `say "hi"`,
+	}
+	tests := []struct {
+		name, template, want string
+	}{
+		{"embedded string", `{"greeting":"hello ${name}!"}`, `{"greeting":"hello Ada!"}`},
+		{"embedded number", `{"msg":"${count} items"}`, `{"msg":"3 items"}`},
+		{"embedded array as JSON", `{"msg":"tags=${tags}"}`, `{"msg":"tags=[\"a\",\"b\"]"}`},
+		{"whole-value number", `{"count":"${count}"}`, `{"count":3}`},
+		{"whole-value object", `{"owner":"${owner}","n":"${name}"}`, `{"owner":{"id":7},"n":"Ada"}`},
+		{"quotes stay valid JSON", `{"q":"(${quote})"}`, `{"q":"(say \"hi\")"}`},
+		{"inside arrays", `["${name}","${count}",1]`, `["Ada",3,1]`},
+		{"key order and keys untouched", `{"z":1,"${name}":"${name}","a":2}`, `{"z":1,"${name}":"Ada","a":2}`},
+		{"no placeholders", `{"n":1.50}`, `{"n":1.50}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderJSONTemplate(tt.template, vars)
+			if err != nil {
+				t.Fatalf("RenderJSONTemplate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderJSONTemplate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderJSONTemplateErrors(t *testing.T) {
+	for _, template := range []string{`{"a":"${missing}"}`, `{"a":"x ${missing} y"}`} {
+		if _, err := RenderJSONTemplate(template, map[string]any{}); !errors.Is(err, ErrMissingTemplateVar) {
+			t.Errorf("RenderJSONTemplate(%s) error = %v, want ErrMissingTemplateVar", template, err)
+		}
+	}
+	for _, template := range []string{`{"a":`, `{} []`} {
+		if _, err := RenderJSONTemplate(template, nil); err == nil {
+			t.Errorf("RenderJSONTemplate(%s) returned no error", template)
+		}
+	}
+}
+
+func TestInterpolateFromJSON(t *testing.T) {
+	const order = `{"orderId":"A-17","total":12.50,"count":3,"paid":true,"user":{"name":"Ann","tier":null},"items":[{"sku":"x1"}],"meta":{"k":"v"}}`
+	tests := []struct {
+		name, template, want string
+	}{
+		{"nested paths", "Order {orderId} for {user.name}", "Order A-17 for Ann"},
+		{"numbers rendered as strings", "{count} items, total {total}", "3 items, total 12.50"},
+		{"booleans, null and arrays", "paid={paid} tier={user.tier} first={items[0].sku}", "paid=true tier=null first=x1"},
+		{"objects render as JSON", "meta={meta}", `meta={"k":"v"}`},
+		{"fallback used when missing", "Hi {user.nickname:there}!", "Hi there!"},
+		{"fallback ignored when present", "Hi {user.name:there}!", "Hi Ann!"},
+		{"empty fallback", "[{coupon:}]", "[]"},
+		{"no placeholders", "plain text", "plain text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateFromJSON(tt.template, order)
+			if err != nil {
+				t.Fatalf("InterpolateFromJSON() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("InterpolateFromJSON() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateFromJSONErrors(t *testing.T) {
+	if _, err := InterpolateFromJSON("Hi {user.nickname}", `{"user":{}}`); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("InterpolateFromJSON() with a missing path error = %v, want ErrPathNotFound", err)
+	}
+	if _, err := InterpolateFromJSON("{a[}", `{"a":[1]}`); err == nil {
+		t.Error("InterpolateFromJSON() with an invalid path returned no error")
+	}
+	if _, err := InterpolateFromJSON("{a}", `{"a":`); err == nil {
+		t.Error("InterpolateFromJSON() with invalid JSON returned no error")
+	}
+}
@@ -0,0 +1,220 @@
+package json
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// treeEncoder converts Go values into a tree of JSON-ready values (*OrderedMap for structs, map[string]any,
+// []any, json.RawMessage and scalars) following encoding/json's rules, so callers can tweak how specific
+// values are rendered before the tree is marshaled.
+type treeEncoder struct {
+	// encodeBytes, if set, renders []byte values instead of encoding/json's standard base64
+	encodeBytes func([]byte) string
+	// redactTags drops fields tagged redact:"true" and masks fields with a mask tag
+	redactTags bool
+	// omitZero drops struct fields holding their zero value, as if every field were tagged omitzero
+	omitZero bool
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func (e *treeEncoder) encode(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		if !v.Type().Implements(jsonMarshalerType) && !v.Type().Implements(textMarshalerType) {
+			return e.encode(v.Elem())
+		}
+	}
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) ||
+		(v.CanAddr() && (v.Addr().Type().Implements(jsonMarshalerType) || v.Addr().Type().Implements(textMarshalerType))) {
+		// custom marshalers render themselves
+		target := v
+		if v.CanAddr() {
+			target = v.Addr()
+		}
+		raw, err := json.Marshal(target.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(raw), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	case reflect.Map:
+		return e.encodeMap(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if e.encodeBytes != nil {
+				return e.encodeBytes(v.Bytes()), nil
+			}
+			return v.Interface(), nil
+		}
+		return e.encodeList(v)
+	case reflect.Array:
+		return e.encodeList(v)
+	default:
+		return v.Interface(), nil
+	}
+}
+
+func (e *treeEncoder) encodeList(v reflect.Value) (any, error) {
+	items := make([]any, v.Len())
+	for i := range items {
+		item, err := e.encode(v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func (e *treeEncoder) encodeStruct(v reflect.Value) (any, error) {
+	object := NewOrderedMap()
+	for _, field := range structFields(v.Type()) {
+		fieldValue, ok := fieldByIndexNoAlloc(v, field.index)
+		if !ok || (field.omitEmpty && isEmptyValue(fieldValue)) || ((e.omitZero || field.omitZero) && isZeroValue(fieldValue)) {
+			continue
+		}
+		if e.redactTags && field.redact {
+			continue
+		}
+		value, err := e.encode(fieldValue)
+		if err != nil {
+			return nil, err
+		}
+		if e.redactTags && field.mask != "" {
+			if value, err = maskFieldValue(value, field.mask); err != nil {
+				return nil, fmt.Errorf("field %q: %w", field.name, err)
+			}
+			object.Set(field.name, value)
+			continue
+		}
+		if field.quoted {
+			value = quoteScalar(value)
+		}
+		object.Set(field.name, value)
+	}
+	return object, nil
+}
+
+func (e *treeEncoder) encodeMap(v reflect.Value) (any, error) {
+	if v.IsNil() {
+		return nil, nil
+	}
+	object := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := mapKeyString(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := e.encode(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+	return object, nil
+}
+
+// mapKeyString converts a map key the way encoding/json does
+func mapKeyString(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", key.Type())
+}
+
+// quoteScalar applies the ",string" tag option to a scalar value
+func quoteScalar(value any) any {
+	switch value.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return value
+		}
+		return string(raw)
+	}
+	return value
+}
+
+// fieldByIndexNoAlloc returns the field at index, reporting false if it's behind a nil embedded pointer
+func fieldByIndexNoAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isZeroValue reports whether v is zero the way the omitzero tag option defines it: by the value's
+// IsZero method if it has one (time.Time, or an optional type tracking absence), else reflect's zero value
+func isZeroValue(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return v.IsZero()
+	}
+	if zeroer, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return true
+		}
+		return zeroer.IsZero()
+	}
+	if v.CanAddr() {
+		if zeroer, ok := v.Addr().Interface().(interface{ IsZero() bool }); ok {
+			return zeroer.IsZero()
+		}
+	}
+	return v.IsZero()
+}
+
+// isEmptyValue mirrors encoding/json's definition of empty for omitempty
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
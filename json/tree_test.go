@@ -0,0 +1,207 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type treeEmbedded struct {
+	Shared string `json:"shared"`
+	Inner  int    `json:"inner,omitempty"`
+}
+
+type treeDoc struct {
+	*treeEmbedded
+	Name     string             `json:"name"`
+	Count    int64              `json:"count,string"`
+	Ratio    float64            `json:"ratio,omitempty"`
+	Flag     bool               `json:",string"`
+	Tags     []string           `json:"tags"`
+	NilTags  []string           `json:"nil_tags"`
+	Matrix   [2][2]int          `json:"matrix"`
+	Blob     []byte             `json:"blob"`
+	ByID     map[int]string     `json:"by_id"`
+	ByAddr   map[netip.Addr]int `json:"by_addr"`
+	Raw      json.RawMessage    `json:"raw"`
+	When     time.Time          `json:"when"`
+	Ptr      *int               `json:"ptr"`
+	Any      any                `json:"any"`
+	Skip     string             `json:"-"`
+	Dash     string             `json:"-,"`
+	internal int
+}
+
+func TestTreeEncoderMatchesEncodingJSON(t *testing.T) {
+	n := 3
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"nil", nil},
+		{"scalar", 42},
+		{"html string", "<a & b>"},
+		{"zero struct", treeDoc{}},
+		{"full struct", treeDoc{
+			treeEmbedded: &treeEmbedded{Shared: "s", Inner: 2},
+			Name:         "doc",
+			Count:        1 << 60,
+			Ratio:        0.5,
+			Flag:         true,
+			Tags:         []string{"a", "b"},
+			Matrix:       [2][2]int{{1, 2}, {3, 4}},
+			Blob:         []byte("hello"),
+			ByID:         map[int]string{2: "two", -1: "minus"},
+			ByAddr:       map[netip.Addr]int{netip.MustParseAddr("10.0.0.1"): 1},
+			Raw:          json.RawMessage(`{"keep":[1,2]}`),
+			When:         time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Ptr:          &n,
+			Any:          map[string]any{"nested": []any{1, "x", nil}},
+			Dash:         "dash",
+		}},
+		{"pointer to struct", &treeEmbedded{Shared: "p"}},
+		{"slice of structs", []treeEmbedded{{Shared: "a"}, {Inner: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var encoder treeEncoder
+			tree, err := encoder.encode(reflect.ValueOf(tt.v))
+			if err != nil {
+				t.Fatalf("encode() error = %v", err)
+			}
+			got, err := encodeJSON(tree, true)
+			if err != nil {
+				t.Fatalf("encodeJSON() error = %v", err)
+			}
+			want, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if !jsonEqual(t, got, want) {
+				t.Errorf("tree encodes as\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+// jsonEqual compares two documents ignoring the key order of maps
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var x, y any
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func TestTreeEncoderKeepsFieldOrder(t *testing.T) {
+	var encoder treeEncoder
+	tree, err := encoder.encode(reflect.ValueOf(struct {
+		Z int `json:"z"`
+		A int `json:"a"`
+		M int `json:"m"`
+	}{1, 2, 3}))
+	if err != nil {
+		t.Fatalf("encode() error = %v", err)
+	}
+	if got, _ := encodeJSON(tree, false); string(got) != `{"z":1,"a":2,"m":3}` {
+		t.Errorf("encode() = %s, want declaration order", got)
+	}
+}
+
+func TestTreeEncoderOptions(t *testing.T) {
+	type doc struct {
+		Blob   []byte    `json:"blob"`
+		Empty  []int     `json:"empty"`
+		Zero   int       `json:"zero"`
+		When   time.Time `json:"when"`
+		Secret string    `json:"secret" redact:"true"`
+		Card   string    `json:"card" mask:"last4"`
+	}
+	v := doc{Blob: []byte{0xfb, 0xff}, Empty: []int{}, Card: "4242424242424242"}
+	tests := []struct {
+		name    string
+		encoder treeEncoder
+		want    string
+	}{
+		{"defaults", treeEncoder{}, `{"blob":"+/8=","empty":[],"zero":0,"when":"0001-01-01T00:00:00Z","secret":"","card":"4242424242424242"}`},
+		{"bytes hook", treeEncoder{encodeBytes: func(b []byte) string { return strings.Repeat("x", len(b)) }}, `{"blob":"xx","empty":[],"zero":0,"when":"0001-01-01T00:00:00Z","secret":"","card":"4242424242424242"}`},
+		{"omit zero", treeEncoder{omitZero: true}, `{"blob":"+/8=","empty":[],"card":"4242424242424242"}`},
+		{"redact tags", treeEncoder{redactTags: true}, `{"blob":"+/8=","empty":[],"zero":0,"when":"0001-01-01T00:00:00Z","card":"************4242"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := tt.encoder.encode(reflect.ValueOf(v))
+			if err != nil {
+				t.Fatalf("encode() error = %v", err)
+			}
+			if got, _ := encodeJSON(tree, false); string(got) != tt.want {
+				t.Errorf("encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeEncoderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"unsupported map key", map[float64]int{1.5: 1}},
+		{"failing marshaler", struct{ F failingMarshaler }{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var encoder treeEncoder
+			if _, err := encoder.encode(reflect.ValueOf(tt.v)); err == nil {
+				t.Error("encode() returned no error")
+			}
+		})
+	}
+}
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errTreeMarshal
+}
+
+var errTreeMarshal = errors.New("cannot marshal")
+
+func TestIsZeroAndEmptyValue(t *testing.T) {
+	var nilTime *time.Time
+	tests := []struct {
+		name        string
+		v           any
+		zero, empty bool
+	}{
+		{"empty string", "", true, true},
+		{"empty non-nil slice", []int{}, false, true},
+		{"nil slice", []int(nil), true, true},
+		{"zero struct", struct{ A int }{}, true, false},
+		{"zero time", time.Time{}, true, false},
+		{"non-UTC zero instant", time.Time{}.In(time.FixedZone("x", 3600)), true, false},
+		{"nil pointer with IsZero", nilTime, true, true},
+		{"false", false, true, true},
+		{"zero float", 0.0, true, true},
+		{"value", 1, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := reflect.ValueOf(tt.v)
+			if got := isZeroValue(v); got != tt.zero {
+				t.Errorf("isZeroValue() = %v, want %v", got, tt.zero)
+			}
+			if got := isEmptyValue(v); got != tt.empty {
+				t.Errorf("isEmptyValue() = %v, want %v", got, tt.empty)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package json
+
+import "encoding/json"
+
+// JSONTypeAt returns the JSON type of the value at the dotted path: "object", "array", "string",
+// "number", "boolean" or "null". It returns ErrPathNotFound if nothing exists at path.
+func JSONTypeAt(data, path string) (string, error) {
+	value, err := Get(data, path)
+	if err != nil {
+		return "", err
+	}
+	return jsonTypeOf(value), nil
+}
+
+// jsonTypeOf names the JSON type of a decoded value
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
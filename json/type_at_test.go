@@ -0,0 +1,41 @@
+package json
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJSONTypeAt(t *testing.T) {
+	const doc = `{"user":{"name":"Ada","age":36,"admin":false,"tags":["x"],"manager":null}}`
+	tests := []struct {
+		path, want string
+	}{
+		{"user", "object"},
+		{"user.tags", "array"},
+		{"user.name", "string"},
+		{"user.age", "number"},
+		{"user.admin", "boolean"},
+		{"user.manager", "null"},
+		{"user.tags[0]", "string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := JSONTypeAt(doc, tt.path)
+			if err != nil {
+				t.Fatalf("JSONTypeAt() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("JSONTypeAt(%q) = %s, want %s", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	for _, path := range []string{"user.email", "user.tags[3]", "user.name.first"} {
+		if _, err := JSONTypeAt(doc, path); !errors.Is(err, ErrPathNotFound) {
+			t.Errorf("JSONTypeAt(%q) error = %v, want ErrPathNotFound", path, err)
+		}
+	}
+	if _, err := JSONTypeAt(`{`, "a"); err == nil {
+		t.Error("JSONTypeAt(invalid JSON) returned no error")
+	}
+}
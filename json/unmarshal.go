@@ -0,0 +1,26 @@
+package json
+
+// UnmarshalTo decodes data into a new T, e.g. UnmarshalTo[[]User](body), so no temporary variable is needed
+func UnmarshalTo[T any](data []byte) (T, error) {
+	var v T
+	if err := CurrentBackend().Unmarshal(data, &v); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// SafeUnmarshal decodes data into a new T, reporting false (with T's zero value) if it isn't valid JSON
+// for T. It is the unmarshaling counterpart of SafeMarshalJson.
+func SafeUnmarshal[T any](data string) (T, bool) {
+	v, err := UnmarshalTo[T]([]byte(data))
+	return v, err == nil
+}
+
+// UnmarshalOrDefault decodes data into a new T, returning def if it isn't valid JSON for T
+func UnmarshalOrDefault[T any](data string, def T) T {
+	if v, ok := SafeUnmarshal[T](data); ok {
+		return v
+	}
+	return def
+}
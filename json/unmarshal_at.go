@@ -0,0 +1,26 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalAtPath unmarshals the value at the dotted path into v, e.g. path "data" for a
+// {"data": {...}} envelope, so no wrapper struct is needed. It returns ErrPathNotFound if nothing
+// exists at path, or the json.Unmarshal error if the value can't bind to v.
+func UnmarshalAtPath(data, path string, v any) error {
+	value, err := Get(data, path)
+	if err != nil {
+		return err
+	}
+
+	// numbers were decoded as json.Number, so re-encoding reproduces them exactly
+	sub, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(sub, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package json
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const envelopedResponse = `{"status":"ok","data":{"user":{"id":9007199254740993,"name":"ann","tags":["a","b"]},"items":[{"sku":"x1","qty":2},{"sku":"y2","qty":1}]}}`
+
+type envelopedUser struct {
+	ID   int64    `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+type envelopedItem struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+func TestUnmarshalAtPath(t *testing.T) {
+	var user envelopedUser
+	if err := UnmarshalAtPath(envelopedResponse, "data.user", &user); err != nil {
+		t.Fatalf("UnmarshalAtPath(data.user) error = %v", err)
+	}
+	if want := (envelopedUser{ID: 9007199254740993, Name: "ann", Tags: []string{"a", "b"}}); !reflect.DeepEqual(user, want) {
+		t.Errorf("UnmarshalAtPath(data.user) = %+v, want %+v", user, want)
+	}
+
+	var items []envelopedItem
+	if err := UnmarshalAtPath(envelopedResponse, "data.items", &items); err != nil {
+		t.Fatalf("UnmarshalAtPath(data.items) error = %v", err)
+	}
+	if want := []envelopedItem{{"x1", 2}, {"y2", 1}}; !reflect.DeepEqual(items, want) {
+		t.Errorf("UnmarshalAtPath(data.items) = %+v, want %+v", items, want)
+	}
+
+	var item envelopedItem
+	if err := UnmarshalAtPath(envelopedResponse, "data.items[1]", &item); err != nil || item.SKU != "y2" {
+		t.Errorf("UnmarshalAtPath(data.items[1]) = %+v, %v, want sku y2", item, err)
+	}
+}
+
+func TestUnmarshalAtPathErrors(t *testing.T) {
+	var user envelopedUser
+	if err := UnmarshalAtPath(envelopedResponse, "data.account", &user); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("UnmarshalAtPath() with a missing path error = %v, want ErrPathNotFound", err)
+	}
+	if err := UnmarshalAtPath(envelopedResponse, "data.items", &user); err == nil {
+		t.Error("UnmarshalAtPath() binding an array into a struct returned no error")
+	}
+	if err := UnmarshalAtPath(`{"data":`, "data", &user); err == nil {
+		t.Error("UnmarshalAtPath() with invalid JSON returned no error")
+	}
+}
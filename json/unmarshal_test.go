@@ -0,0 +1,84 @@
+package json
+
+import (
+	"reflect"
+	"testing"
+)
+
+type unmarshalUser struct {
+	ID   int64    `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func TestUnmarshalTo(t *testing.T) {
+	users, err := UnmarshalTo[[]unmarshalUser]([]byte(`[{"id":1,"name":"ann","tags":["a"]},{"id":2,"name":"bob"}]`))
+	if err != nil {
+		t.Fatalf("UnmarshalTo() error = %v", err)
+	}
+	want := []unmarshalUser{{ID: 1, Name: "ann", Tags: []string{"a"}}, {ID: 2, Name: "bob"}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("UnmarshalTo() = %+v, want %+v", users, want)
+	}
+
+	m, err := UnmarshalTo[map[string]int]([]byte(`{"a":1,"b":2}`))
+	if err != nil || !reflect.DeepEqual(m, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("UnmarshalTo[map[string]int]() = %v, %v", m, err)
+	}
+
+	// a partially decoded value must not leak out with the error
+	got, err := UnmarshalTo[unmarshalUser]([]byte(`{"id":7,"name":5}`))
+	if err == nil {
+		t.Fatal("UnmarshalTo() with a type mismatch returned no error")
+	}
+	if !reflect.DeepEqual(got, unmarshalUser{}) {
+		t.Errorf("UnmarshalTo() on error = %+v, want the zero value", got)
+	}
+}
+
+func TestSafeUnmarshal(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		want   unmarshalUser
+		wantOK bool
+	}{
+		{"valid", `{"id":1,"name":"ann"}`, unmarshalUser{ID: 1, Name: "ann"}, true},
+		{"unknown fields ignored", `{"id":1,"extra":true}`, unmarshalUser{ID: 1}, true},
+		{"null", `null`, unmarshalUser{}, true},
+		{"empty", ``, unmarshalUser{}, false},
+		{"malformed", `{"id":`, unmarshalUser{}, false},
+		{"type mismatch", `{"id":"one"}`, unmarshalUser{}, false},
+		{"wrong shape", `[1,2]`, unmarshalUser{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SafeUnmarshal[unmarshalUser](tt.data)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SafeUnmarshal(%s) = %+v, %v, want %+v, %v", tt.data, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestUnmarshalOrDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		def  []int
+		want []int
+	}{
+		{"valid", `[1,2,3]`, []int{9}, []int{1, 2, 3}},
+		{"empty array", `[]`, []int{9}, []int{}},
+		{"malformed", `[1,`, []int{9}, []int{9}},
+		{"wrong type", `{"a":1}`, []int{9}, []int{9}},
+		{"nil default", `nope`, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnmarshalOrDefault(tt.data, tt.def); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalOrDefault(%s) = %v, want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package json
+
+import "strconv"
+
+// WalkJSON calls visit for every scalar node (string, json.Number, bool or nil) in the document,
+// with its dotted/bracketed path such as "items[0].name". Object keys are visited in sorted order.
+// An error returned by visit aborts the walk and is returned.
+func WalkJSON(data string, visit func(path string, value any) error) error {
+	return walkJSON(data, false, visit)
+}
+
+// WalkJSONNodes is like WalkJSON but also visits objects and arrays (before their children);
+// the root container is visited with an empty path.
+func WalkJSONNodes(data string, visit func(path string, value any) error) error {
+	return walkJSON(data, true, visit)
+}
+
+func walkJSON(data string, includeContainers bool, visit func(path string, value any) error) error {
+	root, err := decodeJSONValue(data)
+	if err != nil {
+		return err
+	}
+	return walkValue("", root, includeContainers, visit)
+}
+
+func walkValue(path string, value any, includeContainers bool, visit func(path string, value any) error) error {
+	switch v := value.(type) {
+	case map[string]any:
+		if includeContainers {
+			if err := visit(path, v); err != nil {
+				return err
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			if err := walkValue(joinPath(path, key), v[key], includeContainers, visit); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		if includeContainers {
+			if err := visit(path, v); err != nil {
+				return err
+			}
+		}
+		for i, item := range v {
+			if err := walkValue(indexPath(path, i), item, includeContainers, visit); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return visit(path, v)
+	}
+}
+
+func indexPath(parent string, index int) string {
+	return parent + "[" + strconv.Itoa(index) + "]"
+}
@@ -0,0 +1,61 @@
+package json
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const walkDoc = `{"id":1,"items":[{"name":"a","tags":["x",null]},{"name":"b"}],"meta":{"ok":true}}`
+
+func TestWalkJSON(t *testing.T) {
+	var paths []string
+	err := WalkJSON(walkDoc, func(path string, value any) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkJSON() error = %v", err)
+	}
+	want := []string{"id", "items[0].name", "items[0].tags[0]", "items[0].tags[1]", "items[1].name", "meta.ok"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("WalkJSON() visited %v, want %v", paths, want)
+	}
+}
+
+func TestWalkJSONNodes(t *testing.T) {
+	var paths []string
+	err := WalkJSONNodes(walkDoc, func(path string, value any) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkJSONNodes() error = %v", err)
+	}
+	want := []string{"", "id", "items", "items[0]", "items[0].name", "items[0].tags", "items[0].tags[0]", "items[0].tags[1]", "items[1]", "items[1].name", "meta", "meta.ok"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("WalkJSONNodes() visited %v, want %v", paths, want)
+	}
+}
+
+func TestWalkJSONAbort(t *testing.T) {
+	errFound := errors.New("found")
+	var paths []string
+	err := WalkJSON(walkDoc, func(path string, value any) error {
+		paths = append(paths, path)
+		if value == "a" {
+			return errFound
+		}
+		return nil
+	})
+	if !errors.Is(err, errFound) {
+		t.Fatalf("WalkJSON() error = %v, want the visit error", err)
+	}
+	if want := []string{"id", "items[0].name"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("WalkJSON() visited %v before aborting, want %v", paths, want)
+	}
+
+	if err := WalkJSON(`{"a":`, func(string, any) error { return nil }); err == nil {
+		t.Error("WalkJSON(truncated) returned no error")
+	}
+}
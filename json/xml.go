@@ -0,0 +1,108 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+var ErrInvalidXMLName = errors.New("key is not a valid XML element name")
+
+// xmlArrayItemElement names the elements produced for the items of an array nested directly in another array
+const xmlArrayItemElement = "item"
+
+// JSONToXML converts a JSON object into an XML document whose root element is rootElement, keeping key order:
+//   - each key becomes a child element; keys that aren't valid XML names yield ErrInvalidXMLName
+//   - nested objects become nested elements
+//   - an array becomes one element per item, repeated under the array's key, so an empty array
+//     produces nothing; items of an array nested inside another array are wrapped in <item> elements
+//   - strings, numbers and booleans become escaped text nodes, numbers keeping their JSON form
+//   - null becomes an empty element such as <key/>
+//
+// No attributes are produced. data must be a JSON object, otherwise ErrNotObject is returned.
+func JSONToXML(data string, rootElement string) (string, error) {
+	if !isXMLName(rootElement) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidXMLName, rootElement)
+	}
+	root, err := ParseOrderedJSON(data)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := writeXMLElement(&buf, rootElement, root); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func writeXMLElement(buf *bytes.Buffer, name string, value any) error {
+	if value == nil {
+		fmt.Fprintf(buf, "<%s/>", name)
+		return nil
+	}
+
+	fmt.Fprintf(buf, "<%s>", name)
+	switch v := value.(type) {
+	case *OrderedMap:
+		for _, key := range v.Keys() {
+			if !isXMLName(key) {
+				return fmt.Errorf("%w: %q", ErrInvalidXMLName, key)
+			}
+			child, _ := v.Get(key)
+			if err := writeXMLChildren(buf, key, child); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if err := writeXMLChildren(buf, xmlArrayItemElement, v); err != nil {
+			return err
+		}
+	case string:
+		if err := xml.EscapeText(buf, []byte(v)); err != nil {
+			return err
+		}
+	case json.Number:
+		buf.WriteString(v.String())
+	case bool:
+		fmt.Fprintf(buf, "%t", v)
+	default:
+		return fmt.Errorf("unsupported JSON value of type %T", value)
+	}
+	fmt.Fprintf(buf, "</%s>", name)
+	return nil
+}
+
+// writeXMLChildren writes value as the element name, repeating the element for each item of an array
+func writeXMLChildren(buf *bytes.Buffer, name string, value any) error {
+	items, ok := value.([]any)
+	if !ok {
+		return writeXMLElement(buf, name, value)
+	}
+	for _, item := range items {
+		if err := writeXMLElement(buf, name, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isXMLName reports whether s is usable as an XML element name; namespace prefixes are not supported
+func isXMLName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package json
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJSONToXML(t *testing.T) {
+	tests := []struct {
+		name, data, want string
+	}{
+		{"scalars", `{"name":"ann","age":30,"ratio":1.50,"admin":false}`, `<user><name>ann</name><age>30</age><ratio>1.50</ratio><admin>false</admin></user>`},
+		{"escaped text", `{"note":"a < b & \"c\""}`, `<user><note>a &lt; b &amp; &#34;c&#34;</note></user>`},
+		{"null", `{"email":null}`, `<user><email/></user>`},
+		{"nested objects", `{"address":{"city":"Oslo","geo":{"lat":59.9}}}`, `<user><address><city>Oslo</city><geo><lat>59.9</lat></geo></address></user>`},
+		{"arrays repeat tags", `{"tag":["a","b"],"order":[{"id":1},{"id":2}]}`, `<user><tag>a</tag><tag>b</tag><order><id>1</id></order><order><id>2</id></order></user>`},
+		{"empty array", `{"tag":[]}`, `<user></user>`},
+		{"nested arrays", `{"grid":[[1,2],[3]]}`, `<user><grid><item>1</item><item>2</item></grid><grid><item>3</item></grid></user>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONToXML(tt.data, "user")
+			if err != nil {
+				t.Fatalf("JSONToXML() error = %v", err)
+			}
+			if want := xml.Header + tt.want; got != want {
+				t.Errorf("JSONToXML() = %s, want %s", got, want)
+			}
+			decoder := xml.NewDecoder(strings.NewReader(got))
+			for {
+				if _, err := decoder.Token(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("JSONToXML() output is not well-formed XML: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONToXMLErrors(t *testing.T) {
+	tests := []struct {
+		name, data, root string
+		want             error
+	}{
+		{"invalid root element", `{}`, "1root", ErrInvalidXMLName},
+		{"invalid key", `{"a b":1}`, "root", ErrInvalidXMLName},
+		{"not an object", `[1,2]`, "root", ErrNotObject},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := JSONToXML(tt.data, tt.root); !errors.Is(err, tt.want) {
+				t.Errorf("JSONToXML() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if _, err := JSONToXML(`{"a":`, "root"); err == nil {
+		t.Error("JSONToXML() with invalid JSON returned no error")
+	}
+}
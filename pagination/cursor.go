@@ -0,0 +1,159 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/id_gen"
+	"github.com/Tealseed-Lab/easy_go_lib/json"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	ErrCursorExpired = errors.New("pagination cursor has expired")
+	ErrWeakSecret    = errors.New("cursor secret must be at least 16 bytes")
+)
+
+// Direction is which way a cursor pages from its position
+type Direction string
+
+const (
+	Forward  Direction = "next"
+	Backward Direction = "prev"
+)
+
+// Cursor is a position in a sorted listing: the ID of the last item served, the value of the sort key
+// at that item (K is its type, e.g. time.Time or a struct for compound keys) and the direction to continue in.
+// The ID breaks ties between items with equal sort keys.
+type Cursor[K any] struct {
+	LastID    string    `json:"id"`
+	SortKey   K         `json:"key"`
+	Direction Direction `json:"dir"`
+}
+
+// token is the signed content of an encoded cursor
+type token[K any] struct {
+	Cursor[K]
+	IssuedAt int64 `json:"iat"`
+}
+
+// Codec turns cursors into opaque, tamper-proof tokens safe to hand to API clients and back.
+// Tokens are base64url JSON signed with HMAC-SHA256; they are not encrypted, so don't put anything in
+// a cursor that clients must not see.
+type Codec[K any] struct {
+	secret     []byte
+	maxAge     time.Duration
+	validateID func(id string) error
+}
+
+// CodecOption customizes a Codec
+type CodecOption func(*codecOptions)
+
+type codecOptions struct {
+	maxAge     time.Duration
+	validateID func(id string) error
+}
+
+// WithMaxAge makes Decode reject tokens issued more than d ago with ErrCursorExpired
+func WithMaxAge(d time.Duration) CodecOption {
+	return func(o *codecOptions) {
+		o.maxAge = d
+	}
+}
+
+// WithIDValidator checks LastID on both Encode and Decode, e.g. WithIDValidator(id_gen.ValidateULID)
+func WithIDValidator(validate func(id string) error) CodecOption {
+	return func(o *codecOptions) {
+		o.validateID = validate
+	}
+}
+
+// WithULIDs is WithIDValidator(id_gen.ValidateULID), for listings keyed by this library's sortable IDs
+func WithULIDs() CodecOption {
+	return WithIDValidator(id_gen.ValidateULID)
+}
+
+// NewCodec returns a Codec signing with secret, which must be at least 16 bytes and should be
+// random and kept server-side
+func NewCodec[K any](secret []byte, opts ...CodecOption) (*Codec[K], error) {
+	if len(secret) < 16 {
+		return nil, ErrWeakSecret
+	}
+	var options codecOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Codec[K]{
+		secret:     append([]byte(nil), secret...),
+		maxAge:     options.maxAge,
+		validateID: options.validateID,
+	}, nil
+}
+
+// Encode returns the opaque token for cursor. An empty Direction is encoded as Forward.
+func (c *Codec[K]) Encode(cursor Cursor[K]) (string, error) {
+	if cursor.Direction == "" {
+		cursor.Direction = Forward
+	}
+	if err := c.check(cursor); err != nil {
+		return "", err
+	}
+	payload, err := json.MarshalCanonical(token[K]{Cursor: cursor, IssuedAt: time.Now().Unix()})
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded)), nil
+}
+
+// Decode verifies a token from Encode and returns its cursor. Tokens that were altered, signed with a
+// different secret or are otherwise malformed return ErrInvalidCursor.
+func (c *Codec[K]) Decode(tokenText string) (Cursor[K], error) {
+	var zero Cursor[K]
+	encoded, signature, ok := strings.Cut(tokenText, ".")
+	if !ok {
+		return zero, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded)) {
+		return zero, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return zero, ErrInvalidCursor
+	}
+	decoded, err := json.StrictUnmarshal[token[K]](string(payload), "id", "dir", "iat")
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if c.maxAge > 0 && time.Now().Sub(time.Unix(decoded.IssuedAt, 0)) > c.maxAge {
+		return zero, ErrCursorExpired
+	}
+	if err := c.check(decoded.Cursor); err != nil {
+		return zero, err
+	}
+	return decoded.Cursor, nil
+}
+
+func (c *Codec[K]) check(cursor Cursor[K]) error {
+	if cursor.Direction != Forward && cursor.Direction != Backward {
+		return fmt.Errorf("%w: unknown direction %q", ErrInvalidCursor, cursor.Direction)
+	}
+	if c.validateID != nil {
+		if err := c.validateID(cursor.LastID); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+	}
+	return nil
+}
+
+func (c *Codec[K]) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,196 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tealseed-Lab/easy_go_lib/id_gen"
+	"github.com/Tealseed-Lab/easy_go_lib/json"
+)
+
+var testSecret = []byte("0123456789abcdef-test-secret")
+
+type compoundKey struct {
+	Score int    `json:"score"`
+	Name  string `json:"name"`
+}
+
+func mustCodec[K any](t *testing.T, opts ...CodecOption) *Codec[K] {
+	t.Helper()
+	codec, err := NewCodec[K](testSecret, opts...)
+	if err != nil {
+		t.Fatalf("NewCodec() error = %v", err)
+	}
+	return codec
+}
+
+// signedToken builds a token by hand, so tests can produce payloads Encode never would
+func signedToken[K any](c *Codec[K], payload string) string {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 30, 0, 123000000, time.UTC)
+	t.Run("time key", func(t *testing.T) {
+		codec := mustCodec[time.Time](t)
+		cursor := Cursor[time.Time]{LastID: "order-42", SortKey: createdAt, Direction: Backward}
+		token, err := codec.Encode(cursor)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		got, err := codec.Decode(token)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if got.LastID != cursor.LastID || !got.SortKey.Equal(createdAt) || got.Direction != Backward {
+			t.Errorf("Decode() = %+v, want %+v", got, cursor)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		cursor Cursor[compoundKey]
+		want   Cursor[compoundKey]
+	}{
+		{"forward", Cursor[compoundKey]{"a", compoundKey{10, "x"}, Forward}, Cursor[compoundKey]{"a", compoundKey{10, "x"}, Forward}},
+		{"empty direction is forward", Cursor[compoundKey]{"b", compoundKey{}, ""}, Cursor[compoundKey]{"b", compoundKey{}, Forward}},
+		{"unicode and html", Cursor[compoundKey]{"<id>", compoundKey{-1, "naïve & co"}, Backward}, Cursor[compoundKey]{"<id>", compoundKey{-1, "naïve & co"}, Backward}},
+	}
+	codec := mustCodec[compoundKey](t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := codec.Encode(tt.cursor)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if strings.ContainsAny(token, "+/=") {
+				t.Errorf("Encode() = %q, want unpadded base64url", token)
+			}
+			got, err := codec.Decode(token)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Decode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCodecWeakSecret(t *testing.T) {
+	for _, secret := range [][]byte{nil, []byte("short"), make([]byte, 15)} {
+		if _, err := NewCodec[int](secret); !errors.Is(err, ErrWeakSecret) {
+			t.Errorf("NewCodec(%d bytes) error = %v, want ErrWeakSecret", len(secret), err)
+		}
+	}
+	secret := []byte("0123456789abcdef")
+	codec, err := NewCodec[int](secret)
+	if err != nil {
+		t.Fatalf("NewCodec(16 bytes) error = %v", err)
+	}
+	token, _ := codec.Encode(Cursor[int]{LastID: "1", SortKey: 1})
+	secret[0] = 'X'
+	if _, err := codec.Decode(token); err != nil {
+		t.Errorf("Decode() after the caller changed its secret slice error = %v, want the codec to keep a copy", err)
+	}
+}
+
+func TestDecodeRejectsTampering(t *testing.T) {
+	codec := mustCodec[int](t)
+	token, err := codec.Encode(Cursor[int]{LastID: "7", SortKey: 100})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"dir":"next","iat":1,"id":"8","key":100}`))
+	other, _ := NewCodec[int]([]byte("a-different-secret-value"))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no separator", payload},
+		{"forged payload", forged + "." + signature},
+		{"truncated signature", payload + "." + signature[:10]},
+		{"signature not base64", payload + ".!!!"},
+		{"payload not base64", "!!!." + base64.RawURLEncoding.EncodeToString(codec.sign("!!!"))},
+		{"signed by another secret", func() string { s, _ := other.Encode(Cursor[int]{LastID: "7"}); return s }()},
+		{"signed garbage", signedToken(codec, "not json")},
+		{"signed unknown field", signedToken(codec, `{"dir":"next","iat":1,"id":"7","key":1,"admin":true}`)},
+		{"signed without direction", signedToken(codec, `{"iat":1,"id":"7","key":1}`)},
+		{"signed bad direction", signedToken(codec, `{"dir":"sideways","iat":1,"id":"7","key":1}`)},
+		{"signed wrong key type", signedToken(codec, `{"dir":"next","iat":1,"id":"7","key":"one"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := codec.Decode(tt.token); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode(%q) error = %v, want ErrInvalidCursor", tt.token, err)
+			}
+		})
+	}
+}
+
+func TestEncodeRejectsUnknownDirection(t *testing.T) {
+	codec := mustCodec[int](t)
+	if _, err := codec.Encode(Cursor[int]{LastID: "1", Direction: "up"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Encode() error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestDecodeMaxAge(t *testing.T) {
+	codec := mustCodec[int](t, WithMaxAge(time.Hour))
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		wantErr  error
+	}{
+		{"fresh", time.Now(), nil},
+		{"just inside", time.Now().Add(-59 * time.Minute), nil},
+		{"expired", time.Now().Add(-2 * time.Hour), ErrCursorExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := json.MarshalCanonical(token[int]{Cursor: Cursor[int]{LastID: "1", Direction: Forward}, IssuedAt: tt.issuedAt.Unix()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := codec.Decode(signedToken(codec, payload)); !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// without WithMaxAge old tokens stay valid
+	unlimited := mustCodec[int](t)
+	old := signedToken(unlimited, `{"dir":"next","iat":1,"id":"1","key":0}`)
+	if _, err := unlimited.Decode(old); err != nil {
+		t.Errorf("Decode() of an old token without a max age error = %v", err)
+	}
+}
+
+func TestULIDValidation(t *testing.T) {
+	codec := mustCodec[int64](t, WithULIDs())
+	id := id_gen.GenerateSortableId()
+	token, err := codec.Encode(Cursor[int64]{LastID: id, SortKey: 5})
+	if err != nil {
+		t.Fatalf("Encode() with a ULID error = %v", err)
+	}
+	if got, err := codec.Decode(token); err != nil || got.LastID != id {
+		t.Errorf("Decode() = %+v, %v, want the ULID back", got, err)
+	}
+
+	if _, err := codec.Encode(Cursor[int64]{LastID: "not-a-ulid"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Encode() with a bad ID error = %v, want ErrInvalidCursor", err)
+	}
+	// a token from a codec without the validator still fails validation on decode
+	lax := mustCodec[int64](t)
+	laxToken, _ := lax.Encode(Cursor[int64]{LastID: "123"})
+	if _, err := codec.Decode(laxToken); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Decode() with a bad ID error = %v, want ErrInvalidCursor", err)
+	}
+}
@@ -0,0 +1,118 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrExecutorClosed = errors.New("executor is shut down")
+
+// Executor runs fire-and-forget tasks on a fixed number of goroutines, queueing up to a bounded number
+// of pending tasks. It is safe for concurrent use.
+type Executor struct {
+	tasks  chan func(ctx context.Context)
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	closing   chan struct{}
+	closeOnce sync.Once
+	mutex     sync.RWMutex
+	closed    bool
+}
+
+// NewExecutor starts workers goroutines (at least 1) serving a queue of queueSize pending tasks
+// (0 makes Submit wait for an idle worker)
+func NewExecutor(workers, queueSize int) *Executor {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &Executor{
+		tasks:   make(chan func(ctx context.Context), max(queueSize, 0)),
+		ctx:     ctx,
+		cancel:  cancel,
+		closing: make(chan struct{}),
+	}
+	workers = max(workers, 1)
+	e.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go e.work()
+	}
+	return e
+}
+
+func (e *Executor) work() {
+	defer e.wg.Done()
+	for task := range e.tasks {
+		task(e.ctx)
+	}
+}
+
+// Submit queues task, waiting while the queue is full. It returns ctx.Err() if ctx is done first, and
+// ErrExecutorClosed once Shutdown has been called, including while it is waiting. The context passed
+// to task is canceled if Shutdown gives up waiting for running tasks.
+func (e *Executor) Submit(ctx context.Context, task func(ctx context.Context)) error {
+	// The read lock keeps Shutdown from closing tasks under a pending send; closing wakes that send up
+	// before Shutdown asks for the write lock.
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	if e.closed {
+		return ErrExecutorClosed
+	}
+	select {
+	case <-e.closing:
+		return ErrExecutorClosed
+	default:
+	}
+	select {
+	case e.tasks <- task:
+		return nil
+	case <-e.closing:
+		return ErrExecutorClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrySubmit queues task only if there is room right away, reporting whether it did
+func (e *Executor) TrySubmit(task func(ctx context.Context)) bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	if e.closed {
+		return false
+	}
+	select {
+	case <-e.closing:
+		return false
+	case e.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown stops accepting tasks and waits for the queued and running ones to finish. If ctx is done
+// first it cancels the context handed to tasks and returns ctx.Err() without waiting further.
+// Calling Shutdown again waits again.
+func (e *Executor) Shutdown(ctx context.Context) error {
+	e.closeOnce.Do(func() { close(e.closing) })
+	e.mutex.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.tasks)
+	}
+	e.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		e.cancel()
+		return nil
+	case <-ctx.Done():
+		e.cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,171 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecutorRunsQueuedTasksBeforeShutdown(t *testing.T) {
+	tests := []struct {
+		name           string
+		workers, queue int
+		tasks          int
+	}{
+		{"single worker", 1, 10, 10},
+		{"unbuffered", 4, 0, 20},
+		{"workers below one", 0, 5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExecutor(tt.workers, tt.queue)
+			var done atomic.Int32
+			for i := 0; i < tt.tasks; i++ {
+				err := e.Submit(context.Background(), func(context.Context) {
+					time.Sleep(time.Millisecond)
+					done.Add(1)
+				})
+				if err != nil {
+					t.Fatalf("Submit() error = %v", err)
+				}
+			}
+			if err := e.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Shutdown() error = %v", err)
+			}
+			if got := int(done.Load()); got != tt.tasks {
+				t.Errorf("Shutdown() returned after %d of %d tasks", got, tt.tasks)
+			}
+		})
+	}
+}
+
+func TestExecutorRejectsAfterShutdown(t *testing.T) {
+	e := NewExecutor(1, 1)
+	if err := e.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := e.Submit(context.Background(), func(context.Context) {}); !errors.Is(err, ErrExecutorClosed) {
+		t.Errorf("Submit() after Shutdown error = %v, want ErrExecutorClosed", err)
+	}
+	if e.TrySubmit(func(context.Context) {}) {
+		t.Error("TrySubmit() after Shutdown = true, want false")
+	}
+	if err := e.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown() error = %v", err)
+	}
+}
+
+// blockedExecutor returns an executor whose only worker is stuck and whose queue of one is full
+func blockedExecutor(t *testing.T) (*Executor, chan struct{}) {
+	t.Helper()
+	release, started := make(chan struct{}), make(chan struct{})
+	e := NewExecutor(1, 1)
+	if err := e.Submit(context.Background(), func(context.Context) { close(started); <-release }); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	<-started
+	if !e.TrySubmit(func(context.Context) {}) {
+		t.Fatal("TrySubmit() into an empty queue = false")
+	}
+	return e, release
+}
+
+func TestExecutorFullQueue(t *testing.T) {
+	e, release := blockedExecutor(t)
+	defer func() {
+		close(release)
+		e.Shutdown(context.Background())
+	}()
+
+	if e.TrySubmit(func(context.Context) {}) {
+		t.Error("TrySubmit() into a full queue = true, want false")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := e.Submit(ctx, func(context.Context) {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Submit() into a full queue error = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestExecutorShutdownWakesWaitingSubmit(t *testing.T) {
+	e, release := blockedExecutor(t)
+	submitted := make(chan error, 1)
+	go func() { submitted <- e.Submit(context.Background(), func(context.Context) {}) }()
+	time.Sleep(5 * time.Millisecond)
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- e.Shutdown(context.Background()) }()
+	select {
+	case err := <-submitted:
+		if !errors.Is(err, ErrExecutorClosed) {
+			t.Errorf("waiting Submit() error = %v, want ErrExecutorClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown() did not wake a waiting Submit()")
+	}
+	close(release)
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
+
+func TestExecutorShutdownTimeoutCancelsTasks(t *testing.T) {
+	e := NewExecutor(1, 0)
+	canceled := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(context.Background(), func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := e.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want DeadlineExceeded", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("the running task's context was not canceled")
+	}
+	if err := e.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() after the task exited error = %v", err)
+	}
+}
+
+func TestExecutorConcurrentSubmitAndShutdown(t *testing.T) {
+	e := NewExecutor(4, 8)
+	var accepted, ran atomic.Int32
+	stop := make(chan struct{})
+	var submitters sync.WaitGroup
+	submitters.Add(8)
+	for g := 0; g < 8; g++ {
+		go func() {
+			defer submitters.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if e.Submit(context.Background(), func(context.Context) { ran.Add(1) }) == nil {
+					accepted.Add(1)
+				}
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := e.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	close(stop)
+	submitters.Wait()
+	if accepted.Load() != ran.Load() {
+		t.Errorf("%d tasks accepted but %d ran", accepted.Load(), ran.Load())
+	}
+}
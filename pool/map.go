@@ -0,0 +1,70 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ItemError is the failure of one item passed to Map
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// Map calls fn on every item using at most workers goroutines and returns the results in the order of
+// items. If any calls fail, the error joins an *ItemError for each of them in index order and the
+// results of the failed items are left as R's zero value; the other calls still run. Once ctx is done,
+// items that haven't started are failed with ctx.Err() instead of being passed to fn.
+// A workers value below 1 is treated as 1.
+func Map[T, R any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	workers = min(max(workers, 1), len(items))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				results[i], errs[i] = fn(ctx, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &ItemError{Index: i, Err: err})
+		}
+	}
+	return results, errors.Join(failed...)
+}
+
+// ForEach is Map for functions without a result
+func ForEach[T any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) error) error {
+	_, err := Map(ctx, items, workers, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	})
+	return err
+}
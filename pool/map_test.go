@@ -0,0 +1,150 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMapOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []int
+		workers int
+	}{
+		{"empty", nil, 4},
+		{"workers below one", []int{1, 2, 3}, 0},
+		{"fewer items than workers", []int{1, 2}, 8},
+		{"many items", []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0, 11, 12}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Map(context.Background(), tt.items, tt.workers, func(_ context.Context, v int) (string, error) {
+				// later items finish first, so ordering can't come from completion order
+				time.Sleep(time.Duration(len(tt.items)-v%len(tt.items)) * 100 * time.Microsecond)
+				return strconv.Itoa(v), nil
+			})
+			if err != nil {
+				t.Fatalf("Map() error = %v", err)
+			}
+			if len(got) != len(tt.items) {
+				t.Fatalf("Map() returned %d results, want %d", len(got), len(tt.items))
+			}
+			for i, v := range tt.items {
+				if got[i] != strconv.Itoa(v) {
+					t.Errorf("Map()[%d] = %q, want %q", i, got[i], strconv.Itoa(v))
+				}
+			}
+		})
+	}
+}
+
+func TestMapLimitsWorkers(t *testing.T) {
+	tests := []struct {
+		workers, items, wantMax int
+	}{
+		{1, 10, 1},
+		{3, 30, 3},
+		{10, 4, 4},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.workers), func(t *testing.T) {
+			var running, peak atomic.Int32
+			_, err := Map(context.Background(), make([]int, tt.items), tt.workers, func(context.Context, int) (int, error) {
+				n := running.Add(1)
+				for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+				}
+				time.Sleep(2 * time.Millisecond)
+				running.Add(-1)
+				return 0, nil
+			})
+			if err != nil {
+				t.Fatalf("Map() error = %v", err)
+			}
+			if got := int(peak.Load()); got != tt.wantMax {
+				t.Errorf("Map() ran %d calls at once, want %d", got, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestMapErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	var calls atomic.Int32
+	results, err := Map(context.Background(), []int{0, 1, 2, 3, 4}, 2, func(_ context.Context, v int) (int, error) {
+		calls.Add(1)
+		if v%2 == 1 {
+			return -1, errOdd
+		}
+		return v * 10, nil
+	})
+	if calls.Load() != 5 {
+		t.Errorf("Map() made %d calls, want every item to run despite failures", calls.Load())
+	}
+	if want := []int{0, -1, 20, -1, 40}; len(results) != len(want) {
+		t.Fatalf("Map() = %v, want %v", results, want)
+	}
+	for i, want := range []int{0, -1, 20, -1, 40} {
+		if results[i] != want {
+			t.Errorf("Map()[%d] = %d, want %d", i, results[i], want)
+		}
+	}
+
+	if !errors.Is(err, errOdd) {
+		t.Fatalf("Map() error = %v, want it to wrap errOdd", err)
+	}
+	if want := "item 1: odd\nitem 3: odd"; err.Error() != want {
+		t.Errorf("Map() error = %q, want %q", err.Error(), want)
+	}
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 1 {
+		t.Errorf("errors.As() = %+v, want the first failed index", itemErr)
+	}
+}
+
+func TestMapContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	_, err := Map(ctx, make([]int, 20), 1, func(context.Context, int) (int, error) {
+		if calls.Add(1) == 3 {
+			cancel()
+		}
+		return 0, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Map() error = %v, want context.Canceled", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Map() made %d calls, want the items after cancellation skipped", calls.Load())
+	}
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 3 {
+		t.Errorf("first failed item = %+v, want index 3", itemErr)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var sum atomic.Int64
+	err := ForEach(context.Background(), []int64{1, 2, 3, 4}, 2, func(_ context.Context, v int64) error {
+		sum.Add(v)
+		return nil
+	})
+	if err != nil || sum.Load() != 10 {
+		t.Errorf("ForEach() = %v with sum %d, want nil and 10", err, sum.Load())
+	}
+
+	errBoom := errors.New("boom")
+	err = ForEach(context.Background(), []int{1, 2}, 2, func(_ context.Context, v int) error {
+		if v == 2 {
+			return errBoom
+		}
+		return nil
+	})
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 1 || !errors.Is(err, errBoom) {
+		t.Errorf("ForEach() error = %v, want item 1 failing with errBoom", err)
+	}
+}
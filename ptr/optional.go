@@ -0,0 +1,134 @@
+package ptr
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type optionalState uint8
+
+const (
+	stateAbsent optionalState = iota
+	stateNull
+	stateValue
+)
+
+// Optional holds a value that distinguishes "absent" from "null" from "set", which a *T cannot: in a
+// PATCH body, a missing field leaves the stored value alone, null clears it and a value replaces it.
+// The zero Optional is absent.
+//
+// Decoding with encoding/json (or this library's json package) leaves fields missing from the input
+// absent, turns null into a null Optional and anything else into a set one. When encoding, absent and
+// null both marshal as null unless the field is tagged omitzero: IsZero reports absence, so
+// json.MarshalWithOptions (and encoding/json from Go 1.24 on) leaves absent fields out.
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// Some returns an Optional holding v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, state: stateValue}
+}
+
+// Null returns an Optional that is present but explicitly null
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: stateNull}
+}
+
+// FromPtr returns Some(*p), or Null if p is nil
+func FromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return Null[T]()
+	}
+	return Some(*p)
+}
+
+// IsPresent reports whether the Optional was set, to a value or to null
+func (o Optional[T]) IsPresent() bool {
+	return o.state != stateAbsent
+}
+
+// IsNull reports whether the Optional was explicitly set to null
+func (o Optional[T]) IsNull() bool {
+	return o.state == stateNull
+}
+
+// HasValue reports whether the Optional holds a (non-null) value
+func (o Optional[T]) HasValue() bool {
+	return o.state == stateValue
+}
+
+// IsZero reports whether the Optional is absent, which lets omitzero leave it out of encoded JSON
+func (o Optional[T]) IsZero() bool {
+	return o.state == stateAbsent
+}
+
+// Get returns the value and whether there is one
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == stateValue
+}
+
+// OrElse returns the value, or fallback if the Optional is absent or null
+func (o Optional[T]) OrElse(fallback T) T {
+	if o.state != stateValue {
+		return fallback
+	}
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if the Optional is absent or null
+func (o Optional[T]) Ptr() *T {
+	if o.state != stateValue {
+		return nil
+	}
+	return To(o.value)
+}
+
+// ApplyTo applies a partial update to *dst: a value replaces it, null resets it to T's zero value
+// and absence leaves it alone. It reports whether *dst was written.
+func (o Optional[T]) ApplyTo(dst *T) bool {
+	switch o.state {
+	case stateValue:
+		*dst = o.value
+	case stateNull:
+		var zero T
+		*dst = zero
+	default:
+		return false
+	}
+	return true
+}
+
+// ApplyToPtr is ApplyTo for nullable fields stored as *T: null sets *dst to nil
+func (o Optional[T]) ApplyToPtr(dst **T) bool {
+	switch o.state {
+	case stateValue:
+		*dst = To(o.value)
+	case stateNull:
+		*dst = nil
+	default:
+		return false
+	}
+	return true
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != stateValue {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Null[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
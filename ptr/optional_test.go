@@ -0,0 +1,189 @@
+package ptr
+
+import (
+	"encoding/json"
+	"testing"
+
+	easyjson "github.com/Tealseed-Lab/easy_go_lib/json"
+)
+
+type patchUser struct {
+	Name     Optional[string] `json:"name"`
+	Nickname Optional[string] `json:"nickname"`
+	Age      Optional[int]    `json:"age"`
+}
+
+func TestOptionalStates(t *testing.T) {
+	tests := []struct {
+		name                            string
+		o                               Optional[int]
+		present, null, hasValue, isZero bool
+		orElse                          int
+	}{
+		{"absent", Optional[int]{}, false, false, false, true, -1},
+		{"null", Null[int](), true, true, false, false, -1},
+		{"value", Some(3), true, false, true, false, 3},
+		{"zero value", Some(0), true, false, true, false, 0},
+		{"from nil pointer", FromPtr[int](nil), true, true, false, false, -1},
+		{"from pointer", FromPtr(To(4)), true, false, true, false, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.o
+			if o.IsPresent() != tt.present || o.IsNull() != tt.null || o.HasValue() != tt.hasValue || o.IsZero() != tt.isZero {
+				t.Errorf("IsPresent/IsNull/HasValue/IsZero = %v/%v/%v/%v, want %v/%v/%v/%v",
+					o.IsPresent(), o.IsNull(), o.HasValue(), o.IsZero(), tt.present, tt.null, tt.hasValue, tt.isZero)
+			}
+			if got := o.OrElse(-1); got != tt.orElse {
+				t.Errorf("OrElse(-1) = %d, want %d", got, tt.orElse)
+			}
+			if v, ok := o.Get(); ok != tt.hasValue || (ok && v != tt.orElse) {
+				t.Errorf("Get() = %d, %v, want %d, %v", v, ok, tt.orElse, tt.hasValue)
+			}
+			if p := o.Ptr(); (p != nil) != tt.hasValue || (p != nil && *p != tt.orElse) {
+				t.Errorf("Ptr() = %v, want a value only when set", p)
+			}
+		})
+	}
+}
+
+func TestOptionalApplyTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		o           Optional[string]
+		want        string
+		wantPtr     *string
+		wantWritten bool
+	}{
+		{"absent leaves alone", Optional[string]{}, "old", To("old"), false},
+		{"null resets", Null[string](), "", nil, true},
+		{"value replaces", Some("new"), "new", To("new"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := "old"
+			if written := tt.o.ApplyTo(&dst); written != tt.wantWritten || dst != tt.want {
+				t.Errorf("ApplyTo() = %v leaving %q, want %v leaving %q", written, dst, tt.wantWritten, tt.want)
+			}
+
+			dstPtr := To("old")
+			written := tt.o.ApplyToPtr(&dstPtr)
+			if written != tt.wantWritten || (dstPtr == nil) != (tt.wantPtr == nil) || (dstPtr != nil && *dstPtr != *tt.wantPtr) {
+				t.Errorf("ApplyToPtr() = %v leaving %v, want %v leaving %v", written, dstPtr, tt.wantWritten, tt.wantPtr)
+			}
+		})
+	}
+}
+
+func TestOptionalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		// each state is "absent", "null" or the expected value
+		userName, nickname string
+		age                any
+	}{
+		{"empty body", `{}`, "absent", "absent", "absent"},
+		{"nulls", `{"name":null,"nickname":null,"age":null}`, "null", "null", "null"},
+		{"values", `{"name":"Ann","nickname":"","age":0}`, "Ann", "", 0},
+		{"mixed", `{"name":"Bob","age": null }`, "Bob", "absent", "null"},
+	}
+	check := func(t *testing.T, field string, o Optional[string], want string) {
+		t.Helper()
+		switch want {
+		case "absent":
+			if o.IsPresent() {
+				t.Errorf("%s = %+v, want absent", field, o)
+			}
+		case "null":
+			if !o.IsNull() {
+				t.Errorf("%s = %+v, want null", field, o)
+			}
+		default:
+			if v, ok := o.Get(); !ok || v != want {
+				t.Errorf("%s = %+v, want %q", field, o, want)
+			}
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, decode := range []struct {
+				name string
+				fn   func(string) (patchUser, error)
+			}{
+				{"encoding/json", func(s string) (patchUser, error) {
+					var u patchUser
+					err := json.Unmarshal([]byte(s), &u)
+					return u, err
+				}},
+				{"easy_go_lib/json", func(s string) (patchUser, error) { return easyjson.UnmarshalTo[patchUser]([]byte(s)) }},
+			} {
+				u, err := decode.fn(tt.body)
+				if err != nil {
+					t.Fatalf("%s: Unmarshal() error = %v", decode.name, err)
+				}
+				check(t, decode.name+" name", u.Name, tt.userName)
+				check(t, decode.name+" nickname", u.Nickname, tt.nickname)
+				switch want := tt.age.(type) {
+				case string:
+					if (want == "absent") == u.Age.IsPresent() || (want == "null") != u.Age.IsNull() {
+						t.Errorf("%s age = %+v, want %s", decode.name, u.Age, want)
+					}
+				case int:
+					if v, ok := u.Age.Get(); !ok || v != want {
+						t.Errorf("%s age = %+v, want %d", decode.name, u.Age, want)
+					}
+				}
+			}
+		})
+	}
+
+	var u patchUser
+	if err := json.Unmarshal([]byte(`{"age":"old"}`), &u); err == nil {
+		t.Error("Unmarshal() of a string into Optional[int] returned no error")
+	}
+}
+
+func TestOptionalMarshal(t *testing.T) {
+	type tagged struct {
+		Name Optional[string] `json:"name,omitzero"`
+		Age  Optional[int]    `json:"age,omitzero"`
+		Note Optional[string] `json:"note"`
+	}
+	tests := []struct {
+		name string
+		v    tagged
+		want string
+	}{
+		{"absent omitted, untagged null", tagged{}, `{"note":null}`},
+		{"null kept", tagged{Name: Null[string](), Note: Some("hi")}, `{"name":null,"note":"hi"}`},
+		{"values", tagged{Name: Some("Ann"), Age: Some(0), Note: Null[string]()}, `{"name":"Ann","age":0,"note":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := easyjson.MarshalWithOptions(tt.v, easyjson.MarshalOptions{})
+			if err != nil {
+				t.Fatalf("MarshalWithOptions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MarshalWithOptions() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionalRoundTrip(t *testing.T) {
+	for _, o := range []Optional[[]int]{Some([]int{1, 2}), Null[[]int]()} {
+		data, err := json.Marshal(o)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		var back Optional[[]int]
+		if err := json.Unmarshal(data, &back); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", data, err)
+		}
+		if back.IsNull() != o.IsNull() || len(back.OrElse(nil)) != len(o.OrElse(nil)) {
+			t.Errorf("round trip of %s = %+v, want %+v", data, back, o)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package ptr
+
+// To returns a pointer to a copy of v, for filling optional fields from literals and constants
+func To[T any](v T) *T {
+	return &v
+}
+
+// Deref returns *p, or fallback if p is nil
+func Deref[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+	return *p
+}
+
+// DerefZero returns *p, or T's zero value if p is nil
+func DerefZero[T any](p *T) T {
+	var zero T
+	return Deref(p, zero)
+}
+
+// ToOrNil returns a pointer to v, or nil if v is T's zero value, e.g. to map "" to an absent optional field
+func ToOrNil[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,59 @@
+package ptr
+
+import "testing"
+
+func TestTo(t *testing.T) {
+	v := 5
+	p := To(v)
+	if p == &v || *p != 5 {
+		t.Errorf("To(5) = %p -> %d, want a pointer to a copy", p, *p)
+	}
+	if s := To("x"); *s != "x" {
+		t.Errorf("To(\"x\") = %q, want x", *s)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *int
+		fallback int
+		want     int
+	}{
+		{"nil", nil, 7, 7},
+		{"value", To(3), 7, 3},
+		{"zero value is not nil", To(0), 7, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Deref(tt.p, tt.fallback); got != tt.want {
+				t.Errorf("Deref() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if got := DerefZero[string](nil); got != "" {
+		t.Errorf("DerefZero(nil) = %q, want empty", got)
+	}
+	if got := DerefZero(To("set")); got != "set" {
+		t.Errorf("DerefZero() = %q, want set", got)
+	}
+}
+
+func TestToOrNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       string
+		wantNil bool
+	}{
+		{"zero", "", true},
+		{"value", "a", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToOrNil(tt.v)
+			if (got == nil) != tt.wantNil || (got != nil && *got != tt.v) {
+				t.Errorf("ToOrNil(%q) = %v, wantNil %v", tt.v, got, tt.wantNil)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// KeyedOption configures a KeyedLimiter
+type KeyedOption func(*keyedConfig)
+
+type keyedConfig struct {
+	idleTimeout time.Duration
+}
+
+// WithIdleTimeout evicts the bucket of a key that hasn't been used for d (default 10 minutes).
+// A bucket idle for longer than it takes to refill is indistinguishable from a new one, so eviction
+// never changes what is allowed as long as d is at least burst / limit.
+func WithIdleTimeout(d time.Duration) KeyedOption {
+	return func(c *keyedConfig) {
+		c.idleTimeout = d
+	}
+}
+
+// KeyedLimiter keeps a separate token bucket per key, such as a user or tenant ID, all with the same
+// limit and burst. Buckets are created on first use and evicted by a background goroutine once idle;
+// call Close to stop it. It is safe for concurrent use.
+type KeyedLimiter[K comparable] struct {
+	limit       Limit
+	burst       int
+	idleTimeout time.Duration
+
+	mutex   sync.Mutex
+	buckets map[K]*keyedBucket
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+type keyedBucket struct {
+	limiter  *Limiter
+	lastUsed time.Time
+}
+
+// NewKeyedLimiter returns a limiter allowing limit events per second with bursts of burst per key
+func NewKeyedLimiter[K comparable](limit Limit, burst int, opts ...KeyedOption) *KeyedLimiter[K] {
+	cfg := keyedConfig{idleTimeout: 10 * time.Minute}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	k := &KeyedLimiter[K]{
+		limit:       limit,
+		burst:       burst,
+		idleTimeout: cfg.idleTimeout,
+		buckets:     map[K]*keyedBucket{},
+		stop:        make(chan struct{}),
+	}
+	if cfg.idleTimeout > 0 {
+		go k.evictIdle()
+	}
+	return k
+}
+
+// Allow reports whether an event for key may happen now
+func (k *KeyedLimiter[K]) Allow(key K) bool {
+	return k.bucket(key).Allow()
+}
+
+// AllowN reports whether n events for key may happen now
+func (k *KeyedLimiter[K]) AllowN(key K, n int) bool {
+	return k.bucket(key).AllowN(n)
+}
+
+// Wait blocks until an event for key may happen, like Limiter.Wait
+func (k *KeyedLimiter[K]) Wait(ctx context.Context, key K) error {
+	return k.bucket(key).Wait(ctx)
+}
+
+// WaitN blocks until n events for key may happen, like Limiter.WaitN
+func (k *KeyedLimiter[K]) WaitN(ctx context.Context, key K, n int) error {
+	return k.bucket(key).WaitN(ctx, n)
+}
+
+// Len returns the number of buckets currently held
+func (k *KeyedLimiter[K]) Len() int {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return len(k.buckets)
+}
+
+// Close stops the eviction goroutine; buckets are then kept until the limiter is garbage collected
+func (k *KeyedLimiter[K]) Close() {
+	k.closeOnce.Do(func() { close(k.stop) })
+}
+
+func (k *KeyedLimiter[K]) bucket(key K) *Limiter {
+	now := time.Now()
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	b, ok := k.buckets[key]
+	if !ok {
+		b = &keyedBucket{limiter: NewLimiter(k.limit, k.burst)}
+		k.buckets[key] = b
+	}
+	b.lastUsed = now
+	return b.limiter
+}
+
+func (k *KeyedLimiter[K]) evictIdle() {
+	ticker := time.NewTicker(max(k.idleTimeout/2, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-k.stop:
+			return
+		case now := <-ticker.C:
+			k.sweep(now)
+		}
+	}
+}
+
+// sweep drops the buckets idle for at least the idle timeout. A bucket in debt is kept however long
+// it has been idle: a waiter may still be counting on its reservation, and a fresh bucket would let
+// the key burst again before that reservation is paid off.
+func (k *KeyedLimiter[K]) sweep(now time.Time) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	for key, b := range k.buckets {
+		if now.Sub(b.lastUsed) >= k.idleTimeout && b.limiter.Tokens() >= 0 {
+			delete(k.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyedLimiterSeparatesKeys(t *testing.T) {
+	k := NewKeyedLimiter[string](Every(time.Hour), 2)
+	defer k.Close()
+
+	tests := []struct {
+		key  string
+		n    int
+		want bool
+	}{
+		{"alice", 2, true},
+		{"alice", 1, false},
+		{"bob", 1, true},
+		{"bob", 1, true},
+		{"bob", 1, false},
+		{"carol", 3, false},
+	}
+	for _, tt := range tests {
+		if got := k.AllowN(tt.key, tt.n); got != tt.want {
+			t.Errorf("AllowN(%q, %d) = %v, want %v", tt.key, tt.n, got, tt.want)
+		}
+	}
+	if k.Len() != 3 {
+		t.Errorf("Len() = %d, want a bucket per key", k.Len())
+	}
+	if !k.Allow("dave") {
+		t.Error("Allow() on a new key = false")
+	}
+}
+
+func TestKeyedLimiterWait(t *testing.T) {
+	k := NewKeyedLimiter[int](100, 1)
+	defer k.Close()
+	if err := k.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := k.WaitN(ctx, 1, 1); err == nil {
+		t.Error("WaitN() past the deadline returned no error")
+	}
+	if err := k.WaitN(context.Background(), 2, 1); err != nil {
+		t.Errorf("WaitN() on another key error = %v", err)
+	}
+}
+
+func TestKeyedLimiterEvictsIdleBuckets(t *testing.T) {
+	k := NewKeyedLimiter[string](1, 1, WithIdleTimeout(100*time.Millisecond))
+	defer k.Close()
+	k.Allow("idle")
+	k.Allow("busy")
+
+	// the sweep runs at most once a second
+	deadline := time.Now().Add(3 * time.Second)
+	for k.Len() == 2 && time.Now().Before(deadline) {
+		k.Allow("busy")
+		time.Sleep(20 * time.Millisecond)
+	}
+	k.mutex.Lock()
+	_, idle := k.buckets["idle"]
+	_, busy := k.buckets["busy"]
+	k.mutex.Unlock()
+	if idle || !busy {
+		t.Errorf("after a sweep idle kept = %v, busy kept = %v, want only busy", idle, busy)
+	}
+	if !k.Allow("idle") {
+		t.Error("an evicted key did not start with a full bucket")
+	}
+}
+
+func TestKeyedLimiterKeepsIndebtedBuckets(t *testing.T) {
+	k := NewKeyedLimiter[string](1, 1, WithIdleTimeout(0))
+	k.idleTimeout = time.Millisecond
+	k.Allow("idle")
+	limiter := k.bucket("indebted")
+	limiter.mutex.Lock()
+	limiter.reserveLocked(time.Now(), 2, time.Hour)
+	limiter.mutex.Unlock()
+
+	k.sweep(time.Now().Add(time.Second))
+	_, idle := k.buckets["idle"]
+	_, indebted := k.buckets["indebted"]
+	if idle || !indebted {
+		t.Errorf("after a sweep idle kept = %v, indebted kept = %v, want only indebted", idle, indebted)
+	}
+}
+
+func TestKeyedLimiterWithoutEviction(t *testing.T) {
+	k := NewKeyedLimiter[string](1, 1, WithIdleTimeout(0))
+	k.Allow("a")
+	k.Close()
+	k.Close()
+	if k.Len() != 1 {
+		t.Errorf("Len() = %d, want the bucket kept", k.Len())
+	}
+}
+
+func TestKeyedLimiterConcurrent(t *testing.T) {
+	k := NewKeyedLimiter[int](Every(time.Hour), 10)
+	defer k.Close()
+	var wg sync.WaitGroup
+	allowed := make([]int, 4)
+	var mutex sync.Mutex
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				if k.Allow(g % 4) {
+					mutex.Lock()
+					allowed[g%4]++
+					mutex.Unlock()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for key, n := range allowed {
+		if n != 10 {
+			t.Errorf("key %d allowed %d events, want the burst of 10", key, n)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	ErrExceedsBurst    = errors.New("request exceeds limiter burst")
+	ErrDeadlineTooSoon = errors.New("rate limit wait would exceed context deadline")
+)
+
+// Limit is a rate of events per second
+type Limit float64
+
+// Inf lets every event through
+const Inf = Limit(math.MaxFloat64)
+
+// Every converts a minimum interval between events to a Limit; zero or negative is Inf
+func Every(interval time.Duration) Limit {
+	if interval <= 0 {
+		return Inf
+	}
+	return Limit(float64(time.Second) / float64(interval))
+}
+
+// Limiter is a token bucket: it holds up to burst tokens, refills at limit tokens per second, and each
+// event spends one. It is safe for concurrent use.
+type Limiter struct {
+	mutex  sync.Mutex
+	limit  Limit
+	burst  int
+	tokens float64
+	last   time.Time
+
+	lastEvent time.Time // when the latest reservation may act, to tell which tokens a cancellation can give back
+}
+
+// NewLimiter returns a limiter allowing limit events per second on average with bursts of up to burst
+// events, starting with a full bucket
+func NewLimiter(limit Limit, burst int) *Limiter {
+	return &Limiter{limit: limit, burst: burst, tokens: float64(burst), last: time.Now()}
+}
+
+// Allow reports whether an event may happen now, spending a token if so
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, spending n tokens if so
+func (l *Limiter) AllowN(n int) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	_, ok := l.reserveLocked(time.Now(), n, 0)
+	return ok
+}
+
+// Wait blocks until an event may happen or ctx is done
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n events may happen. It fails straight away with ErrExceedsBurst if n is more
+// than the burst size, and with ErrDeadlineTooSoon if ctx's deadline would pass before enough tokens
+// accumulate. If ctx is done while waiting, ctx.Err() is returned and the tokens are given back, except
+// for those that reservations made since then are already counting on.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	maxWait := time.Duration(math.MaxInt64)
+	now := time.Now()
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = deadline.Sub(now)
+	}
+
+	l.mutex.Lock()
+	if l.limit != Inf && n > l.burst {
+		burst := l.burst
+		l.mutex.Unlock()
+		return fmt.Errorf("%w: %d > %d", ErrExceedsBurst, n, burst)
+	}
+	wait, ok := l.reserveLocked(now, n, maxWait)
+	l.mutex.Unlock()
+	if !ok {
+		return ErrDeadlineTooSoon
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		l.cancelLocked(time.Now(), now.Add(wait), n)
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Tokens returns how many tokens are available now
+func (l *Limiter) Tokens() float64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.advanceLocked(time.Now())
+	return l.tokens
+}
+
+// SetLimit changes the refill rate, keeping the tokens accumulated so far
+func (l *Limiter) SetLimit(limit Limit) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.advanceLocked(time.Now())
+	l.limit = limit
+}
+
+// SetBurst changes the bucket size, dropping tokens above the new size
+func (l *Limiter) SetBurst(burst int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.advanceLocked(time.Now())
+	l.burst = burst
+	l.tokens = min(l.tokens, float64(burst))
+}
+
+// reserveLocked spends n tokens, letting the balance go negative by up to maxWait worth of refill,
+// and returns how long the caller must wait before acting
+func (l *Limiter) reserveLocked(now time.Time, n int, maxWait time.Duration) (time.Duration, bool) {
+	if l.limit == Inf {
+		return 0, true
+	}
+	l.advanceLocked(now)
+	remaining := l.tokens - float64(n)
+	if remaining >= 0 {
+		l.tokens = remaining
+		l.lastEvent = now
+		return 0, true
+	}
+	if l.limit <= 0 {
+		return 0, false
+	}
+	seconds := -remaining / float64(l.limit)
+	if seconds > maxWait.Seconds() {
+		return 0, false
+	}
+	l.tokens = remaining
+	wait := time.Duration(seconds * float64(time.Second))
+	l.lastEvent = now.Add(wait)
+	return wait, true
+}
+
+// cancelLocked gives back the n tokens of a reservation due at timeToAct whose caller stopped waiting.
+// Reservations made after it were due later because they queued behind it, and the tokens refilled
+// between timeToAct and the latest of them are already promised, so only the rest are restored.
+func (l *Limiter) cancelLocked(now, timeToAct time.Time, n int) {
+	if l.limit == Inf || l.limit <= 0 || !timeToAct.After(now) {
+		return
+	}
+	restore := float64(n) - l.lastEvent.Sub(timeToAct).Seconds()*float64(l.limit)
+	if restore <= 0 {
+		return
+	}
+	l.advanceLocked(now)
+	l.tokens = min(l.tokens+restore, float64(l.burst))
+	if l.lastEvent.Equal(timeToAct) {
+		// this was the latest reservation, so the one before it is now
+		previous := timeToAct.Add(-time.Duration(float64(n) / float64(l.limit) * float64(time.Second)))
+		if !previous.Before(now) {
+			l.lastEvent = previous
+		}
+	}
+}
+
+func (l *Limiter) advanceLocked(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		if l.limit > 0 {
+			l.tokens = min(l.tokens+elapsed.Seconds()*float64(l.limit), float64(l.burst))
+		}
+		l.last = now
+	}
+}
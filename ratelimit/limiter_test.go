@@ -0,0 +1,210 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEvery(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     Limit
+	}{
+		{time.Second, 1},
+		{100 * time.Millisecond, 10},
+		{2 * time.Second, 0.5},
+		{0, Inf},
+		{-time.Second, Inf},
+	}
+	for _, tt := range tests {
+		if got := Every(tt.interval); got != tt.want {
+			t.Errorf("Every(%v) = %v, want %v", tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestLimiterBucket(t *testing.T) {
+	start := time.Now()
+	l := NewLimiter(10, 3)
+	l.last = start
+
+	// each step: how far past start, how many tokens, whether they are granted
+	steps := []struct {
+		after time.Duration
+		n     int
+		want  bool
+	}{
+		{0, 1, true},
+		{0, 2, true},
+		{0, 1, false},
+		{50 * time.Millisecond, 1, false},
+		{100 * time.Millisecond, 1, true},
+		{100 * time.Millisecond, 1, false},
+		{time.Hour, 3, true}, // refill is capped at the burst
+		{time.Hour, 1, false},
+		{time.Hour + 200*time.Millisecond, 2, true},
+	}
+	for i, step := range steps {
+		_, ok := l.reserveLocked(start.Add(step.after), step.n, 0)
+		if ok != step.want {
+			t.Errorf("step %d: reserve(%v, %d) = %v, want %v (tokens %.2f)", i, step.after, step.n, ok, step.want, l.tokens)
+		}
+	}
+}
+
+func TestLimiterReserveWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    Limit
+		n        int
+		maxWait  time.Duration
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{"within burst", 10, 2, 0, 0, true},
+		{"short wait", 10, 3, time.Second, 100 * time.Millisecond, true},
+		{"wait too long", 10, 5, 200 * time.Millisecond, 0, false},
+		{"zero limit never refills", 0, 3, time.Hour, 0, false},
+		{"infinite", Inf, 1000, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			l := NewLimiter(tt.limit, 2)
+			l.last = now
+			wait, ok := l.reserveLocked(now, tt.n, tt.maxWait)
+			if ok != tt.wantOK || (wait-tt.wantWait).Abs() > time.Microsecond {
+				t.Errorf("reserve() = %v, %v, want %v, %v", wait, ok, tt.wantWait, tt.wantOK)
+			}
+			if !ok && tt.limit != Inf && l.tokens != 2 {
+				t.Errorf("a refused reservation spent tokens: %.2f left", l.tokens)
+			}
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	l := NewLimiter(1, 2)
+	if !l.Allow() || !l.Allow() {
+		t.Fatal("Allow() refused events within the burst")
+	}
+	if l.Allow() {
+		t.Error("Allow() accepted an event beyond the burst")
+	}
+	if l.AllowN(3) {
+		t.Error("AllowN() accepted more than the burst")
+	}
+	inf := NewLimiter(Inf, 0)
+	for i := 0; i < 100; i++ {
+		if !inf.Allow() {
+			t.Fatal("an Inf limiter refused an event")
+		}
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	l := NewLimiter(100, 1)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	// one free token, then five at 10ms each
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Errorf("6 waits at 100/s with burst 1 took %v, want about 50ms", elapsed)
+	}
+}
+
+func TestLimiterWaitErrors(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	soon, cancelSoon := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelSoon()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		n       int
+		wantErr error
+	}{
+		{"canceled", canceled, 1, context.Canceled},
+		{"exceeds burst", context.Background(), 3, ErrExceedsBurst},
+		{"deadline too soon", soon, 2, ErrDeadlineTooSoon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(1, 2)
+			l.AllowN(2)
+			if err := l.WaitN(tt.ctx, tt.n); !errors.Is(err, tt.wantErr) {
+				t.Errorf("WaitN(%d) error = %v, want %v", tt.n, err, tt.wantErr)
+			}
+			if tokens := l.Tokens(); tokens < 0 {
+				t.Errorf("a failed WaitN() left %.2f tokens, want none spent", tokens)
+			}
+		})
+	}
+}
+
+func TestLimiterWaitCanceledReturnsTokens(t *testing.T) {
+	l := NewLimiter(10, 1)
+	l.Allow()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	// the wait is 100ms but the deadline-less context is canceled after 10ms
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() error = %v, want Canceled", err)
+	}
+	if tokens := l.Tokens(); tokens < 0 || tokens > 1 {
+		t.Errorf("Tokens() after a canceled Wait() = %.2f, want the reservation given back", tokens)
+	}
+}
+
+func TestLimiterCancelKeepsLaterReservations(t *testing.T) {
+	now := time.Now()
+	l := NewLimiter(10, 1)
+	l.last = now
+	l.reserveLocked(now, 1, 0)
+	first, _ := l.reserveLocked(now, 1, time.Second)
+	second, _ := l.reserveLocked(now, 1, time.Second)
+
+	// the second reservation counts on the token refilled while the first waits, so nothing comes back
+	l.cancelLocked(now, now.Add(first), 1)
+	if math.Abs(l.tokens+2) > 1e-9 {
+		t.Errorf("tokens after canceling a reservation with one behind it = %.2f, want -2", l.tokens)
+	}
+	// the latest reservation has nothing queued behind it and is given back in full
+	l.cancelLocked(now, now.Add(second), 1)
+	if math.Abs(l.tokens+1) > 1e-9 {
+		t.Errorf("tokens after canceling the latest reservation = %.2f, want -1", l.tokens)
+	}
+	if want := now.Add(first); (l.lastEvent.Sub(want)).Abs() > time.Microsecond {
+		t.Errorf("lastEvent = %v after the cancellation, want %v", l.lastEvent, want)
+	}
+}
+
+func TestLimiterSetLimitAndBurst(t *testing.T) {
+	l := NewLimiter(0, 5)
+	l.AllowN(5)
+	if tokens := l.Tokens(); tokens != 0 {
+		t.Fatalf("Tokens() = %v, want 0", tokens)
+	}
+	l.SetLimit(1000)
+	time.Sleep(20 * time.Millisecond)
+	if tokens := l.Tokens(); tokens < 5-1e-9 {
+		t.Errorf("Tokens() after SetLimit(1000) = %v, want a refilled bucket", tokens)
+	}
+	l.SetBurst(2)
+	if tokens := l.Tokens(); math.Abs(tokens-2) > 1e-9 {
+		t.Errorf("Tokens() after SetBurst(2) = %v, want 2", tokens)
+	}
+	if l.AllowN(3) {
+		t.Error("AllowN(3) accepted more than the new burst")
+	}
+}
@@ -0,0 +1,69 @@
+package retry
+
+import "time"
+
+// Option customizes Do
+type Option func(*config)
+
+type config struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	jitter      bool
+	retryIf     func(error) bool
+	onRetry     func(attempt int, err error, delay time.Duration)
+}
+
+func defaultConfig() config {
+	return config{
+		maxAttempts: 3,
+		baseDelay:   100 * time.Millisecond,
+		maxDelay:    10 * time.Second,
+	}
+}
+
+// WithMaxAttempts sets how many times fn is called in total, including the first call (default 3).
+// Values below 1 are treated as 1.
+func WithMaxAttempts(attempts int) Option {
+	return func(c *config) {
+		c.maxAttempts = max(attempts, 1)
+	}
+}
+
+// WithExponentialBackoff waits base before the first retry and doubles the wait after each further
+// failure, never waiting longer than cap (default 100ms doubling up to 10s)
+func WithExponentialBackoff(base, cap time.Duration) Option {
+	return func(c *config) {
+		c.baseDelay = max(base, 0)
+		c.maxDelay = max(cap, c.baseDelay)
+	}
+}
+
+// WithConstantBackoff waits delay between every attempt
+func WithConstantBackoff(delay time.Duration) Option {
+	return WithExponentialBackoff(delay, delay)
+}
+
+// WithJitter randomizes each wait uniformly between zero and the computed backoff ("full jitter"),
+// so callers that failed together don't retry in lockstep
+func WithJitter() Option {
+	return func(c *config) {
+		c.jitter = true
+	}
+}
+
+// RetryIf makes Do retry only errors for which isRetryable returns true; any other error is returned
+// straight away. By default every error is retried.
+func RetryIf(isRetryable func(error) bool) Option {
+	return func(c *config) {
+		c.retryIf = isRetryable
+	}
+}
+
+// WithOnRetry calls fn before each wait with the number of the attempt that just failed, its error and
+// how long Do is about to sleep, e.g. to log retries
+func WithOnRetry(fn func(attempt int, err error, delay time.Duration)) Option {
+	return func(c *config) {
+		c.onRetry = fn
+	}
+}
@@ -0,0 +1,96 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Error is returned by Do when fn never succeeded: Err is the last error fn returned (joined with the
+// context's error if ctx ended first) and Attempts is how many times fn was called
+type Error struct {
+	Attempts int
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("retry: failed after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Do calls fn until it returns nil, an error RetryIf rejects, the attempts run out or ctx is done,
+// sleeping between attempts as configured by opts. Failures are returned as an *Error wrapping fn's
+// last error, so errors.Is and errors.As still see it; if ctx ends, errors.Is(err, ctx.Err()) holds too.
+func Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	_, err := DoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// DoValue is Do for functions that also return a value, which is returned from the successful attempt
+func DoValue[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...Option) (T, error) {
+	c := defaultConfig()
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, &Error{Attempts: 0, Err: err}
+	}
+
+	var timer *time.Timer
+	for attempt := 1; ; attempt++ {
+		value, err := fn(ctx)
+		if err == nil {
+			return value, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return zero, &Error{Attempts: attempt, Err: joinContextError(ctxErr, err)}
+		}
+		if attempt >= c.maxAttempts || (c.retryIf != nil && !c.retryIf(err)) {
+			return zero, &Error{Attempts: attempt, Err: err}
+		}
+
+		delay := c.backoff(attempt)
+		if hook := c.onRetry; hook != nil {
+			hook(attempt, err, delay)
+		}
+		if timer == nil {
+			timer = time.NewTimer(delay)
+			defer timer.Stop()
+		} else {
+			timer.Reset(delay)
+		}
+		select {
+		case <-ctx.Done():
+			return zero, &Error{Attempts: attempt, Err: joinContextError(ctx.Err(), err)}
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait after the given failed attempt
+func (c config) backoff(attempt int) time.Duration {
+	delay := c.baseDelay
+	for i := 1; i < attempt && delay < c.maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, c.maxDelay)
+	if c.jitter && delay > 0 {
+		delay = rand.N(delay + 1)
+	}
+	return delay
+}
+
+func joinContextError(ctxErr, err error) error {
+	if err == ctxErr {
+		return err
+	}
+	return fmt.Errorf("%w (last error: %w)", ctxErr, err)
+}
@@ -0,0 +1,201 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+func TestDo(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	tests := []struct {
+		name         string
+		results      []error
+		opts         []Option
+		wantCalls    int
+		wantErr      error
+		wantAttempts int
+	}{
+		{"first try", []error{nil}, nil, 1, nil, 0},
+		{"succeeds on retry", []error{errTransient, errTransient, nil}, nil, 3, nil, 0},
+		{"default three attempts", []error{errTransient, errTransient, errTransient, nil}, nil, 3, errTransient, 3},
+		{"max attempts", []error{errTransient, errTransient, errTransient, errTransient, nil},
+			[]Option{WithMaxAttempts(5)}, 5, nil, 0},
+		{"max attempts below one", []error{errTransient, nil}, []Option{WithMaxAttempts(0)}, 1, errTransient, 1},
+		{"retry if rejects", []error{errTransient, errPermanent, nil},
+			[]Option{RetryIf(func(err error) bool { return errors.Is(err, errTransient) })}, 2, errPermanent, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			opts := append([]Option{WithConstantBackoff(time.Millisecond)}, tt.opts...)
+			err := Do(context.Background(), func(context.Context) error {
+				err := tt.results[calls]
+				calls++
+				return err
+			}, opts...)
+
+			if calls != tt.wantCalls {
+				t.Errorf("fn was called %d times, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Do() error = %v", err)
+				}
+				return
+			}
+			var retryErr *Error
+			if !errors.As(err, &retryErr) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want an *Error wrapping %v", err, tt.wantErr)
+			}
+			if retryErr.Attempts != tt.wantAttempts {
+				t.Errorf("Error.Attempts = %d, want %d", retryErr.Attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDoValue(t *testing.T) {
+	calls := 0
+	got, err := DoValue(context.Background(), func(context.Context) (string, error) {
+		calls++
+		if calls < 2 {
+			return "partial", errTransient
+		}
+		return "done", nil
+	}, WithConstantBackoff(time.Millisecond))
+	if err != nil || got != "done" {
+		t.Errorf("DoValue() = %q, %v, want done", got, err)
+	}
+
+	got, err = DoValue(context.Background(), func(context.Context) (string, error) {
+		return "partial", errTransient
+	}, WithMaxAttempts(1))
+	if got != "" || !errors.Is(err, errTransient) {
+		t.Errorf("DoValue() = %q, %v, want the zero value and the last error", got, err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want []time.Duration // waits after attempts 1, 2, ...
+	}{
+		{"default", nil, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}},
+		{"capped", []Option{WithExponentialBackoff(time.Second, 5*time.Second)},
+			[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"constant", []Option{WithConstantBackoff(30 * time.Millisecond)},
+			[]time.Duration{30 * time.Millisecond, 30 * time.Millisecond, 30 * time.Millisecond}},
+		{"cap below base", []Option{WithExponentialBackoff(time.Second, time.Millisecond)}, []time.Duration{time.Second, time.Second}},
+		{"zero base", []Option{WithExponentialBackoff(0, time.Second)}, []time.Duration{0, 0}},
+		{"many attempts", []Option{WithExponentialBackoff(time.Millisecond, time.Hour)},
+			[]time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultConfig()
+			for _, opt := range tt.opts {
+				opt(&c)
+			}
+			for i, want := range tt.want {
+				if got := c.backoff(i + 1); got != want {
+					t.Errorf("backoff(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+
+	c := defaultConfig()
+	WithExponentialBackoff(time.Millisecond, time.Hour)(&c)
+	if got := c.backoff(200); got != time.Hour {
+		t.Errorf("backoff(200) = %v, want the cap", got)
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	c := defaultConfig()
+	WithExponentialBackoff(100*time.Millisecond, time.Second)(&c)
+	WithJitter()(&c)
+	distinct := map[time.Duration]bool{}
+	for i := 0; i < 200; i++ {
+		delay := c.backoff(2)
+		if delay < 0 || delay > 200*time.Millisecond {
+			t.Fatalf("backoff(2) with jitter = %v, want within [0, 200ms]", delay)
+		}
+		distinct[delay] = true
+	}
+	if len(distinct) < 100 {
+		t.Errorf("backoff(2) with jitter gave %d distinct delays in 200 calls, want them spread out", len(distinct))
+	}
+}
+
+func TestDoOnRetry(t *testing.T) {
+	type retried struct {
+		attempt int
+		delay   time.Duration
+	}
+	var got []retried
+	_ = Do(context.Background(), func(context.Context) error { return errTransient },
+		WithMaxAttempts(3),
+		WithExponentialBackoff(time.Millisecond, time.Second),
+		WithOnRetry(func(attempt int, err error, delay time.Duration) {
+			if !errors.Is(err, errTransient) {
+				t.Errorf("OnRetry err = %v, want the attempt's error", err)
+			}
+			got = append(got, retried{attempt, delay})
+		}))
+	want := []retried{{1, time.Millisecond}, {2, 2 * time.Millisecond}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("OnRetry calls = %v, want %v", got, want)
+	}
+}
+
+func TestDoContext(t *testing.T) {
+	t.Run("already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		calls := 0
+		err := Do(ctx, func(context.Context) error { calls++; return nil })
+		var retryErr *Error
+		if calls != 0 || !errors.As(err, &retryErr) || retryErr.Attempts != 0 || !errors.Is(err, context.Canceled) {
+			t.Errorf("Do() on a canceled context = %v after %d calls, want Canceled without calling fn", err, calls)
+		}
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := Do(ctx, func(context.Context) error { return errTransient },
+			WithMaxAttempts(10), WithConstantBackoff(time.Hour))
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Do() took %v, want it to stop when the context expires", elapsed)
+		}
+		var retryErr *Error
+		if !errors.As(err, &retryErr) || retryErr.Attempts != 1 {
+			t.Fatalf("Do() error = %v, want an *Error after 1 attempt", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errTransient) {
+			t.Errorf("Do() error = %v, want both DeadlineExceeded and the last error", err)
+		}
+	})
+
+	t.Run("fn returns the context error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := Do(ctx, func(ctx context.Context) error {
+			cancel()
+			return ctx.Err()
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Do() error = %v, want Canceled", err)
+		}
+		const want = "retry: failed after 1 attempt(s): context canceled"
+		if err.Error() != want {
+			t.Errorf("Do() error = %q, want %q without the context error repeated", err, want)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package timeutil
+
+import "time"
+
+// StartOfDay returns midnight at the start of t's day in loc (t's own location if loc is nil).
+// On days when a DST change skips midnight, it is the first instant of the day instead.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	t = in(t, loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay returns the last nanosecond of t's day in loc (t's own location if loc is nil)
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	return StartOfDay(t, loc).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// StartOfWeek returns the start of the week containing t in loc (t's own location if loc is nil),
+// with weeks beginning on weekStart, e.g. time.Monday for ISO weeks
+func StartOfWeek(t time.Time, loc *time.Location, weekStart time.Weekday) time.Time {
+	day := StartOfDay(t, loc)
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// StartOfMonth returns midnight on the first day of t's month in loc (t's own location if loc is nil)
+func StartOfMonth(t time.Time, loc *time.Location) time.Time {
+	t = in(t, loc)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfMonth returns the last nanosecond of t's month in loc (t's own location if loc is nil)
+func EndOfMonth(t time.Time, loc *time.Location) time.Time {
+	return StartOfMonth(t, loc).AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+func in(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
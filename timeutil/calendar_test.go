@@ -0,0 +1,60 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestCalendarBoundaries(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	newYork := mustLoadLocation(t, "America/New_York")
+	// 2024-03-06 is a Wednesday; 23:30 UTC is already Thursday in Tokyo
+	at := time.Date(2024, 3, 6, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"StartOfDay own location", StartOfDay(at, nil), time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"StartOfDay in Tokyo", StartOfDay(at, tokyo), time.Date(2024, 3, 7, 0, 0, 0, 0, tokyo)},
+		{"EndOfDay", EndOfDay(at, nil), time.Date(2024, 3, 6, 23, 59, 59, 999999999, time.UTC)},
+		{"StartOfWeek Monday", StartOfWeek(at, nil, time.Monday), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"StartOfWeek Sunday", StartOfWeek(at, nil, time.Sunday), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"StartOfWeek on its first day", StartOfWeek(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC), nil, time.Monday), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"StartOfWeek Thursday in Tokyo", StartOfWeek(at, tokyo, time.Thursday), time.Date(2024, 3, 7, 0, 0, 0, 0, tokyo)},
+		{"StartOfMonth", StartOfMonth(at, nil), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"EndOfMonth leap year", EndOfMonth(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), nil), time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC)},
+		{"EndOfMonth December", EndOfMonth(time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), nil), time.Date(2023, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{"StartOfMonth across the year in New York", StartOfMonth(time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), newYork), time.Date(2023, 12, 1, 0, 0, 0, 0, newYork)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) || tt.got.Location() != tt.want.Location() {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarAcrossDST(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	// clocks went forward at 02:00 on 2024-03-10, making the day 23 hours long
+	noon := time.Date(2024, 3, 10, 12, 0, 0, 0, newYork)
+	start, end := StartOfDay(noon, nil), EndOfDay(noon, nil)
+	if got := end.Sub(start) + time.Nanosecond; got != 23*time.Hour {
+		t.Errorf("2024-03-10 in New York lasts %v, want 23h", got)
+	}
+	if _, offset := start.Zone(); offset != -5*3600 {
+		t.Errorf("StartOfDay() offset = %d, want EST", offset)
+	}
+}
@@ -0,0 +1,108 @@
+package timeutil
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a source of time. Code that reads the time through a Clock instead of calling time.Now
+// directly can be driven by a FakeClock in tests. A Clock's Now method value also fits APIs that take
+// a func() time.Time, such as id_gen.NewHLCWithClock.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+// RealClock is the Clock backed by the time package
+type RealClock struct{}
+
+// Real is the wall clock
+var Real Clock = RealClock{}
+
+func (RealClock) Now() time.Time                         { return time.Now() }
+func (RealClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (RealClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (RealClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+// FakeClock is a Clock that only moves when told to. After and Sleep block until Advance or Set moves
+// the clock past their deadline. It is safe for concurrent use.
+type FakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewFakeClock returns a FakeClock frozen at start
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance moves the clock forward by d, waking the After and Sleep calls whose deadline it reaches
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.setLocked(c.now.Add(d))
+}
+
+// Set moves the clock to t, which may be in the past; waiters are woken only when their deadline is reached
+func (c *FakeClock) Set(t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.setLocked(t)
+}
+
+// Waiters returns the number of pending After and Sleep calls, so a test can wait until the code
+// under test is blocked before advancing the clock
+func (c *FakeClock) Waiters() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.waiters)
+}
+
+func (c *FakeClock) setLocked(t time.Time) {
+	c.now = t
+	sort.SliceStable(c.waiters, func(i, j int) bool { return c.waiters[i].deadline.Before(c.waiters[j].deadline) })
+	fired := 0
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(t) {
+			break
+		}
+		waiter.ch <- t
+		fired++
+	}
+	c.waiters = c.waiters[fired:]
+}
@@ -0,0 +1,132 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRealClock(t *testing.T) {
+	before := time.Now()
+	now := Real.Now()
+	if now.Before(before) || Real.Since(before) < 0 {
+		t.Errorf("Real.Now() = %v, went back from %v", now, before)
+	}
+	start := time.Now()
+	Real.Sleep(time.Millisecond)
+	<-Real.After(time.Millisecond)
+	if elapsed := time.Since(start); elapsed < 2*time.Millisecond {
+		t.Errorf("Sleep and After returned after %v, want at least 2ms", elapsed)
+	}
+}
+
+func TestFakeClockNow(t *testing.T) {
+	c := NewFakeClock(epoch)
+	tests := []struct {
+		name string
+		move func()
+		want time.Time
+	}{
+		{"frozen", func() {}, epoch},
+		{"advance", func() { c.Advance(90 * time.Second) }, epoch.Add(90 * time.Second)},
+		{"set", func() { c.Set(epoch.Add(time.Hour)) }, epoch.Add(time.Hour)},
+		{"set backwards", func() { c.Set(epoch.Add(-time.Hour)) }, epoch.Add(-time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.move()
+			if got := c.Now(); !got.Equal(tt.want) {
+				t.Errorf("Now() = %v, want %v", got, tt.want)
+			}
+			if got := c.Since(epoch); got != tt.want.Sub(epoch) {
+				t.Errorf("Since(epoch) = %v, want %v", got, tt.want.Sub(epoch))
+			}
+		})
+	}
+}
+
+func TestFakeClockAfter(t *testing.T) {
+	c := NewFakeClock(epoch)
+	immediate := c.After(0)
+	select {
+	case got := <-immediate:
+		if !got.Equal(epoch) {
+			t.Errorf("After(0) fired with %v, want %v", got, epoch)
+		}
+	default:
+		t.Fatal("After(0) did not fire straight away")
+	}
+
+	late, early := c.After(10*time.Second), c.After(5*time.Second)
+	if c.Waiters() != 2 {
+		t.Fatalf("Waiters() = %d, want 2", c.Waiters())
+	}
+	fired := func(ch <-chan time.Time) bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+
+	c.Advance(4 * time.Second)
+	if fired(early) || fired(late) {
+		t.Fatal("a waiter fired before its deadline")
+	}
+	c.Advance(time.Second)
+	if !fired(early) || fired(late) {
+		t.Fatal("reaching the first deadline did not fire exactly the first waiter")
+	}
+	// moving back doesn't fire anything
+	c.Set(epoch)
+	if fired(late) || c.Waiters() != 1 {
+		t.Fatal("Set() into the past fired a waiter")
+	}
+	c.Set(epoch.Add(time.Minute))
+	select {
+	case got := <-late:
+		if !got.Equal(epoch.Add(time.Minute)) {
+			t.Errorf("After() fired with %v, want the time the clock was set to", got)
+		}
+	default:
+		t.Fatal("Set() past the deadline did not fire the waiter")
+	}
+	if c.Waiters() != 0 {
+		t.Errorf("Waiters() = %d, want 0", c.Waiters())
+	}
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	c := NewFakeClock(epoch)
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(time.Hour)
+		close(done)
+	}()
+	for c.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Advance(59 * time.Minute)
+	select {
+	case <-done:
+		t.Fatal("Sleep() returned before the clock reached its deadline")
+	case <-time.After(5 * time.Millisecond):
+	}
+	c.Advance(time.Minute)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Sleep() did not return once the clock reached its deadline")
+	}
+}
+
+func TestClockImplementations(t *testing.T) {
+	var _ Clock = RealClock{}
+	var _ Clock = NewFakeClock(epoch)
+	var now func() time.Time = NewFakeClock(epoch).Now
+	if !now().Equal(epoch) {
+		t.Errorf("Now method value = %v, want %v", now(), epoch)
+	}
+}
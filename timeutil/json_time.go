@@ -0,0 +1,59 @@
+package timeutil
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// RFC3339Milli is the layout of RFC3339MilliTime: RFC 3339 in UTC with exactly three fractional digits
+const RFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
+
+// RFC3339MilliTime is a time.Time that marshals as an RFC 3339 UTC string with millisecond precision,
+// e.g. "2024-05-01T12:00:00.123Z", the format JavaScript's Date.toISOString produces. Unmarshaling
+// accepts any RFC 3339 time. The zero value marshals as null and null unmarshals to the zero value;
+// IsZero lets omitzero leave it out.
+type RFC3339MilliTime struct {
+	time.Time
+}
+
+// NewRFC3339MilliTime wraps t
+func NewRFC3339MilliTime(t time.Time) RFC3339MilliTime {
+	return RFC3339MilliTime{Time: t}
+}
+
+// String formats the time in the RFC3339Milli layout
+func (t RFC3339MilliTime) String() string {
+	return t.UTC().Format(RFC3339Milli)
+}
+
+func (t RFC3339MilliTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+func (t *RFC3339MilliTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = RFC3339MilliTime{}
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("RFC 3339 time must be a JSON string, got %s", data)
+	}
+	return t.UnmarshalText(data[1 : len(data)-1])
+}
+
+func (t RFC3339MilliTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *RFC3339MilliTime) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse(time.RFC3339Nano, string(text))
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
@@ -0,0 +1,89 @@
+package timeutil
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRFC3339MilliTimeMarshal(t *testing.T) {
+	plusTwo := time.FixedZone("+02", 2*3600)
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"zero is null", time.Time{}, `null`},
+		{"whole second", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), `"2024-05-01T12:00:00.000Z"`},
+		{"truncated to milliseconds", time.Date(2024, 5, 1, 12, 0, 0, 123987654, time.UTC), `"2024-05-01T12:00:00.123Z"`},
+		{"converted to UTC", time.Date(2024, 5, 1, 14, 0, 0, 5000000, plusTwo), `"2024-05-01T12:00:00.005Z"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(NewRFC3339MilliTime(tt.t))
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRFC3339MilliTimeUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"milliseconds", `"2024-05-01T12:00:00.123Z"`, time.Date(2024, 5, 1, 12, 0, 0, 123000000, time.UTC), false},
+		{"no fraction", `"2024-05-01T12:00:00Z"`, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), false},
+		{"nanoseconds and offset", `"2024-05-01T14:00:00.000000001+02:00"`, time.Date(2024, 5, 1, 12, 0, 0, 1, time.UTC), false},
+		{"null", `null`, time.Time{}, false},
+		{"not RFC 3339", `"May 1 2024"`, time.Time{}, true},
+		{"number", `1714564800`, time.Time{}, true},
+		{"unterminated", `"2024-05-01T12:00:00Z`, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRFC3339MilliTime(time.Now())
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, got.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestRFC3339MilliTimeInStructs(t *testing.T) {
+	type event struct {
+		At   RFC3339MilliTime `json:"at"`
+		Seen RFC3339MilliTime `json:"seen,omitzero"`
+	}
+	in := event{At: NewRFC3339MilliTime(time.Date(2024, 5, 1, 12, 0, 0, 7000000, time.UTC))}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"at":"2024-05-01T12:00:00.007Z"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+	var out event
+	if err := json.Unmarshal(data, &out); err != nil || !out.At.Equal(in.At.Time) || !out.Seen.IsZero() {
+		t.Errorf("Unmarshal() = %+v, %v, want the event back", out, err)
+	}
+
+	// as a map key the text form is used
+	keyed, err := json.Marshal(map[RFC3339MilliTime]int{in.At: 1})
+	if err != nil || string(keyed) != `{"2024-05-01T12:00:00.007Z":1}` {
+		t.Errorf("Marshal(map) = %s, %v", keyed, err)
+	}
+	if s := in.At.String(); s != "2024-05-01T12:00:00.007Z" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -0,0 +1,84 @@
+package timeutil
+
+import "time"
+
+// Range is the half-open interval [Start, End). A range whose End is not after its Start is empty.
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewRange returns [start, end), swapping the bounds if end is before start
+func NewRange(start, end time.Time) Range {
+	if end.Before(start) {
+		start, end = end, start
+	}
+	return Range{Start: start, End: end}
+}
+
+// IsEmpty reports whether the range contains no instant
+func (r Range) IsEmpty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Duration returns End - Start, or 0 for an empty range
+func (r Range) Duration() time.Duration {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Contains reports whether t lies within the range
+func (r Range) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// Overlaps reports whether the two ranges share at least one instant; ranges that merely touch don't
+func (r Range) Overlaps(other Range) bool {
+	return !r.IsEmpty() && !other.IsEmpty() && r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Intersect returns the range both ranges cover, and false if they don't overlap
+func (r Range) Intersect(other Range) (Range, bool) {
+	if !r.Overlaps(other) {
+		return Range{}, false
+	}
+	start, end := r.Start, r.End
+	if other.Start.After(start) {
+		start = other.Start
+	}
+	if other.End.Before(end) {
+		end = other.End
+	}
+	return Range{Start: start, End: end}, true
+}
+
+// Each calls fn with consecutive sub-ranges of length step covering r, the last one cut short at End,
+// stopping early if fn returns false. It does nothing if step is not positive.
+func (r Range) Each(step time.Duration, fn func(Range) bool) {
+	if step <= 0 {
+		return
+	}
+	for start := r.Start; start.Before(r.End); start = start.Add(step) {
+		end := start.Add(step)
+		if end.After(r.End) {
+			end = r.End
+		}
+		if !fn(Range{Start: start, End: end}) {
+			return
+		}
+	}
+}
+
+// EachDay calls fn with the part of r falling on each calendar day in loc (Start's location if loc is
+// nil), stopping early if fn returns false. Days follow the calendar, so they can be 23 or 25 hours
+// long around DST changes.
+func (r Range) EachDay(loc *time.Location, fn func(Range) bool) {
+	for day := StartOfDay(r.Start, loc); day.Before(r.End); day = day.AddDate(0, 0, 1) {
+		part, ok := r.Intersect(Range{Start: day, End: day.AddDate(0, 0, 1)})
+		if ok && !fn(part) {
+			return
+		}
+	}
+}
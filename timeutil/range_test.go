@@ -0,0 +1,168 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+// at returns epoch plus h hours
+func at(h float64) time.Time {
+	return epoch.Add(time.Duration(h * float64(time.Hour)))
+}
+
+func hours(start, end float64) Range {
+	return Range{Start: at(start), End: at(end)}
+}
+
+func TestNewRange(t *testing.T) {
+	if r := NewRange(at(5), at(2)); r != hours(2, 5) {
+		t.Errorf("NewRange(5h, 2h) = %v, want the bounds swapped", r)
+	}
+	if r := NewRange(at(1), at(3)); r != hours(1, 3) {
+		t.Errorf("NewRange(1h, 3h) = %v", r)
+	}
+}
+
+func TestRangeBasics(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range
+		empty    bool
+		duration time.Duration
+	}{
+		{"normal", hours(1, 3), false, 2 * time.Hour},
+		{"instant", hours(1, 1), true, 0},
+		{"inverted", hours(3, 1), true, 0},
+		{"zero", Range{}, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.IsEmpty(); got != tt.empty {
+				t.Errorf("IsEmpty() = %v, want %v", got, tt.empty)
+			}
+			if got := tt.r.Duration(); got != tt.duration {
+				t.Errorf("Duration() = %v, want %v", got, tt.duration)
+			}
+		})
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	r := hours(1, 3)
+	tests := []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(0.5), false},
+		{at(1), true},
+		{at(2), true},
+		{at(3).Add(-time.Nanosecond), true},
+		{at(3), false},
+	}
+	for _, tt := range tests {
+		if got := r.Contains(tt.t); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestRangeOverlapsAndIntersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Range
+		want   Range
+		wantOK bool
+	}{
+		{"partial", hours(1, 4), hours(3, 6), hours(3, 4), true},
+		{"contained", hours(1, 10), hours(2, 3), hours(2, 3), true},
+		{"identical", hours(1, 2), hours(1, 2), hours(1, 2), true},
+		{"touching", hours(1, 2), hours(2, 3), Range{}, false},
+		{"disjoint", hours(1, 2), hours(5, 6), Range{}, false},
+		{"empty inside", hours(1, 5), hours(2, 2), Range{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, pair := range [][2]Range{{tt.a, tt.b}, {tt.b, tt.a}} {
+				if got := pair[0].Overlaps(pair[1]); got != tt.wantOK {
+					t.Errorf("%v.Overlaps(%v) = %v, want %v", pair[0], pair[1], got, tt.wantOK)
+				}
+				got, ok := pair[0].Intersect(pair[1])
+				if ok != tt.wantOK || got != tt.want {
+					t.Errorf("%v.Intersect(%v) = %v, %v, want %v, %v", pair[0], pair[1], got, ok, tt.want, tt.wantOK)
+				}
+			}
+		})
+	}
+}
+
+func collect(each func(func(Range) bool), limit int) []Range {
+	var parts []Range
+	each(func(r Range) bool {
+		parts = append(parts, r)
+		return len(parts) < limit
+	})
+	return parts
+}
+
+func TestRangeEach(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     Range
+		step  time.Duration
+		limit int
+		want  []Range
+	}{
+		{"even", hours(0, 3), time.Hour, 10, []Range{hours(0, 1), hours(1, 2), hours(2, 3)}},
+		{"last cut short", hours(0, 2.5), time.Hour, 10, []Range{hours(0, 1), hours(1, 2), hours(2, 2.5)}},
+		{"stops early", hours(0, 5), time.Hour, 2, []Range{hours(0, 1), hours(1, 2)}},
+		{"step larger than range", hours(0, 1), 5 * time.Hour, 10, []Range{hours(0, 1)}},
+		{"empty range", hours(2, 2), time.Hour, 10, nil},
+		{"zero step", hours(0, 3), 0, 10, nil},
+		{"negative step", hours(0, 3), -time.Hour, 10, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collect(func(fn func(Range) bool) { tt.r.Each(tt.step, fn) }, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Each() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Each()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRangeEachDay(t *testing.T) {
+	r := Range{Start: time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 3, 6, 0, 0, 0, time.UTC)}
+	got := collect(func(fn func(Range) bool) { r.EachDay(nil, fn) }, 10)
+	want := []time.Duration{6 * time.Hour, 24 * time.Hour, 6 * time.Hour}
+	if len(got) != len(want) {
+		t.Fatalf("EachDay() = %v, want %d parts", got, len(want))
+	}
+	for i, part := range got {
+		if part.Duration() != want[i] {
+			t.Errorf("EachDay()[%d] = %v lasting %v, want %v", i, part, part.Duration(), want[i])
+		}
+	}
+	if got := collect(func(fn func(Range) bool) { r.EachDay(nil, fn) }, 1); len(got) != 1 {
+		t.Errorf("EachDay() kept going after fn returned false: %v", got)
+	}
+}
+
+func TestRangeEachDayAcrossDST(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	r := Range{Start: time.Date(2024, 3, 9, 0, 0, 0, 0, newYork), End: time.Date(2024, 3, 12, 0, 0, 0, 0, newYork)}
+	got := collect(func(fn func(Range) bool) { r.EachDay(newYork, fn) }, 10)
+	want := []time.Duration{24 * time.Hour, 23 * time.Hour, 24 * time.Hour}
+	if len(got) != len(want) {
+		t.Fatalf("EachDay() = %v, want %d days", got, len(want))
+	}
+	for i, part := range got {
+		if part.Duration() != want[i] {
+			t.Errorf("day %d lasts %v, want %v", i, part.Duration(), want[i])
+		}
+	}
+}